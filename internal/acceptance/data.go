@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
@@ -60,6 +61,9 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// recorder records or replays the requests made during this test, when running in either mode
+	recorder *common.Recorder
 }
 
 // BuildTestData generates some test data for the given resource
@@ -96,6 +100,19 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 		Secondary: os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"),
 	}
 
+	if recorder := newRecorder(t); recorder != nil {
+		// these values must be stable between recording and replaying, so are stored within the Cassette
+		testData.RandomInteger = recordedInt(recorder, "random_integer", func() int { return testData.RandomInteger })
+		testData.RandomString = recorder.Variable("random_string", func() string { return testData.RandomString })
+		testData.Locations = Regions{
+			Primary:   recorder.Variable("location_primary", func() string { return testData.Locations.Primary }),
+			Secondary: recorder.Variable("location_secondary", func() string { return testData.Locations.Secondary }),
+			Ternary:   recorder.Variable("location_ternary", func() string { return testData.Locations.Ternary }),
+		}
+		testData.Subscriptions = recordedSubscriptions(recorder)
+		testData.recorder = recorder
+	}
+
 	return testData
 }
 
@@ -134,6 +151,12 @@ func (td *TestData) RandomStringOfLength(len int) string {
 		panic("Invalid Test: RandomStringOfLength: length argument must be between 1 and 1024 characters")
 	}
 
+	if td.recorder != nil {
+		return td.recorder.Variable(fmt.Sprintf("random_string_%d", len), func() string {
+			return randString(len)
+		})
+	}
+
 	return randString(len)
 }
 
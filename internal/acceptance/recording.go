@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+const (
+	// recordingModeEnvVar is the environment variable used to run the Acceptance Tests in either
+	// `record` or `replay` mode, when unset the Acceptance Tests run against Azure as normal
+	recordingModeEnvVar = "ARM_TEST_RECORDING_MODE"
+
+	// recordingsDirectoryEnvVar is the environment variable used to override the directory containing
+	// the recorded Cassettes, which defaults to `testdata/recordings` within the package being tested
+	recordingsDirectoryEnvVar = "ARM_TEST_RECORDINGS_DIR"
+
+	// recordedSecondarySubscriptionId is the placeholder for the Secondary Subscription ID used within recorded Cassettes
+	recordedSecondarySubscriptionId = "00000000-0000-0000-0000-000000000004"
+)
+
+// recordingLock ensures that only a single recorded test runs at once, since the shared test client is
+// bound to the Recorder for the test which is currently running
+var recordingLock = &sync.Mutex{}
+
+// recorders caches the Recorder for each test, since BuildTestData can be called multiple times within a test
+var (
+	recorders     = map[*testing.T]*common.Recorder{}
+	recordersLock = &sync.Mutex{}
+)
+
+var invalidCassetteNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_\-]+`)
+
+// newRecorder returns a Recorder for this test when running in either `record` or `replay` mode, otherwise nil
+func newRecorder(t *testing.T) *common.Recorder {
+	mode := common.RecordingMode(os.Getenv(recordingModeEnvVar))
+	if mode == "" {
+		return nil
+	}
+
+	recordersLock.Lock()
+	defer recordersLock.Unlock()
+	if recorder, ok := recorders[t]; ok {
+		return recorder
+	}
+
+	directory := os.Getenv(recordingsDirectoryEnvVar)
+	if directory == "" {
+		directory = filepath.Join("testdata", "recordings")
+	}
+	path := filepath.Join(directory, invalidCassetteNameCharacters.ReplaceAllString(t.Name(), "_")+".json")
+
+	recorder, err := common.NewRecorder(mode, path)
+	if err != nil {
+		t.Fatalf("building recorder for %q: %+v", t.Name(), err)
+	}
+
+	t.Cleanup(func() {
+		if !t.Failed() {
+			if err := recorder.Save(); err != nil {
+				t.Errorf("saving recording for %q: %+v", t.Name(), err)
+			}
+		}
+		recorder.Close()
+
+		recordersLock.Lock()
+		delete(recorders, t)
+		recordersLock.Unlock()
+	})
+
+	if mode == common.RecordingModeReplay {
+		// the Provider is configured using the placeholder values, since no credentials are available when replaying
+		t.Setenv("ARM_CLIENT_ID", common.RecordedClientId)
+		t.Setenv("ARM_SUBSCRIPTION_ID", common.RecordedSubscriptionId)
+		t.Setenv("ARM_TENANT_ID", common.RecordedTenantId)
+	}
+
+	recorders[t] = recorder
+	return recorder
+}
+
+// recordedSubscriptions returns the Subscriptions which should be used for this test, when replaying these
+// are the placeholder values used within the recorded Cassette
+func recordedSubscriptions(recorder *common.Recorder) Subscriptions {
+	if recorder.Mode() == common.RecordingModeReplay {
+		return Subscriptions{
+			Primary:   common.RecordedSubscriptionId,
+			Secondary: recordedSecondarySubscriptionId,
+		}
+	}
+
+	subscriptions := Subscriptions{
+		Primary:   os.Getenv("ARM_SUBSCRIPTION_ID"),
+		Secondary: os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"),
+	}
+	recorder.AddReplacement(subscriptions.Primary, common.RecordedSubscriptionId)
+	recorder.AddReplacement(subscriptions.Secondary, recordedSecondarySubscriptionId)
+	return subscriptions
+}
+
+// recordedInt returns a random integer which is stored within the recorded Cassette
+func recordedInt(recorder *common.Recorder, name string, generate func() int) int {
+	value := recorder.Variable(name, func() string {
+		return strconv.Itoa(generate())
+	})
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		panic("Invalid Recording: " + name + " is not an integer")
+	}
+
+	return i
+}
@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// DefaultPrefixes are the name prefixes used by the Acceptance Tests (e.g. `acctestRG-`), which are
+// matched case-insensitively
+var DefaultPrefixes = []string{"acctest"}
+
+const (
+	// DefaultMinimumAge is the default minimum age of a Resource before it's swept, which ensures
+	// that the Resources for any test runs which are in progress aren't deleted
+	DefaultMinimumAge = 6 * time.Hour
+
+	// DefaultParallelism is the default number of Resources which are deleted at once
+	DefaultParallelism = 10
+)
+
+// Options configures which Resources are swept and how
+type Options struct {
+	// Prefixes are the name prefixes which a Resource must match (case-insensitively) to be swept,
+	// which defaults to DefaultPrefixes when unspecified
+	Prefixes []string
+
+	// MinimumAge is how long ago a Resource must have been created to be swept
+	MinimumAge time.Duration
+
+	// Parallelism is the maximum number of Resources which are deleted at once, which defaults to
+	// DefaultParallelism when unspecified
+	Parallelism int
+
+	// DryRun only logs the Resources which would be swept, rather than deleting them
+	DryRun bool
+
+	// now returns the current time, which is overridden in tests
+	now func() time.Time
+}
+
+// SweptResource is a Resource which was (or, during a dry-run, would have been) deleted
+type SweptResource struct {
+	Sweeper  string
+	Resource Resource
+}
+
+// Result describes the outcome of running the Sweepers
+type Result struct {
+	// Swept are the Resources which were deleted (or would have been, during a dry-run)
+	Swept []SweptResource
+
+	// Skipped is the number of Resources which were skipped since they didn't match a prefix or were too new
+	Skipped int
+}
+
+// Run runs each of the Sweepers in turn, deleting the Resources which match the Options - where any
+// errors are returned once all of the Sweepers have been run, so that a single failure doesn't
+// prevent the remaining Resources from being swept
+func Run(ctx context.Context, client *clients.Client, sweepers []Sweeper, options Options) (*Result, error) {
+	if len(options.Prefixes) == 0 {
+		options.Prefixes = DefaultPrefixes
+	}
+	if options.Parallelism <= 0 {
+		options.Parallelism = DefaultParallelism
+	}
+	if options.now == nil {
+		options.now = time.Now
+	}
+
+	result := &Result{
+		Swept: make([]SweptResource, 0),
+	}
+	var errs *multierror.Error
+	var lock sync.Mutex
+
+	// the semaphore is shared across all of the Sweepers, bounding the total number of deletions in progress
+	semaphore := make(chan struct{}, options.Parallelism)
+
+	for _, sweeper := range sweepers {
+		name := sweeper.Name()
+		log.Printf("[DEBUG] Sweeper %q: listing Resources..", name)
+		resources, err := sweeper.List(ctx, client)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("sweeper %q: listing Resources: %+v", name, err))
+			continue
+		}
+
+		var wg sync.WaitGroup
+		for _, resource := range resources {
+			if reason := options.skipReason(resource); reason != "" {
+				log.Printf("[DEBUG] Sweeper %q: skipping %q since %s", name, resource.ID, reason)
+				result.Skipped++
+				continue
+			}
+
+			if options.DryRun {
+				log.Printf("[INFO] Sweeper %q: would delete %q (dry-run)", name, resource.ID)
+				result.Swept = append(result.Swept, SweptResource{
+					Sweeper:  name,
+					Resource: resource,
+				})
+				continue
+			}
+
+			wg.Add(1)
+			go func(resource Resource) {
+				defer wg.Done()
+
+				select {
+				case semaphore <- struct{}{}:
+				case <-ctx.Done():
+					lock.Lock()
+					errs = multierror.Append(errs, fmt.Errorf("sweeper %q: deleting %q: %+v", name, resource.ID, ctx.Err()))
+					lock.Unlock()
+					return
+				}
+				defer func() {
+					<-semaphore
+				}()
+
+				log.Printf("[INFO] Sweeper %q: deleting %q..", name, resource.ID)
+				err := sweeper.Delete(ctx, client, resource)
+
+				lock.Lock()
+				defer lock.Unlock()
+				if err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("sweeper %q: deleting %q: %+v", name, resource.ID, err))
+					return
+				}
+				log.Printf("[INFO] Sweeper %q: deleted %q", name, resource.ID)
+				result.Swept = append(result.Swept, SweptResource{
+					Sweeper:  name,
+					Resource: resource,
+				})
+			}(resource)
+		}
+		wg.Wait()
+	}
+
+	return result, errs.ErrorOrNil()
+}
+
+// skipReason returns why the Resource shouldn't be swept, or an empty string if it should be
+func (o Options) skipReason(resource Resource) string {
+	matchesPrefix := false
+	for _, prefix := range o.Prefixes {
+		if strings.HasPrefix(strings.ToLower(resource.Name), strings.ToLower(prefix)) {
+			matchesPrefix = true
+			break
+		}
+	}
+	if !matchesPrefix {
+		return fmt.Sprintf("the name %q doesn't match the prefixes %q", resource.Name, strings.Join(o.Prefixes, ", "))
+	}
+
+	if o.MinimumAge > 0 {
+		if resource.CreatedAt == nil {
+			return "when it was created isn't known"
+		}
+		if age := o.now().Sub(*resource.CreatedAt); age < o.MinimumAge {
+			return fmt.Sprintf("it was created %s ago, which is less than the minimum age of %s", age.Round(time.Second), o.MinimumAge)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// Resource is an existing Azure Resource which a Sweeper can delete
+type Resource struct {
+	// ID is the Azure Resource ID of this Resource
+	ID string
+
+	// Name is the name of this Resource, which is matched against the test prefixes
+	Name string
+
+	// CreatedAt is when this Resource was created - or nil when this isn't known, in which case
+	// the Resource is only swept when no minimum age is specified
+	CreatedAt *time.Time
+}
+
+// A Sweeper finds and deletes the Resources left behind by the Acceptance Tests, for example
+// when a test run has been aborted
+type Sweeper interface {
+	// Name is the unique name of this Sweeper (e.g. `azurerm_resource_group`)
+	Name() string
+
+	// List returns the Resources which could be swept - the Resources returned are then filtered
+	// by name prefix and age, so this doesn't need to filter these
+	List(ctx context.Context, client *clients.Client) ([]Resource, error)
+
+	// Delete deletes the specified Resource, waiting for the deletion to complete
+	Delete(ctx context.Context, client *clients.Client, resource Resource) error
+}
+
+// ServiceRegistration is an optional interface which Service Registrations can implement to
+// register the Sweepers for the Resources within that Service Package
+type ServiceRegistration interface {
+	// Sweepers returns a list of Sweepers supported by this Service
+	Sweepers() []Sweeper
+}
+
+// Registry contains the Sweepers registered by each Service Package
+type Registry struct {
+	sweepers map[string]Sweeper
+}
+
+// NewRegistry returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		sweepers: make(map[string]Sweeper),
+	}
+}
+
+// Register registers the specified Sweepers, returning an error if a Sweeper with the same name
+// has already been registered
+func (r *Registry) Register(sweepers ...Sweeper) error {
+	for _, sweeper := range sweepers {
+		name := sweeper.Name()
+		if name == "" {
+			return fmt.Errorf("a Sweeper must have a name")
+		}
+		if _, exists := r.sweepers[name]; exists {
+			return fmt.Errorf("a Sweeper named %q has already been registered", name)
+		}
+		r.sweepers[name] = sweeper
+	}
+	return nil
+}
+
+// Sweepers returns the registered Sweepers ordered by name - optionally limited to the
+// specified names, in which case an error is returned if any of these aren't registered
+func (r *Registry) Sweepers(names ...string) ([]Sweeper, error) {
+	if len(names) == 0 {
+		for name := range r.sweepers {
+			names = append(names, name)
+		}
+	}
+
+	output := make([]Sweeper, 0)
+	for _, name := range names {
+		sweeper, ok := r.sweepers[name]
+		if !ok {
+			return nil, fmt.Errorf("no Sweeper named %q has been registered", name)
+		}
+		output = append(output, sweeper)
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		return output[i].Name() < output[j].Name()
+	})
+	return output, nil
+}
+
+// randomIntegerRegex matches the value of `acceptance.RandTimeInt`, which is used within the names of
+// most test Resources - in the format `YYMMddHHmmsshhRRRR`
+var randomIntegerRegex = regexp.MustCompile(`(^|[^0-9])([0-9]{18})($|[^0-9])`)
+
+// CreatedAtFromName returns when the Resource was created, determined from the timestamp within the
+// random integer used in the name of the test Resource (e.g. `acctestRG-230102150405001234`), or nil
+// when this isn't present. The timestamp is parsed as UTC, since `acceptance.RandTimeInt` generates it
+// in UTC - Resources named by older versions of the tests (using the local time) may be off by the UTC offset
+func CreatedAtFromName(name string) *time.Time {
+	match := randomIntegerRegex.FindStringSubmatch(name)
+	if len(match) == 0 {
+		return nil
+	}
+
+	// the first 14 digits are the timestamp (to the hundredth of a second), the last 4 are random
+	timestamp := strings.Join([]string{match[2][0:12], match[2][12:14]}, ".")
+	createdAt, err := time.ParseInLocation("060102150405.00", timestamp, time.UTC)
+	if err != nil {
+		return nil
+	}
+	return &createdAt
+}
@@ -0,0 +1,265 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+type fakeSweeper struct {
+	name      string
+	resources []Resource
+	listError error
+	failIds   map[string]struct{}
+
+	lock        sync.Mutex
+	deleted     []string
+	inProgress  int
+	maxInFlight int
+}
+
+func (s *fakeSweeper) Name() string {
+	return s.name
+}
+
+func (s *fakeSweeper) List(_ context.Context, _ *clients.Client) ([]Resource, error) {
+	return s.resources, s.listError
+}
+
+func (s *fakeSweeper) Delete(_ context.Context, _ *clients.Client, resource Resource) error {
+	s.lock.Lock()
+	s.inProgress++
+	if s.inProgress > s.maxInFlight {
+		s.maxInFlight = s.inProgress
+	}
+	s.lock.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.inProgress--
+	if _, ok := s.failIds[resource.ID]; ok {
+		return fmt.Errorf("internal server error")
+	}
+	s.deleted = append(s.deleted, resource.ID)
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(&fakeSweeper{name: "second"}, &fakeSweeper{name: "first"}); err != nil {
+		t.Fatalf("registering: %+v", err)
+	}
+	if err := registry.Register(&fakeSweeper{name: "first"}); err == nil {
+		t.Fatalf("expected an error registering a duplicate Sweeper but didn't get one")
+	}
+	if err := registry.Register(&fakeSweeper{}); err == nil {
+		t.Fatalf("expected an error registering a Sweeper without a name but didn't get one")
+	}
+
+	sweepers, err := registry.Sweepers()
+	if err != nil {
+		t.Fatalf("retrieving Sweepers: %+v", err)
+	}
+	if len(sweepers) != 2 || sweepers[0].Name() != "first" || sweepers[1].Name() != "second" {
+		t.Fatalf("expected the Sweepers `first` and `second` but got %+v", sweepers)
+	}
+
+	sweepers, err = registry.Sweepers("second")
+	if err != nil {
+		t.Fatalf("retrieving Sweepers: %+v", err)
+	}
+	if len(sweepers) != 1 || sweepers[0].Name() != "second" {
+		t.Fatalf("expected the Sweeper `second` but got %+v", sweepers)
+	}
+
+	if _, err := registry.Sweepers("third"); err == nil {
+		t.Fatalf("expected an error retrieving an unregistered Sweeper but didn't get one")
+	}
+}
+
+func TestCreatedAtFromName(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    string
+		Expected *time.Time
+	}{
+		{
+			Name:  "no random integer",
+			Input: "acctestRG-example",
+		},
+		{
+			Name:  "too short",
+			Input: "acctestRG-12345",
+		},
+		{
+			Name:     "resource group",
+			Input:    "acctestRG-230102150405071234",
+			Expected: pointerToTime(time.Date(2023, 1, 2, 15, 4, 5, 70000000, time.UTC)),
+		},
+		{
+			Name:     "suffix",
+			Input:    "acctest-231231235959991234-sa",
+			Expected: pointerToTime(time.Date(2023, 12, 31, 23, 59, 59, 990000000, time.UTC)),
+		},
+		{
+			Name:  "invalid timestamp",
+			Input: "acctest-239999999999991234",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := CreatedAtFromName(v.Input)
+		if v.Expected == nil {
+			if actual != nil {
+				t.Fatalf("expected no value but got %s", *actual)
+			}
+			continue
+		}
+		if actual == nil || !actual.Equal(*v.Expected) {
+			t.Fatalf("expected %s but got %v", *v.Expected, actual)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.Local)
+	old := now.Add(-24 * time.Hour)
+	recent := now.Add(-5 * time.Minute)
+
+	resources := []Resource{
+		{ID: "/resourceGroups/acctestRG-1", Name: "acctestRG-1", CreatedAt: &old},
+		{ID: "/resourceGroups/ACCTESTRG-2", Name: "ACCTESTRG-2", CreatedAt: &old},
+		{ID: "/resourceGroups/acctestRG-3", Name: "acctestRG-3", CreatedAt: &old},
+		{ID: "/resourceGroups/acctestRG-4", Name: "acctestRG-4", CreatedAt: &old},
+		{ID: "/resourceGroups/acctestRG-recent", Name: "acctestRG-recent", CreatedAt: &recent},
+		{ID: "/resourceGroups/acctestRG-unknown", Name: "acctestRG-unknown"},
+		{ID: "/resourceGroups/production", Name: "production", CreatedAt: &old},
+	}
+
+	testData := []struct {
+		Name            string
+		Options         Options
+		FailIds         []string
+		ListError       error
+		ExpectedSwept   []string
+		ExpectedDeleted []string
+		ExpectedSkipped int
+		ExpectError     bool
+	}{
+		{
+			Name: "sweeps old resources matching the prefix",
+			Options: Options{
+				MinimumAge:  time.Hour,
+				Parallelism: 2,
+			},
+			ExpectedSwept:   []string{"/resourceGroups/ACCTESTRG-2", "/resourceGroups/acctestRG-1", "/resourceGroups/acctestRG-3", "/resourceGroups/acctestRG-4"},
+			ExpectedDeleted: []string{"/resourceGroups/ACCTESTRG-2", "/resourceGroups/acctestRG-1", "/resourceGroups/acctestRG-3", "/resourceGroups/acctestRG-4"},
+			ExpectedSkipped: 3,
+		},
+		{
+			Name: "no minimum age",
+			Options: Options{
+				Prefixes: []string{"acctestRG-u", "acctestRG-r"},
+			},
+			ExpectedSwept:   []string{"/resourceGroups/acctestRG-recent", "/resourceGroups/acctestRG-unknown"},
+			ExpectedDeleted: []string{"/resourceGroups/acctestRG-recent", "/resourceGroups/acctestRG-unknown"},
+			ExpectedSkipped: 5,
+		},
+		{
+			Name: "dry run",
+			Options: Options{
+				MinimumAge: time.Hour,
+				DryRun:     true,
+			},
+			ExpectedSwept:   []string{"/resourceGroups/ACCTESTRG-2", "/resourceGroups/acctestRG-1", "/resourceGroups/acctestRG-3", "/resourceGroups/acctestRG-4"},
+			ExpectedDeleted: []string{},
+			ExpectedSkipped: 3,
+		},
+		{
+			Name: "deletion errors don't stop the other resources being swept",
+			Options: Options{
+				MinimumAge: time.Hour,
+			},
+			FailIds:         []string{"/resourceGroups/acctestRG-3"},
+			ExpectedSwept:   []string{"/resourceGroups/ACCTESTRG-2", "/resourceGroups/acctestRG-1", "/resourceGroups/acctestRG-4"},
+			ExpectedDeleted: []string{"/resourceGroups/ACCTESTRG-2", "/resourceGroups/acctestRG-1", "/resourceGroups/acctestRG-4"},
+			ExpectedSkipped: 3,
+			ExpectError:     true,
+		},
+		{
+			Name:            "listing error",
+			ListError:       fmt.Errorf("forbidden"),
+			ExpectedSwept:   []string{},
+			ExpectedDeleted: []string{},
+			ExpectError:     true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		sweeper := &fakeSweeper{
+			name:      "azurerm_resource_group",
+			resources: resources,
+			listError: v.ListError,
+			failIds:   map[string]struct{}{},
+			deleted:   make([]string, 0),
+		}
+		for _, id := range v.FailIds {
+			sweeper.failIds[id] = struct{}{}
+		}
+
+		options := v.Options
+		options.now = func() time.Time {
+			return now
+		}
+		result, err := Run(context.TODO(), nil, []Sweeper{sweeper}, options)
+		if err != nil && !v.ExpectError {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if err == nil && v.ExpectError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		swept := make([]string, 0)
+		for _, item := range result.Swept {
+			swept = append(swept, item.Resource.ID)
+		}
+		sort.Strings(swept)
+		sort.Strings(sweeper.deleted)
+
+		if fmt.Sprint(swept) != fmt.Sprint(v.ExpectedSwept) {
+			t.Fatalf("expected the swept Resources to be %q but got %q", v.ExpectedSwept, swept)
+		}
+		if fmt.Sprint(sweeper.deleted) != fmt.Sprint(v.ExpectedDeleted) {
+			t.Fatalf("expected the deleted Resources to be %q but got %q", v.ExpectedDeleted, sweeper.deleted)
+		}
+		if result.Skipped != v.ExpectedSkipped {
+			t.Fatalf("expected %d skipped Resources but got %d", v.ExpectedSkipped, result.Skipped)
+		}
+
+		parallelism := options.Parallelism
+		if parallelism == 0 {
+			parallelism = DefaultParallelism
+		}
+		if sweeper.maxInFlight > parallelism {
+			t.Fatalf("expected at most %d deletions at once but got %d", parallelism, sweeper.maxInFlight)
+		}
+	}
+}
+
+func pointerToTime(input time.Time) *time.Time {
+	return &input
+}
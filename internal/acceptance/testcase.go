@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/helpers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
 )
 
@@ -110,6 +111,11 @@ func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
 	testCase.ExternalProviders = td.externalProviders()
 	testCase.ProviderFactories = td.providers()
 
+	if td.recorder != nil {
+		td.runRecordedTest(t, testCase)
+		return
+	}
+
 	resource.ParallelTest(t, testCase)
 }
 
@@ -117,22 +123,50 @@ func (td TestData) runAcceptanceSequentialTest(t *testing.T, testCase resource.T
 	testCase.ExternalProviders = td.externalProviders()
 	testCase.ProviderFactories = td.providers()
 
+	if td.recorder != nil {
+		td.runRecordedTest(t, testCase)
+		return
+	}
+
+	resource.Test(t, testCase)
+}
+
+// runRecordedTest runs the test case using the Recorder, since the requests must be recorded/replayed
+// in order these tests can't be run in parallel
+func (td TestData) runRecordedTest(t *testing.T, testCase resource.TestCase) {
+	recordingLock.Lock()
+	defer recordingLock.Unlock()
+
+	reset := testclient.UseRecorder(td.recorder)
+	defer reset()
+
+	if td.recorder.Mode() == common.RecordingModeReplay {
+		// no credentials are available when replaying
+		testCase.PreCheck = nil
+	}
+
 	resource.Test(t, testCase)
 }
 
 func (td TestData) providers() map[string]func() (*schema.Provider, error) {
 	return map[string]func() (*schema.Provider, error){
 		"azurerm": func() (*schema.Provider, error) { //nolint:unparam
-			azurerm := provider.TestAzureProvider()
-			return azurerm, nil
+			return td.testProvider(), nil
 		},
 		"azurerm-alt": func() (*schema.Provider, error) { //nolint:unparam
-			azurerm := provider.TestAzureProvider()
-			return azurerm, nil
+			return td.testProvider(), nil
 		},
 	}
 }
 
+func (td TestData) testProvider() *schema.Provider {
+	if td.recorder != nil {
+		return provider.TestAzureProviderWithRecorder(td.recorder)
+	}
+
+	return provider.TestAzureProvider()
+}
+
 func (td TestData) externalProviders() map[string]resource.ExternalProvider {
 	return map[string]resource.ExternalProvider{
 		"azuread": {
@@ -12,14 +12,34 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
 var (
 	_client    *clients.Client
+	_recorder  *common.Recorder
 	clientLock = &sync.Mutex{}
 )
 
+// UseRecorder ensures the client returned from Build records or replays requests using the specified
+// Recorder, the returned function must be called once the test has completed
+func UseRecorder(recorder *common.Recorder) func() {
+	clientLock.Lock()
+	defer clientLock.Unlock()
+
+	_client = nil
+	_recorder = recorder
+
+	return func() {
+		clientLock.Lock()
+		defer clientLock.Unlock()
+
+		_client = nil
+		_recorder = nil
+	}
+}
+
 func Build() (*clients.Client, error) {
 	clientLock.Lock()
 	defer clientLock.Unlock()
@@ -71,6 +91,7 @@ func Build() (*clients.Client, error) {
 			Features:                 features.Default(),
 			StorageUseAzureAD:        false,
 			SubscriptionID:           os.Getenv("ARM_SUBSCRIPTION_ID"),
+			Recorder:                 _recorder,
 		}
 
 		client, err := clients.Build(ctx, clientBuilder)
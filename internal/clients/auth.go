@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/claims"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients/graph"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
 
 type ResourceManagerAccount struct {
@@ -143,3 +144,26 @@ func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, sub
 
 	return &account, nil
 }
+
+// replayedResourceManagerAccount returns a ResourceManagerAccount using the placeholder values from recorded
+// Acceptance Tests, since the account details can't be determined from an access token when replaying
+func replayedResourceManagerAccount(builder ClientBuilder, azureEnvironment azure.Environment) *ResourceManagerAccount {
+	subscriptionId := builder.SubscriptionID
+	if subscriptionId == "" {
+		subscriptionId = common.RecordedSubscriptionId
+	}
+
+	return &ResourceManagerAccount{
+		Environment: builder.AuthConfig.Environment,
+
+		ClientId:       common.RecordedClientId,
+		ObjectId:       common.RecordedObjectId,
+		SubscriptionId: subscriptionId,
+		TenantId:       common.RecordedTenantId,
+
+		AuthenticatedAsAServicePrincipal: true,
+		SkipResourceProviderRegistration: builder.SkipProviderRegistration,
+
+		AzureEnvironment: azureEnvironment,
+	}
+}
@@ -17,11 +17,13 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 )
 
 type ClientBuilder struct {
 	AuthConfig *auth.Credentials
 	Features   features.UserFeatures
+	Tags       tags.Config
 
 	DisableCorrelationRequestID bool
 	DisableTerraformPartnerID   bool
@@ -33,6 +35,17 @@ type ClientBuilder struct {
 	PartnerID                  string
 	SubscriptionID             string
 	TerraformVersion           string
+
+	RetryPolicy *common.RetryPolicy
+
+	// ReadOnlyPolicy blocks any request which could modify a resource, when set
+	ReadOnlyPolicy *common.ReadOnlyPolicy
+
+	// Recorder is used to record or replay requests during Acceptance Tests
+	Recorder *common.Recorder
+
+	TraceFilePath       string
+	TraceRedactedFields []string
 }
 
 const azureStackEnvironmentError = `
@@ -52,25 +65,35 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf(azureStackEnvironmentError)
 	}
 
+	replaying := builder.Recorder != nil && builder.Recorder.Mode() == common.RecordingModeReplay
+
+	newAuthorizer := func(api environments.Api) (auth.Authorizer, error) {
+		// no credentials are available when replaying recorded requests
+		if replaying {
+			return builder.Recorder.Authorizer(), nil
+		}
+		return auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, api)
+	}
+
 	var resourceManagerAuth, storageAuth, synapseAuth, batchManagementAuth, keyVaultAuth auth.Authorizer
 
-	resourceManagerAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ResourceManager)
+	resourceManagerAuth, err = newAuthorizer(builder.AuthConfig.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Resource Manager API: %+v", err)
 	}
 
-	storageAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Storage)
+	storageAuth, err = newAuthorizer(builder.AuthConfig.Environment.Storage)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Storage API: %+v", err)
 	}
 
-	keyVaultAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.KeyVault)
+	keyVaultAuth, err = newAuthorizer(builder.AuthConfig.Environment.KeyVault)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Key Vault API: %+v", err)
 	}
 
 	if builder.AuthConfig.Environment.Synapse.Available() {
-		synapseAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Synapse)
+		synapseAuth, err = newAuthorizer(builder.AuthConfig.Environment.Synapse)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Synapse API: %+v", err)
 		}
@@ -79,7 +102,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	if builder.AuthConfig.Environment.Batch.Available() {
-		batchManagementAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Batch)
+		batchManagementAuth, err = newAuthorizer(builder.AuthConfig.Environment.Batch)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Batch Management API: %+v", err)
 		}
@@ -89,7 +112,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 
 	// Helper for obtaining endpoint-specific tokens
 	authorizerFunc := common.ApiAuthorizerFunc(func(api environments.Api) (auth.Authorizer, error) {
-		authorizer, err := auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, api)
+		authorizer, err := newAuthorizer(api)
 		if err != nil {
 			return nil, fmt.Errorf("building custom authorizer for API %q: %+v", api.Name(), err)
 		}
@@ -104,14 +127,26 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 	resourceManagerEndpoint, _ := builder.AuthConfig.Environment.ResourceManager.Endpoint()
 
-	account, err := NewResourceManagerAccount(ctx, *builder.AuthConfig, builder.SubscriptionID, builder.SkipProviderRegistration, *azureEnvironment)
-	if err != nil {
-		return nil, fmt.Errorf("building account: %+v", err)
+	var account *ResourceManagerAccount
+	if replaying {
+		account = replayedResourceManagerAccount(builder, *azureEnvironment)
+	} else {
+		account, err = NewResourceManagerAccount(ctx, *builder.AuthConfig, builder.SubscriptionID, builder.SkipProviderRegistration, *azureEnvironment)
+		if err != nil {
+			return nil, fmt.Errorf("building account: %+v", err)
+		}
+	}
+
+	if builder.Recorder != nil && builder.Recorder.Mode() == common.RecordingModeRecord {
+		builder.Recorder.AddReplacement(account.SubscriptionId, common.RecordedSubscriptionId)
+		builder.Recorder.AddReplacement(account.TenantId, common.RecordedTenantId)
+		builder.Recorder.AddReplacement(account.ClientId, common.RecordedClientId)
+		builder.Recorder.AddReplacement(account.ObjectId, common.RecordedObjectId)
 	}
 
 	var managedHSMAuth auth.Authorizer
 	if builder.AuthConfig.Environment.ManagedHSM.Available() {
-		managedHSMAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ManagedHSM)
+		managedHSMAuth, err = newAuthorizer(builder.AuthConfig.Environment.ManagedHSM)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Managed HSM API: %+v", err)
 		}
@@ -119,6 +154,14 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		log.Printf("[DEBUG] Skipping building the Managed HSM Authorizer since this is not supported in the current Azure Environment")
 	}
 
+	var requestTracer *common.RequestTracer
+	if builder.TraceFilePath != "" {
+		requestTracer, err = common.NewRequestTracer(builder.TraceFilePath, builder.TraceRedactedFields)
+		if err != nil {
+			return nil, fmt.Errorf("building request tracer: %+v", err)
+		}
+	}
+
 	client := Client{
 		Account: account,
 	}
@@ -136,6 +179,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 
 		Environment: builder.AuthConfig.Environment,
 		Features:    builder.Features,
+		Tags:        builder.Tags,
 
 		SubscriptionId:   account.SubscriptionId,
 		TenantId:         account.TenantId,
@@ -155,6 +199,11 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		SkipProviderReg:             builder.SkipProviderRegistration,
 		StorageUseAzureAD:           builder.StorageUseAzureAD,
 
+		Recorder:       builder.Recorder,
+		RequestTracer:  requestTracer,
+		RetryPolicy:    builder.RetryPolicy,
+		ReadOnlyPolicy: builder.ReadOnlyPolicy,
+
 		// TODO: remove when `Azure/go-autorest` is no longer used
 		AzureEnvironment:        *azureEnvironment,
 		ResourceManagerEndpoint: *resourceManagerEndpoint,
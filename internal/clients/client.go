@@ -142,6 +142,7 @@ import (
 	vmware "github.com/hashicorp/terraform-provider-azurerm/internal/services/vmware/client"
 	voiceServices "github.com/hashicorp/terraform-provider-azurerm/internal/services/voiceservices/client"
 	web "github.com/hashicorp/terraform-provider-azurerm/internal/services/web/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 )
 
 type Client struct {
@@ -153,6 +154,9 @@ type Client struct {
 	Account  *ResourceManagerAccount
 	Features features.UserFeatures
 
+	// Tags is the provider-level `default_tags` and `ignore_tags` configuration for this provider block
+	Tags tags.Config
+
 	AadB2c                       *aadb2c_v2021_04_01_preview.Client
 	Advisor                      *advisor.Client
 	AnalysisServices             *analysisservices_v2017_08_01.Client
@@ -288,6 +292,7 @@ func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error
 
 	client.Features = o.Features
 	client.StopContext = ctx
+	client.Tags = o.Tags
 
 	var err error
 
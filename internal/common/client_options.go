@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/version"
 )
 
@@ -38,6 +39,7 @@ type ClientOptions struct {
 	Authorizers *Authorizers
 	Environment environments.Environment
 	Features    features.UserFeatures
+	Tags        tags.Config
 
 	SubscriptionId   string
 	TenantId         string
@@ -51,6 +53,18 @@ type ClientOptions struct {
 	SkipProviderReg           bool
 	StorageUseAzureAD         bool
 
+	// RetryPolicy is an optional policy for retrying requests, see RetryPolicy for how this applies to each SDK
+	RetryPolicy *RetryPolicy
+
+	// RequestTracer is an optional tracer which writes a structured record for each request
+	RequestTracer *RequestTracer
+
+	// Recorder is an optional Recorder used to record/replay requests during Acceptance Tests
+	Recorder *Recorder
+
+	// ReadOnlyPolicy is an optional policy which blocks any request which could modify a resource
+	ReadOnlyPolicy *ReadOnlyPolicy
+
 	// Keep these around for convenience with Autorest based clients, remove when we are no longer using autorest
 	AzureEnvironment        azure.Environment
 	ResourceManagerEndpoint string
@@ -69,8 +83,15 @@ type ClientOptions struct {
 func (o ClientOptions) Configure(c *resourcemanager.Client, authorizer auth.Authorizer) {
 	c.Authorizer = authorizer
 	c.UserAgent = userAgent(c.UserAgent, o.TerraformVersion, o.PartnerId, o.DisableTerraformPartnerID)
+	if o.RetryPolicy != nil {
+		o.RetryPolicy.configureSdkClient(c.Client)
+	}
 
 	requestMiddlewares := make([]client.RequestMiddleware, 0)
+	if o.ReadOnlyPolicy != nil {
+		// requests are blocked before anything else happens, so that they're neither logged nor recorded
+		requestMiddlewares = append(requestMiddlewares, readOnlyRequestMiddleware(*o.ReadOnlyPolicy))
+	}
 	if !o.DisableCorrelationRequestID {
 		id := o.CustomCorrelationRequestID
 		if id == "" {
@@ -79,11 +100,24 @@ func (o ClientOptions) Configure(c *resourcemanager.Client, authorizer auth.Auth
 		requestMiddlewares = append(requestMiddlewares, correlationRequestIDMiddleware(id))
 	}
 	requestMiddlewares = append(requestMiddlewares, requestLoggerMiddleware("AzureRM"))
-	c.RequestMiddlewares = &requestMiddlewares
 
-	c.ResponseMiddlewares = &[]client.ResponseMiddleware{
-		responseLoggerMiddleware("AzureRM"),
+	// the Recorder needs to be the first ResponseMiddleware and the last RequestMiddleware, since
+	// it needs to see the raw response and (when replaying) redirects the request
+	responseMiddlewares := make([]client.ResponseMiddleware, 0)
+	if o.Recorder != nil {
+		responseMiddlewares = append(responseMiddlewares, recorderResponseMiddleware(o.Recorder))
+	}
+	if o.RequestTracer != nil {
+		requestMiddlewares = append(requestMiddlewares, traceRequestMiddleware(o.RequestTracer))
+		responseMiddlewares = append(responseMiddlewares, traceResponseMiddleware(o.RequestTracer))
 	}
+	responseMiddlewares = append(responseMiddlewares, responseLoggerMiddleware("AzureRM"))
+	if o.Recorder != nil {
+		requestMiddlewares = append(requestMiddlewares, recorderRequestMiddleware(o.Recorder))
+	}
+
+	c.RequestMiddlewares = &requestMiddlewares
+	c.ResponseMiddlewares = &responseMiddlewares
 }
 
 // ConfigureClient sets up an autorest.Client using an autorest.Authorizer
@@ -92,6 +126,19 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 
 	c.Authorizer = authorizer
 	c.Sender = sender.BuildSender("AzureRM")
+	if o.Recorder != nil {
+		c.Sender = autorest.DecorateSender(c.Sender, withRecorder(o.Recorder))
+	}
+	if o.RequestTracer != nil {
+		c.Sender = autorest.DecorateSender(c.Sender, withRequestTracing(o.RequestTracer))
+	}
+	if o.RetryPolicy != nil {
+		c.Sender = autorest.DecorateSender(c.Sender, withRetryPolicy(*o.RetryPolicy))
+		disableAutorestRetries(c)
+	}
+	if o.ReadOnlyPolicy != nil {
+		c.Sender = autorest.DecorateSender(c.Sender, WithReadOnlyPolicy(*o.ReadOnlyPolicy))
+	}
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
 	if !o.DisableCorrelationRequestID {
 		id := o.CustomCorrelationRequestID
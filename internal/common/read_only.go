@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+// readOnlyModeErrorMessage is included in the message for each ReadOnlyModeError, since the error chain is lost
+// when errors are wrapped using `%+v`
+const readOnlyModeErrorMessage = "the Provider is running in read-only mode"
+
+// ReadOnlyPolicy blocks any request which could modify a resource, so that the Provider can safely be used
+// (for example to run `terraform plan`) with credentials which are able to make changes.
+type ReadOnlyPolicy struct {
+	// AllowedPostOperations is a list of POST operations which only retrieve data (for example `listKeys`),
+	// matched case-insensitively against the final segment of the URI Path
+	AllowedPostOperations []string
+}
+
+// DefaultReadOnlyAllowedPostOperations are the POST operations which are always allowed, in addition to any
+// specified by the user, which are used by Resources and Data Sources to retrieve data
+var DefaultReadOnlyAllowedPostOperations = []string{
+	"listAdminKeys",
+	"listClusterAdminCredential",
+	"listClusterMonitoringUserCredential",
+	"listClusterUserCredential",
+	"listConnectionStrings",
+	"listCredentials",
+	"listKeys",
+	"listSecrets",
+}
+
+// ReadOnlyModeError is returned when a request is blocked by the ReadOnlyPolicy
+type ReadOnlyModeError struct {
+	Method string
+	URI    string
+}
+
+// Response implements the `adal.TokenRefreshError` interface, which the `Azure/go-autorest` based clients use to
+// determine that an error returned from the Sender won't succeed when retried - since otherwise each blocked request
+// is retried with an exponential backoff (taking several minutes) before the error is surfaced. No request is sent,
+// as such there's no response.
+func (e ReadOnlyModeError) Response() *http.Response {
+	return nil
+}
+
+func (e ReadOnlyModeError) Error() string {
+	return fmt.Sprintf("%s, so the %s request to %q was blocked since it could modify a resource - if this request only retrieves data, it can be allowed using `read_only_allowed_post_operations`", readOnlyModeErrorMessage, e.Method, e.URI)
+}
+
+// IsReadOnlyModeError returns whether the error (or an error it wraps) was returned by the ReadOnlyPolicy
+func IsReadOnlyModeError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var readOnlyErr ReadOnlyModeError
+	if errors.As(err, &readOnlyErr) {
+		return true
+	}
+
+	return strings.Contains(err.Error(), readOnlyModeErrorMessage)
+}
+
+// allows returns whether the request can be sent whilst in read-only mode
+func (p ReadOnlyPolicy) allows(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+
+	case http.MethodPost:
+		segments := strings.Split(strings.TrimSuffix(req.URL.Path, "/"), "/")
+		operation := segments[len(segments)-1]
+		for _, allowed := range p.AllowedPostOperations {
+			if strings.EqualFold(operation, allowed) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (p ReadOnlyPolicy) check(req *http.Request) error {
+	if p.allows(req) {
+		return nil
+	}
+
+	uri := req.URL.Scheme + "://" + req.URL.Host + req.URL.Path
+	return ReadOnlyModeError{
+		Method: req.Method,
+		URI:    uri,
+	}
+}
+
+func readOnlyRequestMiddleware(policy ReadOnlyPolicy) client.RequestMiddleware {
+	return func(req *http.Request) (*http.Request, error) {
+		if err := policy.check(req); err != nil {
+			return nil, err
+		}
+		return req, nil
+	}
+}
+
+// WithReadOnlyPolicy returns a SendDecorator which blocks requests which aren't allowed by the ReadOnlyPolicy,
+// which is exposed so that it can be applied to the Data Plane clients which are built outside of ClientOptions
+func WithReadOnlyPolicy(policy ReadOnlyPolicy) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			if err := policy.check(r); err != nil {
+				return nil, err
+			}
+			return s.Do(r)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+func TestReadOnlyPolicyAllows(t *testing.T) {
+	policy := ReadOnlyPolicy{
+		AllowedPostOperations: DefaultReadOnlyAllowedPostOperations,
+	}
+
+	testData := []struct {
+		Name     string
+		Method   string
+		Path     string
+		Expected bool
+	}{
+		{
+			Name:     "Get",
+			Method:   http.MethodGet,
+			Path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			Expected: true,
+		},
+		{
+			Name:     "Head",
+			Method:   http.MethodHead,
+			Path:     "/example-container/example.vhd",
+			Expected: true,
+		},
+		{
+			Name:     "Put",
+			Method:   http.MethodPut,
+			Path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			Expected: false,
+		},
+		{
+			Name:     "Patch",
+			Method:   http.MethodPatch,
+			Path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			Expected: false,
+		},
+		{
+			Name:     "Delete",
+			Method:   http.MethodDelete,
+			Path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			Expected: false,
+		},
+		{
+			Name:     "Allowed Post",
+			Method:   http.MethodPost,
+			Path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/listKeys",
+			Expected: true,
+		},
+		{
+			Name:     "Allowed Post with a different casing",
+			Method:   http.MethodPost,
+			Path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/ListKeys/",
+			Expected: true,
+		},
+		{
+			Name:     "Other Post",
+			Method:   http.MethodPost,
+			Path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/regenerateKey",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		req, err := http.NewRequest(v.Method, "https://management.azure.com"+v.Path, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		if actual := policy.allows(req); actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+func TestReadOnlyPolicySender(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := autorest.DecorateSender(server.Client(), WithReadOnlyPolicy(ReadOnlyPolicy{}))
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/example", nil)
+	if _, err := sender.Do(req); err != nil {
+		t.Fatalf("expected the GET request to be sent but got: %+v", err)
+	}
+
+	req, _ = http.NewRequest(http.MethodPut, server.URL+"/example", nil)
+	_, err := sender.Do(req)
+	if err == nil {
+		t.Fatalf("expected the PUT request to be blocked but it wasn't")
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request to be sent but got %d", requests)
+	}
+
+	// the error chain is lost when errors are wrapped using `%+v`, so this needs to be detected from the message
+	wrapped := fmt.Errorf("creating Example: %+v", err)
+	if !IsReadOnlyModeError(wrapped) {
+		t.Fatalf("expected the wrapped error to be detected as a read-only mode error: %+v", wrapped)
+	}
+	if IsReadOnlyModeError(fmt.Errorf("creating Example: unexpected status 409")) {
+		t.Fatalf("expected an unrelated error not to be detected as a read-only mode error")
+	}
+}
+
+func TestReadOnlyPolicyAutorestClient(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := autorest.NewClientWithUserAgent("Test")
+	options := ClientOptions{
+		DisableCorrelationRequestID: true,
+		ReadOnlyPolicy:              &ReadOnlyPolicy{},
+	}
+	options.ConfigureClient(&c, autorest.NullAuthorizer{})
+
+	attempts := 0
+	countAttempts := func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			attempts++
+			return s.Do(r)
+		})
+	}
+	c.Sender = autorest.DecorateSender(c.Sender, countAttempts)
+
+	// the blocked request would otherwise be retried with a backoff of several minutes, so this fails fast
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx), autorest.AsPut(), autorest.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	// the API Clients send requests using the SendDecorators which retry errors returned from the Sender
+	_, err = autorest.SendWithSender(c, req, azure.DoRetryWithRegistration(c))
+	if !IsReadOnlyModeError(err) {
+		t.Fatalf("expected a read-only mode error but got: %+v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected a single attempt but got %d", attempts)
+	}
+	if requests != 0 {
+		t.Fatalf("expected no requests to be sent but got %d", requests)
+	}
+}
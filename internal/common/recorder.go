@@ -0,0 +1,449 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"golang.org/x/oauth2"
+)
+
+type RecordingMode string
+
+const (
+	// RecordingModeRecord sends requests to Azure and records each interaction into a Cassette
+	RecordingModeRecord RecordingMode = "record"
+
+	// RecordingModeReplay serves each request from a previously recorded Cassette, without calling Azure
+	RecordingModeReplay RecordingMode = "replay"
+)
+
+const (
+	// RecordedSubscriptionId is the placeholder Subscription ID used within recorded Cassettes
+	RecordedSubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+	// RecordedTenantId is the placeholder Tenant ID used within recorded Cassettes
+	RecordedTenantId = "00000000-0000-0000-0000-000000000001"
+
+	// RecordedClientId is the placeholder Client ID used within recorded Cassettes
+	RecordedClientId = "00000000-0000-0000-0000-000000000002"
+
+	// RecordedObjectId is the placeholder Object ID used within recorded Cassettes
+	RecordedObjectId = "00000000-0000-0000-0000-000000000003"
+
+	// recordedHostHeader is used to pass the original host of a replayed request to the replay server
+	recordedHostHeader = "X-Ms-Recorded-Host"
+)
+
+// Cassette is a recording of the HTTP interactions made during a test, alongside the values
+// (such as random integers/strings) which are required to replay it deterministically
+type Cassette struct {
+	Variables    map[string]string     `json:"variables"`
+	Interactions []RecordedInteraction `json:"interactions"`
+}
+
+type RecordedInteraction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder records the HTTP interactions made by the Provider into a Cassette on disk, or replays
+// them from a previously recorded Cassette using a local stand-in for the Azure APIs.
+type Recorder struct {
+	lock sync.Mutex
+
+	mode     RecordingMode
+	path     string
+	cassette Cassette
+
+	// replacements is a map of sensitive/environment specific values to the placeholder used in the Cassette
+	replacements map[string]string
+
+	// redactor removes sensitive fields from the recorded bodies and URLs, using the same fields as request tracing
+	redactor redactor
+
+	// replayed tracks which interactions have already been replayed
+	replayed map[int]struct{}
+
+	// variableCounts tracks the number of times each variable has been requested
+	variableCounts map[string]int
+
+	server *httptest.Server
+}
+
+// NewRecorder returns a Recorder for the Cassette at the specified path. When replaying, the Cassette
+// must already exist and a local server is started to serve the recorded interactions.
+func NewRecorder(mode RecordingMode, path string) (*Recorder, error) {
+	r := &Recorder{
+		mode: mode,
+		path: path,
+		cassette: Cassette{
+			Variables:    make(map[string]string),
+			Interactions: make([]RecordedInteraction, 0),
+		},
+		replacements:   make(map[string]string),
+		redactor:       newRedactor(nil),
+		replayed:       make(map[int]struct{}),
+		variableCounts: make(map[string]int),
+	}
+
+	switch mode {
+	case RecordingModeRecord:
+		return r, nil
+
+	case RecordingModeReplay:
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading cassette %q: %+v", path, err)
+		}
+		if err := json.Unmarshal(contents, &r.cassette); err != nil {
+			return nil, fmt.Errorf("parsing cassette %q: %+v", path, err)
+		}
+		r.server = httptest.NewServer(http.HandlerFunc(r.serveReplay))
+		return r, nil
+	}
+
+	return nil, fmt.Errorf("unsupported recording mode %q", string(mode))
+}
+
+// Mode returns the RecordingMode of this Recorder
+func (r *Recorder) Mode() RecordingMode {
+	return r.mode
+}
+
+// AddReplacement ensures that value is replaced with placeholder within the recorded Cassette
+func (r *Recorder) AddReplacement(value, placeholder string) {
+	if value == "" || value == placeholder {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.replacements[value] = placeholder
+}
+
+// Variable returns a value which is stable between recording and replaying, such as a random
+// string used in a resource name. When recording the value is generated and stored in the
+// Cassette, when replaying the stored value is returned. Each subsequent call for the same name
+// returns a new value.
+func (r *Recorder) Variable(name string, generate func() string) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := fmt.Sprintf("%s.%d", name, r.variableCounts[name])
+	r.variableCounts[name]++
+
+	if r.mode == RecordingModeReplay {
+		if v, ok := r.cassette.Variables[key]; ok {
+			return v
+		}
+	}
+
+	value := generate()
+	r.cassette.Variables[key] = value
+	return value
+}
+
+// Authorizer returns an auth.Authorizer which is used in place of real credentials when replaying
+func (r *Recorder) Authorizer() auth.Authorizer {
+	return &replayAuthorizer{}
+}
+
+// Save writes the recorded Cassette to disk, this is a no-op when replaying
+func (r *Recorder) Save() error {
+	if r.mode != RecordingModeRecord {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	contents, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling cassette: %+v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("creating directory for cassette %q: %+v", r.path, err)
+	}
+
+	if err := os.WriteFile(r.path, contents, 0o644); err != nil {
+		return fmt.Errorf("writing cassette %q: %+v", r.path, err)
+	}
+
+	return nil
+}
+
+// Close stops the local replay server, if any
+func (r *Recorder) Close() {
+	if r.server != nil {
+		r.server.Close()
+	}
+}
+
+// normalise replaces any sensitive/environment specific values with their placeholders
+func (r *Recorder) normalise(input string) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for value, placeholder := range r.replacements {
+		input = strings.ReplaceAll(input, value, placeholder)
+		input = strings.ReplaceAll(input, strings.ToLower(value), placeholder)
+	}
+
+	return input
+}
+
+// recordingKey returns a key for the request which is independent of query string ordering
+func recordingKey(method string, u *url.URL) string {
+	return fmt.Sprintf("%s %s://%s%s?%s", strings.ToUpper(method), u.Scheme, strings.ToLower(u.Host), strings.ToLower(u.EscapedPath()), u.Query().Encode())
+}
+
+func (r *Recorder) record(req *http.Request, reqBody []byte, resp *http.Response) error {
+	var respBody []byte
+	if resp.Body != nil {
+		var err error
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		if err != nil {
+			return fmt.Errorf("reading response body: %+v", err)
+		}
+	}
+
+	headers := http.Header{}
+	for k, v := range resp.Header {
+		if strings.EqualFold(k, "Set-Cookie") {
+			continue
+		}
+		for _, val := range v {
+			headers.Add(k, r.normalise(val))
+		}
+	}
+
+	interaction := RecordedInteraction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    r.normalise(r.redactor.redactURL(req.URL)),
+			Body:   r.normalise(r.redactBody(reqBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    headers,
+			Body:       r.normalise(r.redactBody(respBody)),
+		},
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+
+	return nil
+}
+
+// redactBody returns the body with any sensitive fields redacted, bodies which aren't JSON are recorded as-is
+func (r *Recorder) redactBody(body []byte) string {
+	if redacted := r.redactor.redactBody(body); redacted != nil {
+		return string(redacted)
+	}
+	return string(body)
+}
+
+// replay returns the next recorded response for the request, in the order they were recorded
+func (r *Recorder) replay(method string, u *url.URL) (*http.Response, error) {
+	// the recorded URLs are redacted, so the request URL needs to be redacted in the same way to match
+	redactedUrl, err := url.Parse(r.redactor.redactURL(u))
+	if err != nil {
+		return nil, fmt.Errorf("parsing redacted URL: %+v", err)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := recordingKey(method, redactedUrl)
+	for i, interaction := range r.cassette.Interactions {
+		if _, ok := r.replayed[i]; ok {
+			continue
+		}
+
+		recordedUrl, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing recorded URL %q: %+v", interaction.Request.URL, err)
+		}
+		if recordingKey(interaction.Request.Method, recordedUrl) != key {
+			continue
+		}
+
+		r.replayed[i] = struct{}{}
+
+		headers := interaction.Response.Headers.Clone()
+		if headers == nil {
+			headers = http.Header{}
+		}
+		// there's no need to wait between polling requests when replaying
+		if headers.Get("Retry-After") != "" {
+			headers.Set("Retry-After", "0")
+		}
+
+		return &http.Response{
+			StatusCode:    interaction.Response.StatusCode,
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			Header:        headers,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction was found for %s %s", method, u.String())
+}
+
+func (r *Recorder) serveReplay(w http.ResponseWriter, req *http.Request) {
+	u := *req.URL
+	u.Scheme = "https"
+	u.Host = req.Header.Get(recordedHostHeader)
+
+	resp, err := r.replay(req.Method, &u)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	defer resp.Body.Close()
+
+	for k, v := range resp.Header {
+		for _, val := range v {
+			w.Header().Add(k, val)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+}
+
+// recorderRequestMiddleware either captures the request body for recording, or redirects the
+// request to the local replay server - as such this must be the last RequestMiddleware
+func recorderRequestMiddleware(recorder *Recorder) client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		if recorder.mode == RecordingModeRecord {
+			if err := bufferRequestBody(request); err != nil {
+				return nil, err
+			}
+			return request, nil
+		}
+
+		serverUrl, err := url.Parse(recorder.server.URL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing replay server URL: %+v", err)
+		}
+
+		request.Header.Set(recordedHostHeader, request.URL.Host)
+		request.URL.Scheme = serverUrl.Scheme
+		request.URL.Host = serverUrl.Host
+		request.Host = serverUrl.Host
+
+		return request, nil
+	}
+}
+
+// recorderResponseMiddleware records the interaction, as such this must be the first ResponseMiddleware
+func recorderResponseMiddleware(recorder *Recorder) client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		if recorder.mode != RecordingModeRecord {
+			return response, nil
+		}
+
+		var reqBody []byte
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewinding request body: %+v", err)
+			}
+			reqBody, _ = io.ReadAll(body)
+		}
+
+		if err := recorder.record(request, reqBody, response); err != nil {
+			return nil, fmt.Errorf("recording interaction: %+v", err)
+		}
+
+		return response, nil
+	}
+}
+
+// withRecorder returns a SendDecorator which records or replays requests sent using the `Azure/go-autorest` based clients
+func withRecorder(recorder *Recorder) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			if recorder.mode == RecordingModeReplay {
+				resp, err := recorder.replay(r.Method, r.URL)
+				if err != nil {
+					return nil, err
+				}
+				resp.Request = r
+				return resp, nil
+			}
+
+			var reqBody []byte
+			if r.Body != nil && r.Body != http.NoBody {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					return nil, fmt.Errorf("reading request body: %+v", err)
+				}
+				r.Body.Close()
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				reqBody = body
+			}
+
+			resp, err := s.Do(r)
+			if err != nil || resp == nil {
+				return resp, err
+			}
+
+			if err := recorder.record(r, reqBody, resp); err != nil {
+				return resp, fmt.Errorf("recording interaction: %+v", err)
+			}
+
+			return resp, nil
+		})
+	}
+}
+
+var _ auth.Authorizer = &replayAuthorizer{}
+
+// replayAuthorizer is an auth.Authorizer returning a static token, since no credentials are available when replaying
+type replayAuthorizer struct{}
+
+func (a *replayAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: "replayed",
+		TokenType:   "Bearer",
+	}, nil
+}
+
+func (a *replayAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestRecordingKey(t *testing.T) {
+	testData := []struct {
+		Name     string
+		First    string
+		Second   string
+		Expected bool
+	}{
+		{
+			Name:     "Identical",
+			First:    "https://management.azure.com/subscriptions/abc/resourceGroups/rg?api-version=2021-01-01",
+			Second:   "https://management.azure.com/subscriptions/abc/resourceGroups/rg?api-version=2021-01-01",
+			Expected: true,
+		},
+		{
+			Name:     "Different Casing",
+			First:    "https://management.azure.com/subscriptions/abc/resourceGroups/rg?api-version=2021-01-01",
+			Second:   "https://MANAGEMENT.azure.com/subscriptions/abc/resourcegroups/RG?api-version=2021-01-01",
+			Expected: true,
+		},
+		{
+			Name:     "Different Query String Ordering",
+			First:    "https://management.azure.com/subscriptions/abc?api-version=2021-01-01&$expand=all",
+			Second:   "https://management.azure.com/subscriptions/abc?$expand=all&api-version=2021-01-01",
+			Expected: true,
+		},
+		{
+			Name:     "Different API Version",
+			First:    "https://management.azure.com/subscriptions/abc?api-version=2021-01-01",
+			Second:   "https://management.azure.com/subscriptions/abc?api-version=2022-01-01",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		first, _ := url.Parse(v.First)
+		second, _ := url.Parse(v.Second)
+		actual := recordingKey(http.MethodGet, first) == recordingKey(http.MethodGet, second)
+		if actual != v.Expected {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	subscriptionId := "11111111-2222-3333-4444-555555555555"
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": "/subscriptions/` + subscriptionId + `/resourceGroups/rg"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(RecordingModeRecord, path)
+	if err != nil {
+		t.Fatalf("building recorder: %+v", err)
+	}
+	recorder.AddReplacement(subscriptionId, RecordedSubscriptionId)
+	recordedName := recorder.Variable("name", func() string { return "acctest123" })
+
+	sender := autorest.DecorateSender(&http.Client{}, withRecorder(recorder))
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/subscriptions/"+subscriptionId+"/resourceGroups/rg?api-version=2021-01-01", nil)
+	if _, err := sender.Do(req); err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("saving cassette: %+v", err)
+	}
+
+	replayer, err := NewRecorder(RecordingModeReplay, path)
+	if err != nil {
+		t.Fatalf("building replayer: %+v", err)
+	}
+	defer replayer.Close()
+
+	if actual := replayer.Variable("name", func() string { return "different" }); actual != recordedName {
+		t.Fatalf("expected the replayed variable to be %q but got %q", recordedName, actual)
+	}
+
+	sender = autorest.DecorateSender(&http.Client{}, withRecorder(replayer))
+	req, _ = http.NewRequest(http.MethodGet, server.URL+"/subscriptions/"+RecordedSubscriptionId+"/resourceGroups/rg?api-version=2021-01-01", nil)
+	resp, err := sender.Do(req)
+	if err != nil {
+		t.Fatalf("replaying request: %+v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected the replayed request not to be sent but got %d requests", requests)
+	}
+	if resp.Header.Get("Retry-After") != "0" {
+		t.Fatalf("expected the Retry-After header to be shortened but got %q", resp.Header.Get("Retry-After"))
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if strings.Contains(string(body), subscriptionId) || !strings.Contains(string(body), RecordedSubscriptionId) {
+		t.Fatalf("expected the Subscription ID to be replaced in the response body but got %q", string(body))
+	}
+
+	// each interaction can only be replayed once
+	req, _ = http.NewRequest(http.MethodGet, server.URL+"/subscriptions/"+RecordedSubscriptionId+"/resourceGroups/rg?api-version=2021-01-01", nil)
+	if _, err := sender.Do(req); err == nil {
+		t.Fatalf("expected an error replaying an interaction a second time")
+	}
+}
+
+func TestRecorderRedactsSensitiveValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"keys":[{"keyName":"key1","value":"s3cr3t"}],"primaryConnectionString":"Endpoint=s3cr3t"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(RecordingModeRecord, path)
+	if err != nil {
+		t.Fatalf("building recorder: %+v", err)
+	}
+
+	uri := server.URL + "/container1/blob1?sig=s3cr3t&sp=r"
+	sender := autorest.DecorateSender(&http.Client{}, withRecorder(recorder))
+	req, _ := http.NewRequest(http.MethodPost, uri, strings.NewReader(`{"password":"s3cr3t"}`))
+	if _, err := sender.Do(req); err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("saving cassette: %+v", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cassette: %+v", err)
+	}
+	if strings.Contains(string(contents), "s3cr3t") {
+		t.Fatalf("expected sensitive values to be redacted from the cassette but got %s", string(contents))
+	}
+
+	// the request should still be matched when replaying, despite the query string being redacted
+	replayer, err := NewRecorder(RecordingModeReplay, path)
+	if err != nil {
+		t.Fatalf("building replayer: %+v", err)
+	}
+	defer replayer.Close()
+
+	sender = autorest.DecorateSender(&http.Client{}, withRecorder(replayer))
+	req, _ = http.NewRequest(http.MethodPost, uri, nil)
+	if _, err := sender.Do(req); err != nil {
+		t.Fatalf("replaying request: %+v", err)
+	}
+}
+
+func TestRecorderReplayServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(RecordingModeRecord, path)
+	if err != nil {
+		t.Fatalf("building recorder: %+v", err)
+	}
+	req, _ := http.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/abc?api-version=2021-01-01", nil)
+	resp := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+	}
+	if err := recorder.record(req, nil, resp); err != nil {
+		t.Fatalf("recording interaction: %+v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("saving cassette: %+v", err)
+	}
+
+	replayer, err := NewRecorder(RecordingModeReplay, path)
+	if err != nil {
+		t.Fatalf("building replayer: %+v", err)
+	}
+	defer replayer.Close()
+
+	req, _ = http.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/abc?api-version=2021-01-01", nil)
+	req, err = recorderRequestMiddleware(replayer)(req)
+	if err != nil {
+		t.Fatalf("running request middleware: %+v", err)
+	}
+	if strings.Contains(req.URL.Host, "management.azure.com") {
+		t.Fatalf("expected the request to be redirected to the replay server but got %q", req.URL.String())
+	}
+
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected the status code %d but got %d", http.StatusCreated, resp.StatusCode)
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+// RetryPolicy defines how requests which receive a retryable status code (e.g. `429 Too Many Requests`)
+// should be retried. The `Azure/go-autorest` based clients are retried according to this policy, whereas
+// the `hashicorp/go-azure-sdk` based clients use the retry handling built into that SDK, which is only
+// configurable to the extent that retries can be disabled (see `configureSdkClient`).
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request will be sent, including the first attempt
+	MaxAttempts int
+
+	// MinBackoff is the duration to wait before the first retry, which is doubled for each subsequent retry
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum duration to wait between retries
+	MaxBackoff time.Duration
+
+	// RetryableStatusCodes is a list of the HTTP Status Codes which should be retried
+	RetryableStatusCodes []int
+
+	// HonourRetryAfterHeader specifies whether the duration specified in a `Retry-After` header
+	// should be used as the backoff, rather than the calculated exponential backoff
+	HonourRetryAfterHeader bool
+}
+
+// DefaultRetryableStatusCodes are the HTTP Status Codes retried when none are specified
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used when the `retry` block is specified without overriding any values
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:            5,
+		MinBackoff:             1 * time.Second,
+		MaxBackoff:             60 * time.Second,
+		RetryableStatusCodes:   DefaultRetryableStatusCodes,
+		HonourRetryAfterHeader: true,
+	}
+}
+
+func (p RetryPolicy) shouldRetry(resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+
+	for _, code := range p.RetryableStatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the duration to wait before sending retry number `attempt` (starting at 1), which
+// is capped at MaxBackoff - including when the duration is taken from a `Retry-After` header
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := p.retryAfter(resp); ok {
+		return p.capBackoff(wait)
+	}
+
+	mult := math.Pow(2, float64(attempt-1)) * float64(p.MinBackoff)
+	wait := time.Duration(mult)
+	if float64(wait) != mult {
+		wait = p.MaxBackoff
+	}
+	return p.capBackoff(wait)
+}
+
+// retryAfter returns the duration specified in the `Retry-After` header of the response, if any
+func (p RetryPolicy) retryAfter(resp *http.Response) (time.Duration, bool) {
+	if !p.HonourRetryAfterHeader || resp == nil {
+		return 0, false
+	}
+
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(v); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func (p RetryPolicy) capBackoff(wait time.Duration) time.Duration {
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return wait
+}
+
+// retry resends the request until either a non-retryable response is received or the maximum number
+// of attempts has been reached - the request body must be rewindable using `GetBody` when present.
+func (p RetryPolicy) retry(req *http.Request, resp *http.Response, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	for attempt := 1; attempt < p.MaxAttempts && p.shouldRetry(resp); attempt++ {
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			log.Printf("[DEBUG] Unable to retry %s %s since the request body cannot be rewound", req.Method, req.URL)
+			return resp, nil
+		}
+
+		wait := p.backoff(attempt, resp)
+		log.Printf("[DEBUG] Received status %d for %s %s - retrying in %s (attempt %d of %d)", resp.StatusCode, req.Method, req.URL, wait, attempt+1, p.MaxAttempts)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return resp, req.Context().Err()
+		case <-timer.C:
+		}
+
+		// drain the previous response so that the connection can be reused
+		if resp.Body != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		newReq := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewinding request body: %+v", err)
+			}
+			newReq.Body = body
+		}
+
+		var err error
+		if resp, err = send(newReq); err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
+
+// bufferRequestBody reads the request body into memory, so that it can be rewound using `GetBody` when retrying
+func bufferRequestBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return fmt.Errorf("reading request body: %+v", err)
+	}
+	req.Body.Close()
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return nil
+}
+
+// configureSdkClient configures the retry handling built into the `hashicorp/go-azure-sdk` based clients,
+// rather than resending requests on top of it (which would bypass the SDK's transport and middlewares, and
+// multiply with the SDK's own retries). The SDK always retries requests which are rate limited (honouring
+// the `Retry-After` header), and otherwise retries requests to work around eventual consistency - which is
+// disabled when only a single attempt is allowed. The remaining fields aren't configurable in the SDK, and
+// are reported by `UnsupportedBySdkClients`.
+func (p RetryPolicy) configureSdkClient(c *client.Client) {
+	c.DisableRetries = p.MaxAttempts <= 1
+}
+
+// UnsupportedBySdkClients returns the fields of the RetryPolicy which differ from the defaults, but which can't
+// be applied to the `hashicorp/go-azure-sdk` based clients - so that this can be surfaced when configuring them
+func (p RetryPolicy) UnsupportedBySdkClients() []string {
+	defaults := DefaultRetryPolicy()
+
+	output := make([]string, 0)
+	if p.MinBackoff != defaults.MinBackoff {
+		output = append(output, "min_backoff_in_seconds")
+	}
+	if p.MaxBackoff != defaults.MaxBackoff {
+		output = append(output, "max_backoff_in_seconds")
+	}
+	if !sameStatusCodes(p.RetryableStatusCodes, defaults.RetryableStatusCodes) {
+		output = append(output, "retryable_status_codes")
+	}
+	if p.HonourRetryAfterHeader != defaults.HonourRetryAfterHeader {
+		output = append(output, "honour_retry_after_header")
+	}
+
+	return output
+}
+
+func sameStatusCodes(first, second []int) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	codes := make(map[int]struct{}, len(first))
+	for _, code := range first {
+		codes[code] = struct{}{}
+	}
+	for _, code := range second {
+		if _, ok := codes[code]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// disableAutorestRetries prevents the `Azure/go-autorest` based clients from retrying requests outside of the
+// RetryPolicy, which would otherwise multiply the number of attempts. The SendDecorators passed by each API Client
+// (which retry with a fixed backoff, and retry rate limited requests indefinitely) are replaced, and the requests
+// which are sent without these (such as when polling) are limited to a single attempt.
+func disableAutorestRetries(c *autorest.Client) {
+	c.RetryAttempts = 1
+	c.SendDecorators = make([]autorest.SendDecorator, 0)
+}
+
+// withRetryPolicy returns a SendDecorator which retries requests sent using the `Azure/go-autorest` based clients
+func withRetryPolicy(policy RetryPolicy) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			if err := bufferRequestBody(r); err != nil {
+				return nil, err
+			}
+
+			resp, err := s.Do(r)
+			if err != nil {
+				return resp, err
+			}
+
+			return policy.retry(r, resp, s.Do)
+		})
+	}
+}
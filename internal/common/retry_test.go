@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MinBackoff:             time.Second,
+		MaxBackoff:             5 * time.Second,
+		HonourRetryAfterHeader: true,
+	}
+
+	testData := []struct {
+		Name       string
+		Attempt    int
+		RetryAfter string
+		Expected   time.Duration
+	}{
+		{
+			Name:     "First Retry",
+			Attempt:  1,
+			Expected: time.Second,
+		},
+		{
+			Name:     "Third Retry",
+			Attempt:  3,
+			Expected: 4 * time.Second,
+		},
+		{
+			Name:     "Capped At Max Backoff",
+			Attempt:  10,
+			Expected: 5 * time.Second,
+		},
+		{
+			Name:       "Retry-After Header",
+			Attempt:    1,
+			RetryAfter: "3",
+			Expected:   3 * time.Second,
+		},
+		{
+			Name:       "Retry-After Header Capped At Max Backoff",
+			Attempt:    1,
+			RetryAfter: "30",
+			Expected:   5 * time.Second,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		resp := &http.Response{
+			Header: http.Header{},
+		}
+		if v.RetryAfter != "" {
+			resp.Header.Set("Retry-After", v.RetryAfter)
+		}
+
+		actual := policy.backoff(v.Attempt, resp)
+		if actual != v.Expected {
+			t.Fatalf("Expected %s but got %s", v.Expected, actual)
+		}
+	}
+}
+
+func TestRetryPolicySender(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "hello" {
+			t.Errorf("expected the request body to be %q but got %q", "hello", string(body))
+		}
+
+		if requests < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{
+		MaxAttempts:          5,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+	}
+	sender := autorest.DecorateSender(server.Client(), withRetryPolicy(policy))
+
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	resp, err := sender.Do(req)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d but got %d", http.StatusOK, resp.StatusCode)
+	}
+	if requests != 3 {
+		t.Fatalf("expected 3 requests but got %d", requests)
+	}
+}
+
+func TestRetryPolicyMaxAttempts(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{
+		MaxAttempts:          2,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+	}
+	sender := autorest.DecorateSender(server.Client(), withRetryPolicy(policy))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	resp, err := sender.Do(req)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d but got %d", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests but got %d", requests)
+	}
+}
+
+func TestRetryPolicySdkClient(t *testing.T) {
+	testData := []struct {
+		Name             string
+		MaxAttempts      int
+		ExpectedRequests int
+		ExpectError      bool
+	}{
+		{
+			Name:             "Retries Disabled",
+			MaxAttempts:      1,
+			ExpectedRequests: 1,
+			ExpectError:      true,
+		},
+		{
+			Name:             "Retries Enabled",
+			MaxAttempts:      5,
+			ExpectedRequests: 2,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			// `424 Failed Dependency` is retried by the SDK to work around eventual consistency
+			if requests == 1 {
+				w.WriteHeader(http.StatusFailedDependency)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		c := &resourcemanager.Client{
+			Client: client.NewClient(server.URL, "Test", "2020-01-01"),
+		}
+		options := ClientOptions{
+			DisableCorrelationRequestID: true,
+			RetryPolicy: &RetryPolicy{
+				MaxAttempts:          v.MaxAttempts,
+				RetryableStatusCodes: DefaultRetryableStatusCodes,
+			},
+		}
+		options.Configure(c, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		req, err := c.Client.NewRequest(ctx, client.RequestOptions{
+			ContentType:         "application/json",
+			ExpectedStatusCodes: []int{http.StatusOK},
+			HttpMethod:          http.MethodGet,
+			Path:                "/",
+		})
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		_, err = c.Execute(ctx, req)
+		cancel()
+		server.Close()
+
+		if v.ExpectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ExpectError && err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+		if requests != v.ExpectedRequests {
+			t.Fatalf("expected %d requests but got %d", v.ExpectedRequests, requests)
+		}
+	}
+}
+
+func TestRetryPolicyAutorestClient(t *testing.T) {
+	testData := []struct {
+		Name             string
+		MaxAttempts      int
+		ExpectedRequests int
+	}{
+		{
+			Name:             "Retries Disabled",
+			MaxAttempts:      1,
+			ExpectedRequests: 1,
+		},
+		{
+			Name:             "Retries Enabled",
+			MaxAttempts:      3,
+			ExpectedRequests: 3,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+
+		c := autorest.NewClientWithUserAgent("Test")
+		options := ClientOptions{
+			DisableCorrelationRequestID: true,
+			RetryPolicy: &RetryPolicy{
+				MaxAttempts:          v.MaxAttempts,
+				RetryableStatusCodes: DefaultRetryableStatusCodes,
+			},
+		}
+		options.ConfigureClient(&c, autorest.NullAuthorizer{})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		req, err := autorest.Prepare((&http.Request{}).WithContext(ctx), autorest.AsGet(), autorest.WithBaseURL(server.URL))
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		// the API Clients send requests using the SendDecorators which retry rate limited requests indefinitely
+		resp, err := c.Send(req, azure.DoRetryWithRegistration(c))
+		cancel()
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("expected status %d but got %d", http.StatusTooManyRequests, resp.StatusCode)
+		}
+		if requests != v.ExpectedRequests {
+			t.Fatalf("expected %d requests but got %d", v.ExpectedRequests, requests)
+		}
+	}
+}
+
+func TestRetryPolicyUnsupportedBySdkClients(t *testing.T) {
+	if actual := DefaultRetryPolicy().UnsupportedBySdkClients(); len(actual) != 0 {
+		t.Fatalf("expected the default policy to be supported but got %+v", actual)
+	}
+
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = 1
+	policy.MaxBackoff = 5 * time.Second
+	policy.RetryableStatusCodes = []int{http.StatusTooManyRequests}
+	expected := []string{"max_backoff_in_seconds", "retryable_status_codes"}
+	if actual := policy.UnsupportedBySdkClients(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
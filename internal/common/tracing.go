@@ -0,0 +1,436 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+// DefaultTraceRedactedFields are the JSON fields within request and response bodies which are always
+// redacted from trace records, in addition to any fields specified by the user
+var DefaultTraceRedactedFields = []string{
+	"accessKey",
+	"adminPassword",
+	"administratorLoginPassword",
+	"clientSecret",
+	"connectionString",
+	"keys",
+	"password",
+	"primaryConnectionString",
+	"primaryKey",
+	"primaryMasterKey",
+	"sasToken",
+	"secondaryConnectionString",
+	"secondaryKey",
+	"secondaryMasterKey",
+	"secret",
+}
+
+// traceRedactedScalarFields are JSON fields which are redacted only when they contain a scalar value, since
+// these are commonly used both for secret values (e.g. Key Vault Secrets or Storage Account Keys) and as the
+// list of items within a paged response, which should be retained
+var traceRedactedScalarFields = []string{
+	"value",
+}
+
+// traceRedactedQueryParameters are the query parameters within the URL which are always redacted from trace
+// records, in addition to any query parameters matching the redacted fields
+var traceRedactedQueryParameters = []string{
+	// the signature of a Shared Access Signature
+	"sig",
+}
+
+const traceRedactedValue = "REDACTED"
+
+// TraceRecord is a single structured record describing an HTTP request and its response
+type TraceRecord struct {
+	Timestamp     time.Time       `json:"timestamp"`
+	Method        string          `json:"method"`
+	URL           string          `json:"url"`
+	StatusCode    int             `json:"status_code,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	DurationMs    int64           `json:"duration_ms"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	ResourceType  string          `json:"resource_type,omitempty"`
+	Operation     string          `json:"operation,omitempty"`
+	RequestBody   json.RawMessage `json:"request_body,omitempty"`
+	ResponseBody  json.RawMessage `json:"response_body,omitempty"`
+}
+
+// RequestTracer writes a JSON TraceRecord for each HTTP request made by the Provider to a file,
+// redacting any sensitive fields within the URL and request/response bodies.
+type RequestTracer struct {
+	lock   sync.Mutex
+	writer io.Writer
+
+	redactor redactor
+}
+
+// NewRequestTracer returns a RequestTracer which appends trace records to the file at the specified path,
+// redacting the specified fields in addition to DefaultTraceRedactedFields
+func NewRequestTracer(filePath string, redactedFields []string) (*RequestTracer, error) {
+	// check the file can be opened up-front, so that any issues are surfaced when configuring the Provider
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening trace file %q: %+v", filePath, err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("closing trace file %q: %+v", filePath, err)
+	}
+
+	return newRequestTracer(traceFile(filePath), redactedFields), nil
+}
+
+func newRequestTracer(writer io.Writer, redactedFields []string) *RequestTracer {
+	return &RequestTracer{
+		writer:   writer,
+		redactor: newRedactor(redactedFields),
+	}
+}
+
+// traceFile is an io.Writer which appends to the file at the path, which is opened and closed for each
+// write since there's no point at which the Provider is notified that it's about to exit
+type traceFile string
+
+func (f traceFile) Write(p []byte) (int, error) {
+	file, err := os.OpenFile(string(f), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := file.Write(p)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
+
+// trace builds and writes the TraceRecord for the specified request/response
+func (t *RequestTracer) trace(req *http.Request, reqBody []byte, resp *http.Response, respErr error, start time.Time) {
+	record := TraceRecord{
+		Timestamp:  start.UTC(),
+		Method:     req.Method,
+		URL:        t.redactor.redactURL(req.URL),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	record.ResourceType, record.Operation = resourceTypeAndOperation(req.Method, req.URL.Path)
+	record.RequestBody = t.redactor.redactBody(reqBody)
+
+	record.CorrelationID = req.Header.Get(HeaderCorrelationRequestID)
+	if resp != nil {
+		record.StatusCode = resp.StatusCode
+		if record.CorrelationID == "" {
+			record.CorrelationID = resp.Header.Get(HeaderCorrelationRequestID)
+		}
+
+		if resp.Body != nil {
+			respBody, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+			if err == nil {
+				record.ResponseBody = t.redactor.redactBody(respBody)
+			}
+		}
+	}
+	if respErr != nil {
+		record.Error = respErr.Error()
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to marshal trace record for %s %s: %+v", req.Method, req.URL, err)
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, err := t.writer.Write(append(line, '\n')); err != nil {
+		log.Printf("[DEBUG] Unable to write trace record for %s %s: %+v", req.Method, req.URL, err)
+	}
+}
+
+// redactor removes sensitive values from URLs and JSON bodies
+type redactor struct {
+	fields          map[string]struct{}
+	scalarFields    map[string]struct{}
+	queryParameters map[string]struct{}
+}
+
+// newRedactor returns a redactor which redacts the specified fields in addition to DefaultTraceRedactedFields
+func newRedactor(redactedFields []string) redactor {
+	fields := make([]string, 0, len(DefaultTraceRedactedFields)+len(redactedFields))
+	fields = append(fields, DefaultTraceRedactedFields...)
+	fields = append(fields, redactedFields...)
+
+	queryParameters := make([]string, 0, len(traceRedactedQueryParameters)+len(fields))
+	queryParameters = append(queryParameters, traceRedactedQueryParameters...)
+	queryParameters = append(queryParameters, fields...)
+
+	return redactor{
+		fields:          lowerCaseSet(fields),
+		scalarFields:    lowerCaseSet(traceRedactedScalarFields),
+		queryParameters: lowerCaseSet(queryParameters),
+	}
+}
+
+func lowerCaseSet(input []string) map[string]struct{} {
+	output := make(map[string]struct{}, len(input))
+	for _, v := range input {
+		output[strings.ToLower(v)] = struct{}{}
+	}
+	return output
+}
+
+// redactURL returns the URL with the values of any sensitive query parameters replaced
+func (r redactor) redactURL(input *url.URL) string {
+	if input.RawQuery == "" {
+		return input.String()
+	}
+
+	query := input.Query()
+	redacted := false
+	for key := range query {
+		if _, ok := r.queryParameters[strings.ToLower(key)]; ok {
+			query[key] = []string{traceRedactedValue}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return input.String()
+	}
+
+	output := *input
+	output.RawQuery = query.Encode()
+	return output.String()
+}
+
+// redactBody returns the JSON body with any sensitive fields replaced, non-JSON bodies are omitted entirely
+func (r redactor) redactBody(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil
+	}
+
+	out, err := json.Marshal(r.redactValue(v))
+	if err != nil {
+		return nil
+	}
+
+	return out
+}
+
+func (r redactor) redactValue(input interface{}) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if val == nil {
+				continue
+			}
+			if _, ok := r.fields[strings.ToLower(key)]; ok {
+				v[key] = traceRedactedValue
+				continue
+			}
+			if _, ok := r.scalarFields[strings.ToLower(key)]; ok && isScalar(val) {
+				v[key] = traceRedactedValue
+				continue
+			}
+			v[key] = r.redactValue(val)
+		}
+		return v
+
+	case []interface{}:
+		for i, val := range v {
+			v[i] = r.redactValue(val)
+		}
+		return v
+	}
+
+	return input
+}
+
+func isScalar(input interface{}) bool {
+	switch input.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
+
+// resourceTypeAndOperation determines the ARM Resource Type (e.g. `Microsoft.Network/virtualNetworks/subnets`)
+// and the Operation being performed (e.g. `Read` or `listKeys`) from the HTTP Method and URI Path
+func resourceTypeAndOperation(method, path string) (string, string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	providerIndex := -1
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "providers") && i+1 < len(segments) {
+			providerIndex = i
+		}
+	}
+
+	// segments following the last provider namespace alternate between the type and the name
+	remaining := make([]string, 0)
+	resourceType := ""
+	if providerIndex >= 0 {
+		resourceType = segments[providerIndex+1]
+		remaining = segments[providerIndex+2:]
+	} else if len(segments) >= 2 && strings.EqualFold(segments[0], "subscriptions") {
+		resourceType = "Microsoft.Resources"
+		remaining = segments[2:]
+		if len(remaining) == 0 {
+			resourceType = "Microsoft.Resources/subscriptions"
+		}
+	}
+
+	action := ""
+	for i := 0; i < len(remaining); i += 2 {
+		if i+1 >= len(remaining) {
+			// a trailing segment without a name is either a collection or an action (e.g. `listKeys`)
+			if method == http.MethodPost {
+				action = remaining[i]
+				break
+			}
+		}
+		resourceType = fmt.Sprintf("%s/%s", resourceType, remaining[i])
+	}
+
+	if action != "" {
+		return resourceType, action
+	}
+
+	isCollection := len(remaining)%2 == 1
+	switch method {
+	case http.MethodGet:
+		if isCollection {
+			return resourceType, "List"
+		}
+		return resourceType, "Read"
+	case http.MethodHead:
+		return resourceType, "Exists"
+	case http.MethodPut:
+		return resourceType, "CreateOrUpdate"
+	case http.MethodPatch:
+		return resourceType, "Update"
+	case http.MethodDelete:
+		return resourceType, "Delete"
+	}
+
+	return resourceType, method
+}
+
+type traceContextKey struct{}
+
+type traceContext struct {
+	start   time.Time
+	reqBody []byte
+}
+
+// traceRequestMiddleware captures the start time and request body for the trace record. Since the
+// ResponseMiddlewares aren't called when a request fails to be sent, transport errors (such as DNS or
+// connection failures) are traced as they happen using an httptrace.ClientTrace.
+func traceRequestMiddleware(tracer *RequestTracer) client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		tc := traceContext{
+			start: time.Now(),
+		}
+
+		if request.Body != nil && request.Body != http.NoBody {
+			body, err := io.ReadAll(request.Body)
+			if err != nil {
+				return nil, fmt.Errorf("reading request body: %+v", err)
+			}
+			request.Body.Close()
+			request.Body = io.NopCloser(bytes.NewReader(body))
+			tc.reqBody = body
+		}
+
+		traceError := func(err error) {
+			tracer.trace(request, tc.reqBody, nil, err, tc.start)
+		}
+		clientTrace := &httptrace.ClientTrace{
+			DNSDone: func(info httptrace.DNSDoneInfo) {
+				if info.Err != nil {
+					traceError(fmt.Errorf("resolving host: %+v", info.Err))
+				}
+			},
+			ConnectDone: func(network, addr string, err error) {
+				if err != nil {
+					traceError(fmt.Errorf("connecting to %s: %+v", addr, err))
+				}
+			},
+			TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+				if err != nil {
+					traceError(fmt.Errorf("performing TLS handshake: %+v", err))
+				}
+			},
+			WroteRequest: func(info httptrace.WroteRequestInfo) {
+				if info.Err != nil {
+					traceError(fmt.Errorf("writing request: %+v", info.Err))
+				}
+			},
+		}
+
+		ctx := context.WithValue(request.Context(), traceContextKey{}, tc)
+		ctx = httptrace.WithClientTrace(ctx, clientTrace)
+		return request.WithContext(ctx), nil
+	}
+}
+
+// traceResponseMiddleware writes the trace record once the response has been received
+func traceResponseMiddleware(tracer *RequestTracer) client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		tc, ok := request.Context().Value(traceContextKey{}).(traceContext)
+		if !ok {
+			tc = traceContext{
+				start: time.Now(),
+			}
+		}
+
+		tracer.trace(request, tc.reqBody, response, nil, tc.start)
+		return response, nil
+	}
+}
+
+// withRequestTracing returns a SendDecorator which writes a trace record for requests sent using the `Azure/go-autorest` based clients
+func withRequestTracing(tracer *RequestTracer) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			var reqBody []byte
+			if r.Body != nil && r.Body != http.NoBody {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					return nil, fmt.Errorf("reading request body: %+v", err)
+				}
+				r.Body.Close()
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				reqBody = body
+			}
+
+			start := time.Now()
+			resp, err := s.Do(r)
+			tracer.trace(r, reqBody, resp, err, start)
+			return resp, err
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
+)
+
+func TestResourceTypeAndOperation(t *testing.T) {
+	testData := []struct {
+		Method            string
+		Path              string
+		ExpectedType      string
+		ExpectedOperation string
+	}{
+		{
+			Method:            http.MethodGet,
+			Path:              "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+			ExpectedType:      "Microsoft.Network/virtualNetworks/subnets",
+			ExpectedOperation: "Read",
+		},
+		{
+			Method:            http.MethodGet,
+			Path:              "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks",
+			ExpectedType:      "Microsoft.Network/virtualNetworks",
+			ExpectedOperation: "List",
+		},
+		{
+			Method:            http.MethodPost,
+			Path:              "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/listKeys",
+			ExpectedType:      "Microsoft.Storage/storageAccounts",
+			ExpectedOperation: "listKeys",
+		},
+		{
+			Method:            http.MethodPut,
+			Path:              "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			ExpectedType:      "Microsoft.Resources/resourceGroups",
+			ExpectedOperation: "CreateOrUpdate",
+		},
+		{
+			Method:            http.MethodDelete,
+			Path:              "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Sql/servers/server1/databases/db1",
+			ExpectedType:      "Microsoft.Sql/servers/databases",
+			ExpectedOperation: "Delete",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %s %s", v.Method, v.Path)
+
+		resourceType, operation := resourceTypeAndOperation(v.Method, v.Path)
+		if resourceType != v.ExpectedType {
+			t.Fatalf("Expected resource type %q but got %q", v.ExpectedType, resourceType)
+		}
+		if operation != v.ExpectedOperation {
+			t.Fatalf("Expected operation %q but got %q", v.ExpectedOperation, operation)
+		}
+	}
+}
+
+func TestRequestTracerRedactsSensitiveFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderCorrelationRequestID, "abc123")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"keys":[{"keyName":"key1","value":"s3cr3t"}],"properties":{"primaryConnectionString":"Endpoint=s3cr3t","name":"example"}}`))
+	}))
+	defer server.Close()
+
+	output := &bytes.Buffer{}
+	tracer := newRequestTracer(output, nil)
+	sender := autorest.DecorateSender(server.Client(), withRequestTracing(tracer))
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/listKeys", strings.NewReader(`{"password":"hunter2"}`))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	resp, err := sender.Do(req)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	// the response body must still be readable by the caller
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "s3cr3t") {
+		t.Fatalf("expected the original response body to be returned but got %q", string(body))
+	}
+
+	if strings.Contains(output.String(), "s3cr3t") || strings.Contains(output.String(), "hunter2") {
+		t.Fatalf("expected sensitive fields to be redacted but got %s", output.String())
+	}
+
+	var record TraceRecord
+	if err := json.Unmarshal(output.Bytes(), &record); err != nil {
+		t.Fatalf("unmarshaling trace record: %+v", err)
+	}
+	if record.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d but got %d", http.StatusOK, record.StatusCode)
+	}
+	if record.CorrelationID != "abc123" {
+		t.Fatalf("expected correlation ID %q but got %q", "abc123", record.CorrelationID)
+	}
+	if record.Operation != "listKeys" {
+		t.Fatalf("expected operation %q but got %q", "listKeys", record.Operation)
+	}
+	if !strings.Contains(string(record.ResponseBody), `"name":"example"`) {
+		t.Fatalf("expected non-sensitive fields to be retained but got %s", string(record.ResponseBody))
+	}
+}
+
+func TestRedactorRedactBody(t *testing.T) {
+	r := newRedactor([]string{"customSecret"})
+
+	testData := []struct {
+		Name     string
+		Input    string
+		Expected string
+	}{
+		{
+			Name:     "Default Field",
+			Input:    `{"properties":{"password":"hunter2","name":"example"}}`,
+			Expected: `{"properties":{"name":"example","password":"REDACTED"}}`,
+		},
+		{
+			Name:     "Custom Field merged with Defaults",
+			Input:    `{"customSecret":"abc","primaryKey":"def"}`,
+			Expected: `{"customSecret":"REDACTED","primaryKey":"REDACTED"}`,
+		},
+		{
+			Name:     "Key Vault Secret Value",
+			Input:    `{"id":"https://example.vault.azure.net/secrets/example/abc123","value":"s3cr3t"}`,
+			Expected: `{"id":"https://example.vault.azure.net/secrets/example/abc123","value":"REDACTED"}`,
+		},
+		{
+			Name:     "Paged Response Value",
+			Input:    `{"value":[{"name":"example"}]}`,
+			Expected: `{"value":[{"name":"example"}]}`,
+		},
+		{
+			Name:     "Non-JSON Body",
+			Input:    `hello`,
+			Expected: ``,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := r.redactBody([]byte(v.Input))
+		if string(actual) != v.Expected {
+			t.Fatalf("Expected %s but got %s", v.Expected, string(actual))
+		}
+	}
+}
+
+func TestRedactorRedactURL(t *testing.T) {
+	r := newRedactor(nil)
+
+	testData := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "https://management.azure.com/subscriptions/12345678-1234-9876-4563-123456789012?api-version=2022-09-01",
+			Expected: "https://management.azure.com/subscriptions/12345678-1234-9876-4563-123456789012?api-version=2022-09-01",
+		},
+		{
+			Input:    "https://account1.blob.core.windows.net/container1?restype=container&sig=s3cr3t&sp=r&sv=2020-08-04",
+			Expected: "https://account1.blob.core.windows.net/container1?restype=container&sig=REDACTED&sp=r&sv=2020-08-04",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		input, err := url.Parse(v.Input)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", v.Input, err)
+		}
+		if actual := r.redactURL(input); actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestRequestTracerTransportError(t *testing.T) {
+	// start and then immediately stop a server, so that the connection is refused
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	output := &bytes.Buffer{}
+	c := &resourcemanager.Client{
+		Client: client.NewClient(server.URL, "Test", "2020-01-01"),
+	}
+	options := ClientOptions{
+		DisableCorrelationRequestID: true,
+		RequestTracer:               newRequestTracer(output, nil),
+	}
+	options.Configure(c, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	req, err := c.Client.NewRequest(ctx, client.RequestOptions{
+		ContentType:         "application/json",
+		ExpectedStatusCodes: []int{http.StatusOK},
+		// non-idempotent requests aren't retried by the SDK when no response is received
+		HttpMethod: http.MethodPost,
+		Path:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/listKeys",
+	})
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	if _, err := c.Execute(ctx, req); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	var record TraceRecord
+	if err := json.Unmarshal(output.Bytes(), &record); err != nil {
+		t.Fatalf("unmarshaling trace record %q: %+v", output.String(), err)
+	}
+	if !strings.Contains(record.Error, "connecting to") {
+		t.Fatalf("expected the transport error to be traced but got %q", record.Error)
+	}
+	if record.Operation != "listKeys" {
+		t.Fatalf("expected operation %q but got %q", "listKeys", record.Operation)
+	}
+}
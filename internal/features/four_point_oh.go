@@ -24,6 +24,10 @@ func DeprecatedInFourPointOh(deprecationMessage string) string {
 // This exists to allow breaking changes to be piped through the provider
 // during the development of 3.x until 4.0 is ready.
 func FourPointOh() bool {
+	if fourPointOhOverride != nil {
+		return *fourPointOhOverride
+	}
+
 	return false
 }
 
@@ -38,3 +42,19 @@ func FourPointOh() bool {
 func FourPointOhBeta() bool {
 	return FourPointOh() || false
 }
+
+// fourPointOhOverride overrides the value returned from FourPointOh (and so FourPointOhBeta) when set, see OverrideFourPointOh
+var fourPointOhOverride *bool
+
+// OverrideFourPointOh overrides the value returned from FourPointOh (and so FourPointOhBeta) and returns a function
+// which restores the previous value.
+//
+// This exists for tooling (such as the config-linter) which needs to build the schema of the provider
+// running in 4.0 mode in-process - and shouldn't be used within the provider itself.
+func OverrideFourPointOh(enabled bool) func() {
+	previous := fourPointOhOverride
+	fourPointOhOverride = &enabled
+	return func() {
+		fourPointOhOverride = previous
+	}
+}
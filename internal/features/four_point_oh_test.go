@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package features
+
+import (
+	"testing"
+)
+
+func TestOverrideFourPointOh(t *testing.T) {
+	expected := FourPointOh()
+
+	restore := OverrideFourPointOh(true)
+	if !FourPointOh() {
+		t.Fatalf("expected FourPointOh to be overridden to true")
+	}
+	if !FourPointOhBeta() {
+		t.Fatalf("expected FourPointOhBeta to be true when FourPointOh is overridden to true")
+	}
+	if DeprecatedInFourPointOh("example") != "example" {
+		t.Fatalf("expected the deprecation message to be returned when running in 4.0 mode")
+	}
+
+	restoreNested := OverrideFourPointOh(false)
+	if FourPointOh() {
+		t.Fatalf("expected FourPointOh to be overridden to false")
+	}
+
+	restoreNested()
+	if !FourPointOh() {
+		t.Fatalf("expected FourPointOh to be restored to true")
+	}
+
+	restore()
+	if actual := FourPointOh(); actual != expected {
+		t.Fatalf("expected FourPointOh to be restored to %t but got %t", expected, actual)
+	}
+}
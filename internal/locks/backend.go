@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Backend serialises operations on a key across multiple processes (for example when several Terraform
+// workspaces are applied in parallel against the same Virtual Network), in addition to the in-memory locks
+// used within this process.
+//
+// Locks within a Backend are leases which are renewed whilst they're held, so that a lock held by a process
+// which exits without unlocking it expires, rather than blocking other processes indefinitely.
+type Backend interface {
+	// Lock blocks until the lock for the given key has been acquired, or the context is cancelled
+	Lock(ctx context.Context, key string) error
+
+	// Unlock releases the lock for the given key, previously acquired using Lock
+	Unlock(ctx context.Context, key string) error
+}
+
+var (
+	backend     Backend
+	backendLock = &sync.RWMutex{}
+)
+
+// ConfigureBackend sets the Backend used to serialise locks across processes, when nil (the default) locks
+// are only held within this process.
+func ConfigureBackend(input Backend) {
+	backendLock.Lock()
+	defer backendLock.Unlock()
+
+	backend = input
+}
+
+func currentBackend() Backend {
+	backendLock.RLock()
+	defer backendLock.RUnlock()
+
+	return backend
+}
+
+// lock acquires the in-memory lock for this key, and then the lock within the Backend (if configured)
+func lock(ctx context.Context, key string, owner string) error {
+	if err := armMutexKV.LockWithContext(ctx, key, owner); err != nil {
+		return err
+	}
+
+	if b := currentBackend(); b != nil {
+		if err := b.Lock(ctx, key); err != nil {
+			armMutexKV.Unlock(key)
+			return fmt.Errorf("acquiring the lock %q from the Lock Backend: %+v", key, err)
+		}
+	}
+
+	return nil
+}
+
+// unlock releases the lock within the Backend (if configured), and then the in-memory lock for this key
+func unlock(key string) {
+	if b := currentBackend(); b != nil {
+		if err := b.Unlock(context.Background(), key); err != nil {
+			log.Printf("[WARN] Unable to release the lock for %q from the Lock Backend: %+v", key, err)
+		}
+	}
+
+	armMutexKV.Unlock(key)
+}
+
+// backendKeyName returns a name for the given key which is safe to use as a file or blob name
+func backendKeyName(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// renewLease calls renew at the specified interval until the returned function is called
+func renewLease(key string, interval time.Duration, renew func() error) func() {
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := renew(); err != nil {
+					log.Printf("[WARN] Unable to renew the lease for the lock %q: %+v", key, err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-stopped
+	}
+}
+
+// waitOrCancel waits for the specified interval, returning an error if the context is cancelled first
+func waitOrCancel(ctx context.Context, interval time.Duration) error {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// blobLeaseDurationInSeconds is the duration of each Blob Lease, which must be between 15 and 60 seconds
+	blobLeaseDurationInSeconds = 55
+
+	// blobLeaseRenewInterval is how often a held Blob Lease is renewed
+	blobLeaseRenewInterval = 20 * time.Second
+
+	// blobPollInterval is how often a leased Blob is checked whilst waiting for the Lease to be released
+	blobPollInterval = 5 * time.Second
+)
+
+// ErrLeaseAlreadyPresent is returned from a BlobLeaseClient when the Blob is already leased
+var ErrLeaseAlreadyPresent = errors.New("there is already a lease present on this blob")
+
+// BlobLeaseClient is the subset of the Blob Storage API used by the BlobLeaseBackend, scoped to a single
+// Storage Container.
+type BlobLeaseClient interface {
+	// EnsureBlob creates the specified Blob if it doesn't already exist
+	EnsureBlob(ctx context.Context, blobName string) error
+
+	// AcquireLease acquires a Lease on the specified Blob, returning ErrLeaseAlreadyPresent if it's already leased
+	AcquireLease(ctx context.Context, blobName string, durationInSeconds int) (leaseId string, err error)
+
+	// RenewLease renews the specified Lease on the Blob
+	RenewLease(ctx context.Context, blobName string, leaseId string) error
+
+	// ReleaseLease releases the specified Lease on the Blob
+	ReleaseLease(ctx context.Context, blobName string, leaseId string) error
+}
+
+var _ Backend = &BlobLeaseBackend{}
+
+// BlobLeaseBackend is a Backend which uses Leases on Blobs within a Storage Container, allowing locks to be
+// shared by Terraform processes running on different machines.
+type BlobLeaseBackend struct {
+	client        BlobLeaseClient
+	renewInterval time.Duration
+	pollInterval  time.Duration
+
+	lock sync.Mutex
+	held map[string]heldBlobLease
+}
+
+type heldBlobLease struct {
+	leaseId   string
+	stopRenew func()
+}
+
+// NewBlobLeaseBackend returns a BlobLeaseBackend which acquires Leases using the specified client
+func NewBlobLeaseBackend(client BlobLeaseClient) *BlobLeaseBackend {
+	return &BlobLeaseBackend{
+		client:        client,
+		renewInterval: blobLeaseRenewInterval,
+		pollInterval:  blobPollInterval,
+		held:          make(map[string]heldBlobLease),
+	}
+}
+
+func (b *BlobLeaseBackend) Lock(ctx context.Context, key string) error {
+	blobName := backendKeyName(key)
+	if err := b.client.EnsureBlob(ctx, blobName); err != nil {
+		return fmt.Errorf("ensuring the Blob %q exists: %+v", blobName, err)
+	}
+
+	var leaseId string
+	for {
+		var err error
+		leaseId, err = b.client.AcquireLease(ctx, blobName, blobLeaseDurationInSeconds)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrLeaseAlreadyPresent) {
+			return fmt.Errorf("acquiring a Lease on the Blob %q: %+v", blobName, err)
+		}
+
+		if err := waitOrCancel(ctx, b.pollInterval); err != nil {
+			return fmt.Errorf("waiting for the Lease on the Blob %q to be released: %+v", blobName, err)
+		}
+	}
+
+	stopRenew := renewLease(key, b.renewInterval, func() error {
+		// the lease needs to be renewed for as long as it's held, rather than for the lifetime of the request
+		// which acquired it
+		return b.client.RenewLease(context.Background(), blobName, leaseId)
+	})
+
+	b.lock.Lock()
+	b.held[key] = heldBlobLease{
+		leaseId:   leaseId,
+		stopRenew: stopRenew,
+	}
+	b.lock.Unlock()
+
+	return nil
+}
+
+func (b *BlobLeaseBackend) Unlock(ctx context.Context, key string) error {
+	b.lock.Lock()
+	held, ok := b.held[key]
+	delete(b.held, key)
+	b.lock.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	held.stopRenew()
+
+	blobName := backendKeyName(key)
+	if err := b.client.ReleaseLease(ctx, blobName, held.leaseId); err != nil {
+		return fmt.Errorf("releasing the Lease on the Blob %q: %+v", blobName, err)
+	}
+
+	return nil
+}
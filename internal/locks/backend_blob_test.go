@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlobLeaseBackend(t *testing.T) {
+	client := &fakeBlobLeaseClient{
+		blobs: make(map[string]string),
+	}
+	first := NewBlobLeaseBackend(client)
+	first.pollInterval = 5 * time.Millisecond
+	first.renewInterval = 5 * time.Millisecond
+	second := NewBlobLeaseBackend(client)
+	second.pollInterval = 5 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := first.Lock(ctx, "example"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		if err := second.Lock(ctx, "example"); err != nil {
+			t.Errorf("locking: %+v", err)
+		}
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatalf("expected the lock not to be acquired whilst it's leased")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := first.Unlock(ctx, "example"); err != nil {
+		t.Fatalf("unlocking: %+v", err)
+	}
+	<-acquired
+
+	if err := second.Unlock(ctx, "example"); err != nil {
+		t.Fatalf("unlocking: %+v", err)
+	}
+
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.renewals == 0 {
+		t.Fatalf("expected the lease to be renewed whilst it was held")
+	}
+	if leaseId := client.blobs[backendKeyName("example")]; leaseId != "" {
+		t.Fatalf("expected the lease to be released but got %q", leaseId)
+	}
+}
+
+// fakeBlobLeaseClient is an in-memory BlobLeaseClient, where blobs maps the Blob Name to the current Lease ID
+type fakeBlobLeaseClient struct {
+	lock     sync.Mutex
+	blobs    map[string]string
+	leases   int
+	renewals int
+}
+
+func (c *fakeBlobLeaseClient) EnsureBlob(_ context.Context, blobName string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.blobs[blobName]; !ok {
+		c.blobs[blobName] = ""
+	}
+	return nil
+}
+
+func (c *fakeBlobLeaseClient) AcquireLease(_ context.Context, blobName string, _ int) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.blobs[blobName] != "" {
+		return "", ErrLeaseAlreadyPresent
+	}
+	c.leases++
+	leaseId := fmt.Sprintf("lease-%d", c.leases)
+	c.blobs[blobName] = leaseId
+	return leaseId, nil
+}
+
+func (c *fakeBlobLeaseClient) RenewLease(_ context.Context, blobName string, leaseId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.blobs[blobName] != leaseId {
+		return fmt.Errorf("the lease %q isn't held", leaseId)
+	}
+	c.renewals++
+	return nil
+}
+
+func (c *fakeBlobLeaseClient) ReleaseLease(_ context.Context, blobName string, leaseId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.blobs[blobName] != leaseId {
+		return fmt.Errorf("the lease %q isn't held", leaseId)
+	}
+	c.blobs[blobName] = ""
+	return nil
+}
@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// fileLeaseDuration is the duration after which a lock file which hasn't been renewed is considered stale
+	fileLeaseDuration = 2 * time.Minute
+
+	// fileLeaseRenewInterval is how often a held lock file is renewed
+	fileLeaseRenewInterval = 30 * time.Second
+
+	// filePollInterval is how often an existing lock file is checked whilst waiting for it to be released
+	filePollInterval = 1 * time.Second
+)
+
+var _ Backend = &FileBackend{}
+
+// FileBackend is a Backend which uses lock files within a directory on the local machine, allowing locks to be
+// shared by Terraform processes running on the same machine (or with access to the same shared directory).
+type FileBackend struct {
+	directory     string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+	pollInterval  time.Duration
+
+	lock sync.Mutex
+	held map[string]heldFileLock
+}
+
+type heldFileLock struct {
+	token      string
+	stopRenew  func()
+	lockedPath string
+}
+
+type fileLockContents struct {
+	Key      string    `json:"key"`
+	Token    string    `json:"token"`
+	Hostname string    `json:"hostname"`
+	PID      int       `json:"pid"`
+	Acquired time.Time `json:"acquired"`
+}
+
+// NewFileBackend returns a FileBackend which stores lock files within the specified directory, which is
+// created if it doesn't exist
+func NewFileBackend(directory string) (*FileBackend, error) {
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, fmt.Errorf("creating the lock directory %q: %+v", directory, err)
+	}
+
+	// confirm that we can create lock files within this directory, rather than finding out when locking
+	f, err := os.CreateTemp(directory, ".check-")
+	if err != nil {
+		return nil, fmt.Errorf("the lock directory %q isn't writable: %+v", directory, err)
+	}
+	f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		return nil, fmt.Errorf("removing %q: %+v", f.Name(), err)
+	}
+
+	return &FileBackend{
+		directory:     directory,
+		leaseDuration: fileLeaseDuration,
+		renewInterval: fileLeaseRenewInterval,
+		pollInterval:  filePollInterval,
+		held:          make(map[string]heldFileLock),
+	}, nil
+}
+
+func (b *FileBackend) Lock(ctx context.Context, key string) error {
+	path := filepath.Join(b.directory, backendKeyName(key)+".lock")
+
+	token, err := newLockToken()
+	if err != nil {
+		return err
+	}
+	hostname, _ := os.Hostname()
+	contents, err := json.Marshal(fileLockContents{
+		Key:      key,
+		Token:    token,
+		Hostname: hostname,
+		PID:      os.Getpid(),
+		Acquired: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("serializing lock file: %+v", err)
+	}
+
+	for {
+		acquired, err := b.tryCreate(path, contents)
+		if err != nil {
+			return fmt.Errorf("creating the lock file %q: %+v", path, err)
+		}
+		if acquired {
+			break
+		}
+
+		if err := b.removeIfStale(path); err != nil {
+			return err
+		}
+
+		if err := waitOrCancel(ctx, b.pollInterval); err != nil {
+			return fmt.Errorf("waiting for the lock file %q to be released: %+v", path, err)
+		}
+	}
+
+	stopRenew := renewLease(key, b.renewInterval, func() error {
+		now := time.Now()
+		return os.Chtimes(path, now, now)
+	})
+
+	b.lock.Lock()
+	b.held[key] = heldFileLock{
+		token:      token,
+		stopRenew:  stopRenew,
+		lockedPath: path,
+	}
+	b.lock.Unlock()
+
+	return nil
+}
+
+func (b *FileBackend) Unlock(_ context.Context, key string) error {
+	b.lock.Lock()
+	held, ok := b.held[key]
+	delete(b.held, key)
+	b.lock.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	held.stopRenew()
+
+	// if the lease wasn't renewed in time the lock file may since have been taken over by another process,
+	// in which case it's theirs to remove
+	removed, err := b.removeIf(held.lockedPath, func(existing *fileLockContents, _ os.FileInfo) bool {
+		return existing != nil && existing.Token == held.token
+	})
+	if err != nil {
+		return err
+	}
+	if !removed {
+		log.Printf("[WARN] The lock file %q for %q is no longer held by this process", held.lockedPath, key)
+	}
+
+	return nil
+}
+
+// removeIf removes the lock file at the specified path if shouldRemove returns true for it. Since another process
+// may replace the lock file at any point, it's moved aside (which is atomic) so that the file which is checked is the
+// file which is removed - and is moved back if it's since been renewed or replaced, and shouldn't be removed.
+//
+// Whilst the lock file is moved aside another process can acquire the lock, in which case it can't be moved back and
+// the lock may be held by both processes - as such the lock file is only moved aside when it appears to be removable,
+// and an error is returned if it can't be moved back.
+func (b *FileBackend) removeIf(path string, shouldRemove func(existing *fileLockContents, info os.FileInfo) bool) (bool, error) {
+	existing, info, err := statLockFile(path)
+	if err != nil {
+		return false, err
+	}
+	if existing == nil || !shouldRemove(existing, info) {
+		return false, nil
+	}
+
+	suffix, err := newLockToken()
+	if err != nil {
+		return false, err
+	}
+	removing := fmt.Sprintf("%s.%s.removing", path, suffix)
+
+	if err := os.Rename(path, removing); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("moving the lock file %q: %+v", path, err)
+	}
+
+	existing, info, err = statLockFile(removing)
+	if err != nil {
+		return false, err
+	}
+
+	if existing == nil || !shouldRemove(existing, info) {
+		// linking (unlike renaming) fails if a lock file has since been created at the path, rather than replacing it
+		if err := os.Link(removing, path); err != nil {
+			_ = os.Remove(removing)
+			return false, fmt.Errorf("restoring the lock file %q, which was renewed or replaced whilst being checked, since the lock was acquired by another process in the meantime - as such the lock may be held by more than one process, and should be released by both: %+v", path, err)
+		}
+		if err := os.Remove(removing); err != nil {
+			return false, fmt.Errorf("removing the lock file %q: %+v", removing, err)
+		}
+		return false, nil
+	}
+
+	if err := os.Remove(removing); err != nil {
+		return false, fmt.Errorf("removing the lock file %q: %+v", removing, err)
+	}
+
+	return true, nil
+}
+
+// statLockFile returns the contents and FileInfo for the lock file at the specified path, or nil if it doesn't exist
+func statLockFile(path string) (*fileLockContents, os.FileInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("checking the lock file %q: %+v", path, err)
+	}
+
+	existing, err := readLockFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading the lock file %q: %+v", path, err)
+	}
+
+	return existing, info, nil
+}
+
+// tryCreate creates the lock file at the specified path, returning false if it already exists
+func (b *FileBackend) tryCreate(path string, contents []byte) (bool, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if _, err := f.Write(contents); err != nil {
+		f.Close()
+		os.Remove(path)
+		return false, err
+	}
+
+	return true, f.Close()
+}
+
+// removeIfStale removes the lock file at the specified path if it hasn't been renewed within the lease duration,
+// which happens when the process holding it exits without releasing it
+func (b *FileBackend) removeIfStale(path string) error {
+	var stale *fileLockContents
+
+	// the lock file may have been renewed, or replaced by another process, since it was checked
+	removed, err := b.removeIf(path, func(existing *fileLockContents, info os.FileInfo) bool {
+		stale = existing
+		return time.Since(info.ModTime()) >= b.leaseDuration
+	})
+	if err != nil {
+		return err
+	}
+
+	if removed {
+		log.Printf("[WARN] Removed the stale lock file %q for %q (held by PID %d on %q since %s)", path, stale.Key, stale.PID, stale.Hostname, stale.Acquired.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func readLockFile(path string) (*fileLockContents, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var out fileLockContents
+	if err := json.Unmarshal(contents, &out); err != nil {
+		// the lock file is being written to, or has been modified, either way it isn't ours
+		return &fileLockContents{}, nil
+	}
+
+	return &out, nil
+}
+
+func newLockToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("generating lock token: %+v", err)
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileBackendSerialisesAcrossBackends(t *testing.T) {
+	directory := t.TempDir()
+
+	// each Backend represents a separate process sharing the same lock directory
+	first := testFileBackend(t, directory)
+	second := testFileBackend(t, directory)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := first.Lock(ctx, "example"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+
+	var (
+		lock     = &sync.Mutex{}
+		unlocked = false
+		acquired = make(chan bool)
+	)
+	go func() {
+		if err := second.Lock(ctx, "example"); err != nil {
+			t.Errorf("locking: %+v", err)
+		}
+		lock.Lock()
+		acquired <- unlocked
+		lock.Unlock()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	lock.Lock()
+	unlocked = true
+	lock.Unlock()
+	if err := first.Unlock(ctx, "example"); err != nil {
+		t.Fatalf("unlocking: %+v", err)
+	}
+
+	if wasUnlocked := <-acquired; !wasUnlocked {
+		t.Fatalf("expected the lock to be acquired only after it was released")
+	}
+	if err := second.Unlock(ctx, "example"); err != nil {
+		t.Fatalf("unlocking: %+v", err)
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		t.Fatalf("listing lock directory: %+v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected the lock directory to be empty but got %d entries", len(entries))
+	}
+}
+
+func TestFileBackendRemovesStaleLocks(t *testing.T) {
+	directory := t.TempDir()
+	backend := testFileBackend(t, directory)
+
+	// a lock file left behind by a process which exited without unlocking
+	path := filepath.Join(directory, backendKeyName("example")+".lock")
+	if err := os.WriteFile(path, []byte(`{"key":"example","token":"abc123"}`), 0o600); err != nil {
+		t.Fatalf("writing lock file: %+v", err)
+	}
+	stale := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, stale, stale); err != nil {
+		t.Fatalf("updating lock file: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := backend.Lock(ctx, "example"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+	if err := backend.Unlock(ctx, "example"); err != nil {
+		t.Fatalf("unlocking: %+v", err)
+	}
+}
+
+func TestFileBackendLockCancelled(t *testing.T) {
+	directory := t.TempDir()
+	first := testFileBackend(t, directory)
+	second := testFileBackend(t, directory)
+
+	if err := first.Lock(context.Background(), "example"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+	defer func() {
+		if err := first.Unlock(context.Background(), "example"); err != nil {
+			t.Fatalf("unlocking: %+v", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := second.Lock(ctx, "example"); err == nil {
+		t.Fatalf("expected an error when the context is cancelled but didn't get one")
+	}
+}
+
+func TestFileBackendUnlockDoesNotRemoveLocksTakenOver(t *testing.T) {
+	directory := t.TempDir()
+	first := testFileBackend(t, directory)
+	second := testFileBackend(t, directory)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := first.Lock(ctx, "example"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+
+	// the lease held by the first backend expires, and the lock is taken over by the second
+	second.leaseDuration = 0
+	if err := second.Lock(ctx, "example"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+
+	if err := first.Unlock(ctx, "example"); err != nil {
+		t.Fatalf("unlocking: %+v", err)
+	}
+
+	path := filepath.Join(directory, backendKeyName("example")+".lock")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the lock file held by the second backend to remain but got: %+v", err)
+	}
+	if err := second.Unlock(ctx, "example"); err != nil {
+		t.Fatalf("unlocking: %+v", err)
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		t.Fatalf("listing lock directory: %+v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected the lock directory to be empty but got %d entries", len(entries))
+	}
+}
+
+func TestFileBackendRemoveIfReturnsErrorWhenLockAcquiredWhilstChecking(t *testing.T) {
+	directory := t.TempDir()
+	backend := testFileBackend(t, directory)
+
+	path := filepath.Join(directory, backendKeyName("example")+".lock")
+	if err := os.WriteFile(path, []byte(`{"key":"example","token":"abc123"}`), 0o600); err != nil {
+		t.Fatalf("writing lock file: %+v", err)
+	}
+
+	checks := 0
+	_, err := backend.removeIf(path, func(existing *fileLockContents, _ os.FileInfo) bool {
+		checks++
+		if checks == 1 {
+			return true
+		}
+
+		// the lock file is renewed whilst it's moved aside, during which another process acquires the lock
+		if err := os.WriteFile(path, []byte(`{"key":"example","token":"def456"}`), 0o600); err != nil {
+			t.Fatalf("writing lock file: %+v", err)
+		}
+		return false
+	})
+	if err == nil {
+		t.Fatalf("expected an error when the lock file couldn't be restored but didn't get one")
+	}
+
+	existing, err := readLockFile(path)
+	if err != nil {
+		t.Fatalf("reading lock file: %+v", err)
+	}
+	if existing == nil || existing.Token != "def456" {
+		t.Fatalf("expected the lock file acquired by the other process to remain but got %+v", existing)
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		t.Fatalf("listing lock directory: %+v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the lock file to remain but got %d entries", len(entries))
+	}
+}
+
+func testFileBackend(t *testing.T, directory string) *FileBackend {
+	backend, err := NewFileBackend(directory)
+	if err != nil {
+		t.Fatalf("building backend: %+v", err)
+	}
+	backend.pollInterval = 5 * time.Millisecond
+	backend.renewInterval = 10 * time.Millisecond
+	return backend
+}
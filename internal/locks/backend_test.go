@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type unavailableBackend struct{}
+
+func (unavailableBackend) Lock(_ context.Context, _ string) error {
+	return errors.New("the backend is unavailable")
+}
+
+func (unavailableBackend) Unlock(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestLockReturnsBackendError(t *testing.T) {
+	ConfigureBackend(unavailableBackend{})
+	defer ConfigureBackend(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := ByName(ctx, "example", "azurerm_example"); err == nil {
+		t.Fatalf("expected an error when the Lock Backend is unavailable but didn't get one")
+	}
+
+	// the in-memory lock must have been released, so that it can be acquired again
+	ConfigureBackend(nil)
+	if err := ByName(ctx, "example", "azurerm_example"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+	UnlockByName("example", "azurerm_example")
+}
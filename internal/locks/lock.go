@@ -3,34 +3,51 @@
 
 package locks
 
+import (
+	"context"
+)
+
 // armMutexKV is the instance of MutexKV for ARM resources
 var armMutexKV = newMutexKV()
 
-func ByID(id string) {
-	armMutexKV.Lock(id)
+// ByID acquires the lock for the given ID, returning an error if the context is cancelled (or times out) before
+// the lock is acquired. UnlockByID must be called once the lock is no longer needed if this succeeds
+func ByID(ctx context.Context, id string) error {
+	return lock(ctx, id, ownerFromContext(ctx))
 }
 
-// handle the case of using the same name for different kinds of resources
-func ByName(name string, resourceType string) {
+// ByName acquires the lock for the given name and resource type (to handle the case of using the same name for
+// different kinds of resources), returning an error if the context is cancelled (or times out) before the lock is
+// acquired. UnlockByName must be called once the lock is no longer needed if this succeeds
+func ByName(ctx context.Context, name string, resourceType string) error {
 	updatedName := resourceType + "." + name
-	armMutexKV.Lock(updatedName)
+	return lock(ctx, updatedName, ownerFromContext(ctx))
 }
 
-func MultipleByName(names *[]string, resourceType string) {
+// MultipleByName acquires the locks for each of the given names, releasing any locks which have already been
+// acquired if one can't be. UnlockMultipleByName must be called once the locks are no longer needed if this succeeds
+func MultipleByName(ctx context.Context, names *[]string, resourceType string) error {
 	newSlice := removeDuplicatesFromStringArray(*names)
 
-	for _, name := range newSlice {
-		ByName(name, resourceType)
+	for i, name := range newSlice {
+		if err := ByName(ctx, name, resourceType); err != nil {
+			for _, acquired := range newSlice[:i] {
+				UnlockByName(acquired, resourceType)
+			}
+			return err
+		}
 	}
+
+	return nil
 }
 
 func UnlockByID(id string) {
-	armMutexKV.Unlock(id)
+	unlock(id)
 }
 
 func UnlockByName(name string, resourceType string) {
 	updatedName := resourceType + "." + name
-	armMutexKV.Unlock(updatedName)
+	unlock(updatedName)
 }
 
 func UnlockMultipleByName(names *[]string, resourceType string) {
@@ -4,8 +4,22 @@
 package locks
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"sync"
+	"time"
+)
+
+const (
+	// defaultHoldWarningThreshold is the duration after which a lock which is still held is reported, since this
+	// generally indicates a missing Unlock (or an operation which is stuck)
+	defaultHoldWarningThreshold = 30 * time.Minute
+
+	// defaultWatchdogInterval is how often held locks are checked against the hold warning threshold
+	defaultWatchdogInterval = 1 * time.Minute
 )
 
 // mutexKV is a simple key/value store for arbitrary mutexes. It can be used to
@@ -13,39 +27,229 @@ import (
 // keys they must serialize on.
 type mutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]*keyLock
+	stats map[string]*keyStats
+
+	holdWarningThreshold time.Duration
+	watchdogInterval     time.Duration
+	watchdogOnce         sync.Once
+}
+
+// keyLock is a lock for a single key, which is removed from the store once it's neither held nor waited on
+type keyLock struct {
+	// held contains a value whilst this lock is held, so that acquiring the lock can be cancelled
+	held chan struct{}
+
+	// references is the number of callers holding, or waiting to acquire, this lock
+	references int
+
+	owner     string
+	heldSince time.Time
+	reported  bool
 }
 
-// Locks the mutex for the given key. Caller is responsible for calling Unlock
-// for the same key
-func (m *mutexKV) Lock(key string) {
+// keyStats are the statistics for a single key over the lifetime of this process
+type keyStats struct {
+	acquisitions int
+	totalWait    time.Duration
+	longestWait  time.Duration
+	totalHold    time.Duration
+	longestHold  time.Duration
+}
+
+// LockWithContext locks the mutex for the given key, returning an error if the context is cancelled (or times out)
+// before the lock is acquired. The owner describes what's acquiring the lock, and is used when reporting locks which
+// are held for longer than expected. Caller is responsible for calling Unlock for the same key if this succeeds
+func (m *mutexKV) LockWithContext(ctx context.Context, key string, owner string) error {
+	m.watchdogOnce.Do(func() {
+		go m.watchdog()
+	})
+
 	log.Printf("[DEBUG] Locking %q", key)
-	m.get(key).Lock()
-	log.Printf("[DEBUG] Locked %q", key)
+	lock := m.get(key)
+
+	start := time.Now()
+	select {
+	case lock.held <- struct{}{}:
+	case <-ctx.Done():
+		m.release(key, lock)
+
+		m.lock.Lock()
+		holder := lock.owner
+		m.lock.Unlock()
+		return fmt.Errorf("waiting %s for the lock %q (held by %s): %+v", time.Since(start).Round(time.Millisecond), key, holder, ctx.Err())
+	}
+	wait := time.Since(start)
+
+	m.lock.Lock()
+	lock.owner = owner
+	lock.heldSince = time.Now()
+	lock.reported = false
+	stats := m.statsFor(key)
+	stats.acquisitions++
+	stats.totalWait += wait
+	if wait > stats.longestWait {
+		stats.longestWait = wait
+	}
+	m.lock.Unlock()
+
+	log.Printf("[DEBUG] Locked %q (waited %s)", key, wait.Round(time.Millisecond))
+	return nil
 }
 
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first
 func (m *mutexKV) Unlock(key string) {
 	log.Printf("[DEBUG] Unlocking %q", key)
-	m.get(key).Unlock()
-	log.Printf("[DEBUG] Unlocked %q", key)
+
+	m.lock.Lock()
+	lock, ok := m.store[key]
+	if !ok || len(lock.held) == 0 {
+		m.lock.Unlock()
+		panic(fmt.Sprintf("unlock of unlocked key %q", key))
+	}
+
+	hold := time.Since(lock.heldSince)
+	if lock.reported {
+		log.Printf("[WARN] The lock %q held by %s was released after %s", key, lock.owner, hold.Round(time.Second))
+	}
+	stats := m.statsFor(key)
+	stats.totalHold += hold
+	if hold > stats.longestHold {
+		stats.longestHold = hold
+	}
+	lock.owner = ""
+	m.lock.Unlock()
+
+	<-lock.held
+	idle := m.release(key, lock)
+	log.Printf("[DEBUG] Unlocked %q (held %s)", key, hold.Round(time.Millisecond))
+
+	// there's no notification that Terraform has finished with the Provider (which is killed once it's done), so the
+	// statistics are logged whenever no locks are held or waited on, the last of which is at the end of the apply
+	if idle {
+		log.Printf("[INFO] Lock statistics: %s", m.Statistics(10))
+	}
 }
 
-// Returns a mutex for the given key, no guarantee of its lock status
-func (m *mutexKV) get(key string) *sync.Mutex {
+// Returns the lock for the given key, no guarantee of its lock status. Callers must call release once they no
+// longer hold (or are no longer waiting on) this lock
+func (m *mutexKV) get(key string) *keyLock {
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	mutex, ok := m.store[key]
+	lock, ok := m.store[key]
 	if !ok {
-		mutex = &sync.Mutex{}
-		m.store[key] = mutex
+		lock = &keyLock{
+			held: make(chan struct{}, 1),
+		}
+		m.store[key] = lock
+	}
+	lock.references++
+	return lock
+}
+
+// release removes the lock for the given key from the store once it's no longer referenced, returning whether
+// no locks remain in the store
+func (m *mutexKV) release(key string, lock *keyLock) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	lock.references--
+	if lock.references == 0 {
+		delete(m.store, key)
+	}
+	return len(m.store) == 0
+}
+
+// statsFor returns the statistics for the given key, the caller must hold m.lock
+func (m *mutexKV) statsFor(key string) *keyStats {
+	stats, ok := m.stats[key]
+	if !ok {
+		stats = &keyStats{}
+		m.stats[key] = stats
+	}
+	return stats
+}
+
+// watchdog periodically reports any locks which have been held for longer than the hold warning threshold
+func (m *mutexKV) watchdog() {
+	ticker := time.NewTicker(m.watchdogInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for _, message := range m.heldPastThreshold() {
+			log.Print(message)
+		}
+	}
+}
+
+// heldPastThreshold returns a message for each lock which has been held for longer than the hold warning
+// threshold and hasn't yet been reported
+func (m *mutexKV) heldPastThreshold() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	messages := make([]string, 0)
+	for key, lock := range m.store {
+		if len(lock.held) == 0 || lock.reported {
+			continue
+		}
+
+		held := time.Since(lock.heldSince)
+		if held < m.holdWarningThreshold {
+			continue
+		}
+
+		lock.reported = true
+		messages = append(messages, fmt.Sprintf("[WARN] The lock %q has been held by %s for %s, with %d other operation(s) waiting for it - this may indicate that the lock is never released", key, lock.owner, held.Round(time.Second), lock.references-1))
+	}
+	sort.Strings(messages)
+	return messages
+}
+
+// Statistics returns a summary of the time spent waiting for, and holding, locks - ordered by the keys with the
+// longest total wait time, limited to the specified number of keys
+func (m *mutexKV) Statistics(limit int) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if len(m.stats) == 0 {
+		return "no locks were acquired"
+	}
+
+	keys := make([]string, 0, len(m.stats))
+	total := keyStats{}
+	for key, stats := range m.stats {
+		keys = append(keys, key)
+		total.acquisitions += stats.acquisitions
+		total.totalWait += stats.totalWait
+		total.totalHold += stats.totalHold
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if m.stats[keys[i]].totalWait == m.stats[keys[j]].totalWait {
+			return keys[i] < keys[j]
+		}
+		return m.stats[keys[i]].totalWait > m.stats[keys[j]].totalWait
+	})
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	lines := []string{
+		fmt.Sprintf("%d lock(s) acquired across %d key(s), spending %s waiting and %s held", total.acquisitions, len(m.stats), total.totalWait.Round(time.Millisecond), total.totalHold.Round(time.Millisecond)),
+	}
+	for _, key := range keys {
+		stats := m.stats[key]
+		lines = append(lines, fmt.Sprintf("  %q: %d acquisition(s), waited %s (longest %s), held %s (longest %s)", key, stats.acquisitions, stats.totalWait.Round(time.Millisecond), stats.longestWait.Round(time.Millisecond), stats.totalHold.Round(time.Millisecond), stats.longestHold.Round(time.Millisecond)))
 	}
-	return mutex
+	return strings.Join(lines, "\n")
 }
 
 // newMutexKV returns a properly initialized mutexKV
 func newMutexKV() *mutexKV {
 	return &mutexKV{
-		store: make(map[string]*sync.Mutex),
+		store:                make(map[string]*keyLock),
+		stats:                make(map[string]*keyStats),
+		holdWarningThreshold: defaultHoldWarningThreshold,
+		watchdogInterval:     defaultWatchdogInterval,
 	}
 }
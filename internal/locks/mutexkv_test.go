@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMutexKVRemovesUnusedKeys(t *testing.T) {
+	m := newMutexKV()
+
+	for _, key := range []string{"first", "second", "first"} {
+		if err := m.LockWithContext(context.Background(), key, "test"); err != nil {
+			t.Fatalf("locking %q: %+v", key, err)
+		}
+		m.Unlock(key)
+	}
+
+	if len(m.store) != 0 {
+		t.Fatalf("expected no keys to remain but got %d", len(m.store))
+	}
+	if m.stats["first"].acquisitions != 2 || m.stats["second"].acquisitions != 1 {
+		t.Fatalf("expected the acquisitions to be recorded but got %+v / %+v", *m.stats["first"], *m.stats["second"])
+	}
+}
+
+func TestMutexKVLockWithContextTimesOut(t *testing.T) {
+	m := newMutexKV()
+
+	if err := m.LockWithContext(context.Background(), "example", "azurerm_subnet (Create)"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := m.LockWithContext(ctx, "example", "azurerm_subnet (Delete)")
+	if err == nil {
+		t.Fatalf("expected an error when the context times out but didn't get one")
+	}
+	if !strings.Contains(err.Error(), "held by azurerm_subnet (Create)") {
+		t.Fatalf("expected the error to contain the owner of the lock but got: %+v", err)
+	}
+	if m.store["example"].references != 1 {
+		t.Fatalf("expected the cancelled caller to release its reference but got %d references", m.store["example"].references)
+	}
+
+	m.Unlock("example")
+	if err := m.LockWithContext(context.Background(), "example", "test"); err != nil {
+		t.Fatalf("locking after the timeout: %+v", err)
+	}
+	m.Unlock("example")
+}
+
+func TestMutexKVWaitsForUnlock(t *testing.T) {
+	m := newMutexKV()
+
+	if err := m.LockWithContext(context.Background(), "example", "first"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		if err := m.LockWithContext(context.Background(), "example", "second"); err != nil {
+			t.Errorf("locking: %+v", err)
+		}
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatalf("expected the lock not to be acquired whilst it's held")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	m.Unlock("example")
+	<-acquired
+	m.Unlock("example")
+
+	if m.stats["example"].longestWait < 20*time.Millisecond {
+		t.Fatalf("expected the wait to be recorded but got %s", m.stats["example"].longestWait)
+	}
+	if !strings.Contains(m.Statistics(10), `2 lock(s) acquired across 1 key(s)`) {
+		t.Fatalf("unexpected statistics: %s", m.Statistics(10))
+	}
+}
+
+func TestMutexKVReportsLocksHeldPastThreshold(t *testing.T) {
+	m := newMutexKV()
+	m.holdWarningThreshold = 10 * time.Millisecond
+
+	if err := m.LockWithContext(context.Background(), "example", "azurerm_subnet (Update /subscriptions/00000000-0000-0000-0000-000000000000)"); err != nil {
+		t.Fatalf("locking: %+v", err)
+	}
+	if messages := m.heldPastThreshold(); len(messages) != 0 {
+		t.Fatalf("expected no locks to be reported before the threshold but got %+v", messages)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	messages := m.heldPastThreshold()
+	if len(messages) != 1 || !strings.Contains(messages[0], `The lock "example" has been held by azurerm_subnet (Update`) {
+		t.Fatalf("expected the lock to be reported with its owner but got %+v", messages)
+	}
+	if messages := m.heldPastThreshold(); len(messages) != 0 {
+		t.Fatalf("expected the lock to only be reported once but got %+v", messages)
+	}
+
+	m.Unlock("example")
+}
+
+func TestMutexKVUnlockOfUnlockedKeyPanics(t *testing.T) {
+	m := newMutexKV()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected unlocking an unlocked key to panic")
+		}
+	}()
+	m.Unlock("example")
+}
+
+func TestCallerOwner(t *testing.T) {
+	owner := ownerFromContext(context.Background())
+	if !strings.Contains(owner, "TestCallerOwner") {
+		t.Fatalf("expected the owner to be the calling function but got %q", owner)
+	}
+
+	owner = ownerFromContext(WithOwner(context.Background(), "azurerm_subnet"))
+	if owner != "azurerm_subnet" {
+		t.Fatalf("expected the owner to be %q but got %q", "azurerm_subnet", owner)
+	}
+}
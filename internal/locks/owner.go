@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+type ownerContextKey struct{}
+
+// WithOwner returns a context which identifies the Resource acquiring locks (for example the Resource Type and ID),
+// which is reported when a lock acquired using this context is held for longer than expected
+func WithOwner(ctx context.Context, owner string) context.Context {
+	return context.WithValue(ctx, ownerContextKey{}, owner)
+}
+
+// ownerFromContext returns the owner specified using WithOwner, falling back to the function acquiring the lock
+func ownerFromContext(ctx context.Context) string {
+	if v, ok := ctx.Value(ownerContextKey{}).(string); ok && v != "" {
+		return v
+	}
+	return callerOwner()
+}
+
+// callerOwner returns the first function outside of this package in the call stack, which is used to identify
+// what's acquiring a lock when no owner has been specified
+func callerOwner() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.Function, "/internal/locks.") || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s (%s:%d)", frame.Function, filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return "an unknown caller"
+		}
+	}
+}
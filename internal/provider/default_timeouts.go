@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func schemaDefaultTimeouts() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		Description: "The default timeouts which should be used for a Resource Type, instead of the built-in defaults. A `timeouts` block within a resource takes precedence over these.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"resource_type": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"create": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validateTimeoutDuration,
+				},
+
+				"read": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validateTimeoutDuration,
+				},
+
+				"update": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validateTimeoutDuration,
+				},
+
+				"delete": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validateTimeoutDuration,
+				},
+			},
+		},
+	}
+}
+
+func expandDefaultTimeouts(input []interface{}) (map[string]timeouts.ResourceTimeouts, error) {
+	output := make(map[string]timeouts.ResourceTimeouts)
+
+	for _, item := range input {
+		if item == nil {
+			continue
+		}
+
+		raw := item.(map[string]interface{})
+		resourceType := raw["resource_type"].(string)
+		if _, exists := output[resourceType]; exists {
+			return nil, fmt.Errorf("`default_timeouts` can only be specified once for the Resource Type %q", resourceType)
+		}
+
+		// Validate should have ignored these errors already
+		parse := func(key string) *time.Duration {
+			v, ok := raw[key].(string)
+			if !ok || v == "" {
+				return nil
+			}
+			duration, _ := time.ParseDuration(v)
+			return &duration
+		}
+
+		output[resourceType] = timeouts.ResourceTimeouts{
+			Create: parse("create"),
+			Read:   parse("read"),
+			Update: parse("update"),
+			Delete: parse("delete"),
+		}
+	}
+
+	return output, nil
+}
+
+func validateTimeoutDuration(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	duration, err := time.ParseDuration(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("expected %q to be a duration (for example `30m` or `2h`) but got %q: %+v", k, v, err))
+		return
+	}
+	if duration <= 0 {
+		errors = append(errors, fmt.Errorf("expected %q to be a positive duration but got %q", k, v))
+	}
+
+	return
+}
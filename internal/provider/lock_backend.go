@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2020-08-04/blob/blobs"
+)
+
+func schemaLockBackend() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Configures where locks are held, so that operations on shared resources (such as Subnets within a Virtual Network) are serialised across multiple Terraform processes, rather than only within this one.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"directory": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					ExactlyOneOf: []string{"lock_backend.0.directory", "lock_backend.0.storage_account_name"},
+				},
+
+				"storage_account_name": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					ExactlyOneOf: []string{"lock_backend.0.directory", "lock_backend.0.storage_account_name"},
+					RequiredWith: []string{"lock_backend.0.storage_container_name"},
+				},
+
+				"storage_container_name": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					RequiredWith: []string{"lock_backend.0.storage_account_name"},
+				},
+			},
+		},
+	}
+}
+
+func expandLockBackend(ctx context.Context, input []interface{}, client *clients.Client) (locks.Backend, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	if directory := raw["directory"].(string); directory != "" {
+		return locks.NewFileBackend(directory)
+	}
+
+	accountName := raw["storage_account_name"].(string)
+	containerName := raw["storage_container_name"].(string)
+
+	account, err := client.Storage.FindAccount(ctx, accountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the Storage Account %q for the Lock Backend: %+v", accountName, err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("the Storage Account %q for the Lock Backend was not found", accountName)
+	}
+
+	blobsClient, err := client.Storage.BlobsClient(ctx, *account)
+	if err != nil {
+		return nil, fmt.Errorf("building Blobs Client for the Lock Backend: %+v", err)
+	}
+
+	return locks.NewBlobLeaseBackend(blobLeaseClient{
+		client:        blobsClient,
+		accountName:   accountName,
+		containerName: containerName,
+	}), nil
+}
+
+var _ locks.BlobLeaseClient = blobLeaseClient{}
+
+// blobLeaseClient acquires Leases on Blobs within a single Storage Container for the Lock Backend
+type blobLeaseClient struct {
+	client        *blobs.Client
+	accountName   string
+	containerName string
+}
+
+func (c blobLeaseClient) EnsureBlob(ctx context.Context, blobName string) error {
+	existing, err := c.client.GetProperties(ctx, c.accountName, c.containerName, blobName, blobs.GetPropertiesInput{})
+	if err == nil {
+		return nil
+	}
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return fmt.Errorf("retrieving Blob %q: %+v", blobName, err)
+	}
+
+	// another process may have created (and leased) this Blob in the meantime, in which case it exists
+	if resp, err := c.client.PutBlockBlob(ctx, c.accountName, c.containerName, blobName, blobs.PutBlockBlobInput{}); err != nil && !utils.ResponseWasStatusCode(resp, http.StatusPreconditionFailed) {
+		return fmt.Errorf("creating Blob %q: %+v", blobName, err)
+	}
+
+	return nil
+}
+
+func (c blobLeaseClient) AcquireLease(ctx context.Context, blobName string, durationInSeconds int) (string, error) {
+	result, err := c.client.AcquireLease(ctx, c.accountName, c.containerName, blobName, blobs.AcquireLeaseInput{
+		LeaseDuration: durationInSeconds,
+	})
+	if err != nil {
+		if utils.ResponseWasConflict(result.Response) {
+			return "", locks.ErrLeaseAlreadyPresent
+		}
+		return "", err
+	}
+
+	return result.LeaseID, nil
+}
+
+func (c blobLeaseClient) RenewLease(ctx context.Context, blobName string, leaseId string) error {
+	_, err := c.client.RenewLease(ctx, c.accountName, c.containerName, blobName, leaseId)
+	return err
+}
+
+func (c blobLeaseClient) ReleaseLease(ctx context.Context, blobName string, leaseId string) error {
+	_, err := c.client.ReleaseLease(ctx, c.accountName, c.containerName, blobName, leaseId)
+	return err
+}
@@ -19,8 +19,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
@@ -28,10 +31,26 @@ func AzureProvider() *schema.Provider {
 	return azureProvider(false)
 }
 
+// AzureProviderWithRecorder returns a Provider which records or replays the requests made to Azure using the
+// specified Recorder, allowing tooling to run against recorded API responses
+func AzureProviderWithRecorder(recorder *common.Recorder) *schema.Provider {
+	p := azureProvider(false)
+	p.ConfigureContextFunc = providerConfigure(p, recorder)
+	return p
+}
+
 func TestAzureProvider() *schema.Provider {
 	return azureProvider(true)
 }
 
+// TestAzureProviderWithRecorder returns a Provider which records or replays the requests made during
+// an Acceptance Test using the specified Recorder
+func TestAzureProviderWithRecorder(recorder *common.Recorder) *schema.Provider {
+	p := azureProvider(true)
+	p.ConfigureContextFunc = providerConfigure(p, recorder)
+	return p
+}
+
 func ValidatePartnerID(i interface{}, k string) ([]string, []error) {
 	// ValidatePartnerID checks if partner_id is any of the following:
 	//  * a valid UUID - will add "pid-" prefix to the ID if it is not already present
@@ -165,6 +184,13 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 		}
 	}
 
+	for _, dataSource := range dataSources {
+		wrapDataSourceWithTagsConfig(dataSource)
+	}
+	for _, resource := range resources {
+		wrapResourceWithTagsConfig(resource)
+	}
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
@@ -346,24 +372,64 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "Should the AzureRM Provider skip registering all of the Resource Providers that it supports, if they're not already registered?",
 			},
 
+			"resource_providers_to_register": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"skip_provider_registration"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Description: "A list of Resource Providers which should be registered, rather than all of the Resource Providers that the AzureRM Provider supports.",
+			},
+
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_READ_ONLY", false),
+				Description: "Should the AzureRM Provider block any request which could modify a resource? This allows running `terraform plan` with credentials which are able to make changes.",
+			},
+
+			"read_only_allowed_post_operations": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Description: "A list of additional POST operations (for example `listKeys`) which only retrieve data, and so should be allowed when `read_only` is enabled. These are allowed in addition to a list of commonly used operations.",
+			},
+
 			"storage_use_azuread": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_STORAGE_USE_AZUREAD", false),
 				Description: "Should the AzureRM Provider use AzureAD to access the Storage Data Plane API's?",
 			},
+
+			"default_tags": schemaDefaultTags(),
+
+			"default_timeouts": schemaDefaultTimeouts(),
+
+			"ignore_tags": schemaIgnoreTags(),
+
+			"retry": schemaRetry(),
+
+			"lock_backend": schemaLockBackend(),
+
+			"request_tracing": schemaRequestTracing(),
 		},
 
 		DataSourcesMap: dataSources,
 		ResourcesMap:   resources,
 	}
 
-	p.ConfigureContextFunc = providerConfigure(p)
+	p.ConfigureContextFunc = providerConfigure(p, nil)
 
 	return p
 }
 
-func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
+func providerConfigure(p *schema.Provider, recorder *common.Recorder) schema.ConfigureContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		var auxTenants []string
 		if v, ok := d.Get("auxiliary_tenant_ids").([]interface{}); ok && len(v) > 0 {
@@ -446,13 +512,39 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			EnableAuthenticationUsingGitHubOIDC:        enableOidc,
 		}
 
-		return buildClient(ctx, p, d, authConfig)
+		return buildClient(ctx, p, d, authConfig, recorder)
 	}
 }
 
-func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials) (*clients.Client, diag.Diagnostics) {
+func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials, recorder *common.Recorder) (*clients.Client, diag.Diagnostics) {
 	skipProviderRegistration := d.Get("skip_provider_registration").(bool)
 
+	defaultTimeouts, err := expandDefaultTimeouts(d.Get("default_timeouts").([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	resources, err := timeouts.ApplyDefaults(p.ResourcesMap, defaultTimeouts)
+	if err != nil {
+		return nil, diag.Errorf("configuring `default_timeouts`: %+v", err)
+	}
+	p.ResourcesMap = resources
+
+	var diags diag.Diagnostics
+
+	retryPolicy, err := expandRetry(d.Get("retry").([]interface{}))
+	if err != nil {
+		return nil, diag.Errorf("configuring `retry`: %+v", err)
+	}
+	if retryPolicy != nil {
+		if unsupported := retryPolicy.UnsupportedBySdkClients(); len(unsupported) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Some `retry` settings only apply to some resources",
+				Detail:   fmt.Sprintf("The `retry` fields %s only apply to resources which use the `Azure/go-autorest` SDK. Resources which use the `hashicorp/go-azure-sdk` SDK use the retry handling built into that SDK, which only supports disabling retries by setting `max_attempts` to `1`.", "`"+strings.Join(unsupported, "`, `")+"`"),
+			})
+		}
+	}
+
 	clientBuilder := clients.ClientBuilder{
 		AuthConfig:                  authConfig,
 		DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
@@ -460,9 +552,12 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		Features:                    expandFeatures(d.Get("features").([]interface{})),
 		MetadataHost:                d.Get("metadata_host").(string),
 		PartnerID:                   d.Get("partner_id").(string),
+		Recorder:                    recorder,
+		RetryPolicy:                 retryPolicy,
 		SkipProviderRegistration:    skipProviderRegistration,
 		StorageUseAzureAD:           d.Get("storage_use_azuread").(bool),
 		SubscriptionID:              d.Get("subscription_id").(string),
+		Tags:                        expandTagsConfig(d),
 		TerraformVersion:            p.TerraformVersion,
 
 		// this field is intentionally not exposed in the provider block, since it's only used for
@@ -476,6 +571,22 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		stopCtx = ctx
 	}
 
+	readOnly := d.Get("read_only").(bool)
+	if readOnly {
+		allowedPostOperations := make([]string, 0)
+		allowedPostOperations = append(allowedPostOperations, common.DefaultReadOnlyAllowedPostOperations...)
+		allowedPostOperations = append(allowedPostOperations, *utils.ExpandStringSlice(d.Get("read_only_allowed_post_operations").(*schema.Set).List())...)
+		clientBuilder.ReadOnlyPolicy = &common.ReadOnlyPolicy{
+			AllowedPostOperations: allowedPostOperations,
+		}
+	}
+
+	if v := d.Get("request_tracing").([]interface{}); len(v) > 0 && v[0] != nil {
+		tracing := v[0].(map[string]interface{})
+		clientBuilder.TraceFilePath = tracing["file_path"].(string)
+		clientBuilder.TraceRedactedFields = *utils.ExpandStringSlice(tracing["redacted_fields"].([]interface{}))
+	}
+
 	client, err := clients.Build(stopCtx, clientBuilder)
 	if err != nil {
 		return nil, diag.FromErr(err)
@@ -483,9 +594,25 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 
 	client.StopContext = stopCtx
 
-	if !skipProviderRegistration {
+	lockBackend, err := expandLockBackend(stopCtx, d.Get("lock_backend").([]interface{}), client)
+	if err != nil {
+		return nil, diag.Errorf("configuring the Lock Backend: %+v", err)
+	}
+	locks.ConfigureBackend(lockBackend)
+
+	// registering Resource Providers modifies the Subscription, so this is skipped in read-only mode
+	if readOnly && !skipProviderRegistration {
+		log.Printf("[DEBUG] Skipping Resource Provider Registration since the Provider is running in read-only mode")
+	}
+	if !skipProviderRegistration && !readOnly {
 		subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
 		requiredResourceProviders := resourceproviders.Required()
+		if v := d.Get("resource_providers_to_register").(*schema.Set).List(); len(v) > 0 {
+			requiredResourceProviders = make(map[string]struct{}, len(v))
+			for _, providerName := range v {
+				requiredResourceProviders[providerName.(string)] = struct{}{}
+			}
+		}
 		ctx2, cancel := context.WithTimeout(ctx, 30*time.Minute)
 		defer cancel()
 
@@ -494,7 +621,7 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		}
 	}
 
-	return client, nil
+	return client, diags
 }
 
 func decodeCertificate(clientCertificate string) ([]byte, error) {
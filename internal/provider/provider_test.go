@@ -145,7 +145,7 @@ func TestAccProvider_cliAuth(t *testing.T) {
 			EnableAuthenticatingUsingAzureCLI: true,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -207,7 +207,7 @@ func TestAccProvider_clientCertificateAuth(t *testing.T) {
 			ClientCertificatePassword:                  d.Get("client_certificate_password").(string),
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -275,7 +275,7 @@ func testAccProvider_clientSecretAuthFromEnvironment(t *testing.T) {
 			ClientSecret:                          *clientSecret,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -338,7 +338,7 @@ func testAccProvider_clientSecretAuthFromFiles(t *testing.T) {
 			ClientSecret:                          *clientSecret,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -388,7 +388,7 @@ func TestAccProvider_genericOidcAuth(t *testing.T) {
 			OIDCAssertionToken:            *oidcToken,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -437,7 +437,7 @@ func TestAccProvider_githubOidcAuth(t *testing.T) {
 			GitHubOIDCTokenRequestURL:           d.Get("oidc_request_url").(string),
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
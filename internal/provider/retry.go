@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func schemaRetry() *pluginsdk.Schema {
+	defaults := common.DefaultRetryPolicy()
+
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Configures how requests which receive a retryable response (such as throttling or a transient server error) should be retried.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"max_attempts": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      defaults.MaxAttempts,
+					ValidateFunc: validation.IntBetween(1, 50),
+				},
+
+				"min_backoff_in_seconds": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      int(defaults.MinBackoff / time.Second),
+					ValidateFunc: validation.IntBetween(1, 3600),
+				},
+
+				"max_backoff_in_seconds": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      int(defaults.MaxBackoff / time.Second),
+					ValidateFunc: validation.IntBetween(1, 3600),
+				},
+
+				"retryable_status_codes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeInt,
+						ValidateFunc: validation.IntBetween(400, 599),
+					},
+				},
+
+				"honour_retry_after_header": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  defaults.HonourRetryAfterHeader,
+				},
+			},
+		},
+	}
+}
+
+func expandRetry(input []interface{}) (*common.RetryPolicy, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+
+	statusCodes := make([]int, 0)
+	if v, ok := raw["retryable_status_codes"]; ok {
+		for _, code := range v.(*pluginsdk.Set).List() {
+			statusCodes = append(statusCodes, code.(int))
+		}
+	}
+	if len(statusCodes) == 0 {
+		statusCodes = common.DefaultRetryableStatusCodes
+	}
+
+	minBackoff := time.Duration(raw["min_backoff_in_seconds"].(int)) * time.Second
+	maxBackoff := time.Duration(raw["max_backoff_in_seconds"].(int)) * time.Second
+	if maxBackoff < minBackoff {
+		return nil, fmt.Errorf("`max_backoff_in_seconds` (%d) must be greater than or equal to `min_backoff_in_seconds` (%d)", raw["max_backoff_in_seconds"].(int), raw["min_backoff_in_seconds"].(int))
+	}
+
+	return &common.RetryPolicy{
+		MaxAttempts:            raw["max_attempts"].(int),
+		MinBackoff:             minBackoff,
+		MaxBackoff:             maxBackoff,
+		RetryableStatusCodes:   statusCodes,
+		HonourRetryAfterHeader: raw["honour_retry_after_header"].(bool),
+	}, nil
+}
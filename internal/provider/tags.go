@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func schemaDefaultTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Tags which should be applied to all resources which support Tags. Tags specified on a resource take precedence over these.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"tags": {
+					Type:         pluginsdk.TypeMap,
+					Required:     true,
+					ValidateFunc: tags.Validate,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaIgnoreTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Tags which should be ignored when reading resources, for example Tags which are managed by Azure Policy.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"keys": {
+					Type:         pluginsdk.TypeSet,
+					Optional:     true,
+					AtLeastOneOf: []string{"ignore_tags.0.keys", "ignore_tags.0.key_prefixes"},
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"key_prefixes": {
+					Type:         pluginsdk.TypeSet,
+					Optional:     true,
+					AtLeastOneOf: []string{"ignore_tags.0.keys", "ignore_tags.0.key_prefixes"},
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandTagsConfig(d *schema.ResourceData) tags.Config {
+	return tags.Config{
+		DefaultTags: expandDefaultTags(d.Get("default_tags").([]interface{})),
+		IgnoreTags:  expandIgnoreTags(d.Get("ignore_tags").([]interface{})),
+	}
+}
+
+func expandDefaultTags(input []interface{}) map[string]string {
+	output := make(map[string]string)
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	raw := input[0].(map[string]interface{})
+	for k, v := range raw["tags"].(map[string]interface{}) {
+		// Validate should have ignored this error already
+		value, _ := tags.TagValueToString(v)
+		output[k] = value
+	}
+
+	return output
+}
+
+func expandIgnoreTags(input []interface{}) tags.IgnoreConfig {
+	output := tags.IgnoreConfig{}
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	raw := input[0].(map[string]interface{})
+	if v, ok := raw["keys"]; ok {
+		for _, key := range v.(*pluginsdk.Set).List() {
+			output.Keys = append(output.Keys, key.(string))
+		}
+	}
+	if v, ok := raw["key_prefixes"]; ok {
+		for _, prefix := range v.(*pluginsdk.Set).List() {
+			output.KeyPrefixes = append(output.KeyPrefixes, prefix.(string))
+		}
+	}
+
+	return output
+}
+
+// wrapResourceWithTagsConfig applies the provider-level `default_tags` and `ignore_tags` configuration to a Resource
+// which exposes a top-level `tags` argument. Since this configuration is specific to each (potentially aliased)
+// provider block it's retrieved from the Provider's meta for each operation, rather than being configured globally.
+//
+// The effective Tags (those configured on the Resource merged with the Default Tags) are planned into `tags`, which
+// is marked as Computed so that this is possible - as such changes to `default_tags` are shown in the plan for, and
+// applied to, existing Resources, and are sent to the API by `tags.Expand` and the typed `tags` helpers as-is.
+func wrapResourceWithTagsConfig(resource *schema.Resource) {
+	if !hasTagsArgument(resource) {
+		return
+	}
+
+	// the Schema may be shared between Resources, so this is updated on a copy
+	tagsSchema := *resource.Schema["tags"]
+	tagsSchema.Computed = true
+	resource.Schema["tags"] = &tagsSchema
+
+	customizeDiff := resource.CustomizeDiff
+	resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if err := planEffectiveTags(d, meta); err != nil {
+			return err
+		}
+		if customizeDiff != nil {
+			return customizeDiff(ctx, d, meta)
+		}
+		return nil
+	}
+
+	// when reading, the ignored Tags are removed from those returned from the API
+	if resource.Read != nil {
+		read := resource.Read
+		resource.Read = func(d *schema.ResourceData, meta interface{}) error {
+			if err := read(d, meta); err != nil {
+				return err
+			}
+			return filterIgnoredTags(d, meta)
+		}
+	}
+	if resource.ReadContext != nil {
+		read := resource.ReadContext
+		resource.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if diags := read(ctx, d, meta); diags.HasError() {
+				return diags
+			}
+			return diag.FromErr(filterIgnoredTags(d, meta))
+		}
+	}
+}
+
+// wrapDataSourceWithTagsConfig applies the provider-level `ignore_tags` configuration to a Data Source which
+// exposes a top-level `tags` attribute - Default Tags are real Tags on the resource, so are returned as-is
+func wrapDataSourceWithTagsConfig(dataSource *schema.Resource) {
+	if v, ok := dataSource.Schema["tags"]; !ok || v.Type != schema.TypeMap {
+		return
+	}
+
+	if dataSource.Read != nil {
+		read := dataSource.Read
+		dataSource.Read = func(d *schema.ResourceData, meta interface{}) error {
+			if err := read(d, meta); err != nil {
+				return err
+			}
+			return filterIgnoredTags(d, meta)
+		}
+	}
+	if dataSource.ReadContext != nil {
+		read := dataSource.ReadContext
+		dataSource.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if diags := read(ctx, d, meta); diags.HasError() {
+				return diags
+			}
+			return diag.FromErr(filterIgnoredTags(d, meta))
+		}
+	}
+}
+
+// planEffectiveTags plans the Tags configured on the Resource merged with the Default Tags (less any ignored Tags)
+// into `tags` - the configuration is used rather than the planned value, since `tags` is Computed and as such would
+// otherwise retain the Tags in the state (including any Default Tags which have since been removed)
+func planEffectiveTags(d *schema.ResourceDiff, meta interface{}) error {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return nil
+	}
+
+	v := raw.GetAttr("tags")
+	if !v.IsWhollyKnown() {
+		if err := d.SetNewComputed("tags"); err != nil {
+			return fmt.Errorf("setting `tags` to computed: %+v", err)
+		}
+		return nil
+	}
+
+	configured := make(map[string]interface{})
+	if !v.IsNull() {
+		for key, value := range v.AsValueMap() {
+			if value.IsNull() {
+				continue
+			}
+			configured[key] = value.AsString()
+		}
+	}
+
+	if err := d.SetNew("tags", tagsConfigFromMeta(meta).EffectiveTags(configured)); err != nil {
+		return fmt.Errorf("planning `tags`: %+v", err)
+	}
+
+	return nil
+}
+
+// filterIgnoredTags removes the ignored Tags from the Tags read from the API
+func filterIgnoredTags(d *schema.ResourceData, meta interface{}) error {
+	config := tagsConfigFromMeta(meta)
+	if d.Id() == "" || len(config.IgnoreTags.Keys) == 0 && len(config.IgnoreTags.KeyPrefixes) == 0 {
+		return nil
+	}
+
+	if err := d.Set("tags", config.FilterIgnored(tagsFromResourceData(d))); err != nil {
+		return fmt.Errorf("setting `tags`: %+v", err)
+	}
+
+	return nil
+}
+
+func hasTagsArgument(resource *schema.Resource) bool {
+	v, ok := resource.Schema["tags"]
+	return ok && v.Type == schema.TypeMap && v.Optional
+}
+
+func tagsFromResourceData(d *schema.ResourceData) map[string]interface{} {
+	if v, ok := d.Get("tags").(map[string]interface{}); ok {
+		return v
+	}
+	return map[string]interface{}{}
+}
+
+func tagsConfigFromMeta(meta interface{}) tags.Config {
+	if client, ok := meta.(*clients.Client); ok && client != nil {
+		return client.Tags
+	}
+	return tags.Config{}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+)
+
+func TestResourceWithTagsConfigPlansDefaultTagsForExistingResource(t *testing.T) {
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+	wrapResourceWithTagsConfig(resource)
+
+	// the existing Resource was created with the Default Tag `owner = platform`
+	state := &terraform.InstanceState{
+		ID: "example",
+		Attributes: map[string]string{
+			"id":         "example",
+			"name":       "example",
+			"tags.%":     "2",
+			"tags.env":   "prod",
+			"tags.owner": "platform",
+		},
+	}
+
+	testData := []struct {
+		Name        string
+		DefaultTags map[string]string
+		Configured  map[string]cty.Value
+		Expected    map[string]*terraform.ResourceAttrDiff
+	}{
+		{
+			Name: "Unchanged",
+			DefaultTags: map[string]string{
+				"owner": "platform",
+			},
+			Configured: map[string]cty.Value{
+				"env": cty.StringVal("prod"),
+			},
+			Expected: map[string]*terraform.ResourceAttrDiff{},
+		},
+		{
+			Name: "Default Tag Added",
+			DefaultTags: map[string]string{
+				"cost-center": "1234",
+				"owner":       "platform",
+			},
+			Configured: map[string]cty.Value{
+				"env": cty.StringVal("prod"),
+			},
+			Expected: map[string]*terraform.ResourceAttrDiff{
+				"tags.%":           {Old: "2", New: "3"},
+				"tags.cost-center": {Old: "", New: "1234"},
+			},
+		},
+		{
+			Name: "Default Tag Value Changed",
+			DefaultTags: map[string]string{
+				"owner": "networking",
+			},
+			Configured: map[string]cty.Value{
+				"env": cty.StringVal("prod"),
+			},
+			Expected: map[string]*terraform.ResourceAttrDiff{
+				"tags.owner": {Old: "platform", New: "networking"},
+			},
+		},
+		{
+			Name:        "Default Tag Removed",
+			DefaultTags: map[string]string{},
+			Configured: map[string]cty.Value{
+				"env": cty.StringVal("prod"),
+			},
+			Expected: map[string]*terraform.ResourceAttrDiff{
+				"tags.%":     {Old: "2", New: "1"},
+				"tags.owner": {Old: "platform", New: "", NewRemoved: true},
+			},
+		},
+		{
+			Name: "Default Tag Overridden",
+			DefaultTags: map[string]string{
+				"owner": "networking",
+			},
+			Configured: map[string]cty.Value{
+				"env":   cty.StringVal("prod"),
+				"owner": cty.StringVal("platform"),
+			},
+			Expected: map[string]*terraform.ResourceAttrDiff{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		config := cty.ObjectVal(map[string]cty.Value{
+			"id":   cty.NullVal(cty.String),
+			"name": cty.StringVal("example"),
+			"tags": cty.MapVal(v.Configured),
+		})
+		existing := state.DeepCopy()
+		existing.RawConfig = config
+		meta := &clients.Client{
+			Tags: tags.Config{
+				DefaultTags: v.DefaultTags,
+			},
+		}
+
+		diff, err := resource.SimpleDiff(context.TODO(), existing, terraform.NewResourceConfigShimmed(config, resource.CoreConfigSchema()), meta)
+		if err != nil {
+			t.Fatalf("diffing: %+v", err)
+		}
+
+		actual := make(map[string]*terraform.ResourceAttrDiff)
+		if diff != nil {
+			for k, attr := range diff.Attributes {
+				actual[k] = attr
+			}
+		}
+		if len(actual) != len(v.Expected) {
+			t.Fatalf("expected %d changed attributes but got %d: %+v", len(v.Expected), len(actual), actual)
+		}
+		for k, expected := range v.Expected {
+			attr, ok := actual[k]
+			if !ok {
+				t.Fatalf("expected a diff for %q but didn't get one: %+v", k, actual)
+			}
+			if attr.Old != expected.Old || attr.New != expected.New || attr.NewRemoved != expected.NewRemoved {
+				t.Fatalf("expected %q to change from %q to %q (removed: %t) but got %q to %q (removed: %t)", k, expected.Old, expected.New, expected.NewRemoved, attr.Old, attr.New, attr.NewRemoved)
+			}
+		}
+	}
+}
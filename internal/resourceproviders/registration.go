@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -14,9 +15,18 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-09-01/providers"
 	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders/custompollers"
 )
 
+const (
+	// maxConcurrentRegistrations is the maximum number of Resource Providers which are registered at once
+	maxConcurrentRegistrations = 10
+
+	// registrationTimeout is the maximum length of time to wait for a single Resource Provider to be registered
+	registrationTimeout = 10 * time.Minute
+)
+
 func EnsureRegistered(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, requiredRPs map[string]struct{}) error {
 	if cachedResourceProviders == nil || registeredResourceProviders == nil || unregisteredResourceProviders == nil {
 		if err := populateCache(ctx, client, subscriptionId); err != nil {
@@ -44,22 +54,51 @@ func EnsureRegistered(ctx context.Context, client *providers.ProvidersClient, su
 
 // registerForSubscription registers the specified Resource Providers in the current Subscription
 func registerForSubscription(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, providersToRegister []string) error {
-	var err error
-	var failedProviders []string
-	var wg sync.WaitGroup
-	wg.Add(len(providersToRegister))
+	return registerResourceProviders(ctx, providersToRegister, maxConcurrentRegistrations, registrationTimeout, func(ctx context.Context, providerName string) error {
+		return registerWithSubscription(ctx, client, subscriptionId, providerName)
+	})
+}
+
+// registerResourceProviders calls register for each of the specified Resource Providers, running at most
+// maxConcurrency registrations at once, with each registration limited to the specified timeout
+func registerResourceProviders(ctx context.Context, providersToRegister []string, maxConcurrency int, timeout time.Duration, register func(ctx context.Context, providerName string) error) error {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	var (
+		errs            *multierror.Error
+		failedProviders = make([]string, 0)
+		lock            = &sync.Mutex{}
+		semaphore       = make(chan struct{}, maxConcurrency)
+		wg              sync.WaitGroup
+	)
 
 	for _, providerName := range providersToRegister {
-		go func(p string) {
+		wg.Add(1)
+		go func(providerName string) {
 			defer wg.Done()
-			log.Printf("[DEBUG] Registering Resource Provider %q with namespace", p)
-			if innerErr := registerWithSubscription(ctx, client, subscriptionId, p); innerErr != nil {
-				failedProviders = append(failedProviders, p)
-				if err == nil {
-					err = innerErr
-				} else {
-					err = fmt.Errorf("%s\n%s", err, innerErr)
-				}
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				lock.Lock()
+				defer lock.Unlock()
+				failedProviders = append(failedProviders, providerName)
+				errs = multierror.Append(errs, fmt.Errorf("registering Resource Provider %q: %+v", providerName, ctx.Err()))
+				return
+			}
+
+			registrationCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			log.Printf("[DEBUG] Registering Resource Provider %q with namespace", providerName)
+			if err := register(registrationCtx, providerName); err != nil {
+				lock.Lock()
+				defer lock.Unlock()
+				failedProviders = append(failedProviders, providerName)
+				errs = multierror.Append(errs, err)
 			}
 		}(providerName)
 	}
@@ -67,9 +106,11 @@ func registerForSubscription(ctx context.Context, client *providers.ProvidersCli
 	wg.Wait()
 
 	if len(failedProviders) > 0 {
-		err = fmt.Errorf("Cannot register providers: %s. Errors were: %s", strings.Join(failedProviders, ", "), err)
+		sort.Strings(failedProviders)
+		return fmt.Errorf("Cannot register providers: %s. Errors were: %+v", strings.Join(failedProviders, ", "), errs.ErrorOrNil())
 	}
-	return err
+
+	return nil
 }
 
 func registerWithSubscription(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, providerName string) error {
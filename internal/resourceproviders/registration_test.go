@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRegisterResourceProvidersBoundsConcurrency(t *testing.T) {
+	providerNames := make([]string, 0)
+	for i := 0; i < 50; i++ {
+		providerNames = append(providerNames, fmt.Sprintf("Microsoft.Example%d", i))
+	}
+
+	var (
+		lock      = &sync.Mutex{}
+		running   = 0
+		maxActive = 0
+		called    = make(map[string]struct{})
+	)
+	err := registerResourceProviders(context.Background(), providerNames, 5, time.Minute, func(ctx context.Context, providerName string) error {
+		lock.Lock()
+		running++
+		if running > maxActive {
+			maxActive = running
+		}
+		called[providerName] = struct{}{}
+		lock.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		lock.Lock()
+		running--
+		lock.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error but got: %+v", err)
+	}
+
+	if maxActive > 5 {
+		t.Fatalf("expected at most 5 concurrent registrations but got %d", maxActive)
+	}
+	if len(called) != len(providerNames) {
+		t.Fatalf("expected %d Resource Providers to be registered but got %d", len(providerNames), len(called))
+	}
+}
+
+func TestRegisterResourceProvidersAggregatesErrors(t *testing.T) {
+	providerNames := []string{"Microsoft.First", "Microsoft.Second", "Microsoft.Third"}
+
+	err := registerResourceProviders(context.Background(), providerNames, 2, time.Minute, func(ctx context.Context, providerName string) error {
+		if providerName == "Microsoft.Second" {
+			return nil
+		}
+		return fmt.Errorf("registering %q: failed", providerName)
+	})
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	for _, expected := range []string{"Cannot register providers: Microsoft.First, Microsoft.Third.", `registering "Microsoft.First": failed`, `registering "Microsoft.Third": failed`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected the error to contain %q but got: %s", expected, err.Error())
+		}
+	}
+	if strings.Contains(err.Error(), "Microsoft.Second") {
+		t.Fatalf("expected the error not to contain %q but got: %s", "Microsoft.Second", err.Error())
+	}
+}
+
+func TestRegisterResourceProvidersTimeout(t *testing.T) {
+	err := registerResourceProviders(context.Background(), []string{"Microsoft.Slow"}, 1, 10*time.Millisecond, func(ctx context.Context, providerName string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Fatalf("expected a deadline exceeded error but got: %+v", err)
+	}
+}
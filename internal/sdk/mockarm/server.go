@@ -0,0 +1,546 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mockarm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// SubscriptionId is the Subscription ID which should be used for Resources within the Server
+	SubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+	// TenantId is the Tenant ID returned as part of the ClientOptions for the Server
+	TenantId = "00000000-0000-0000-0000-000000000001"
+
+	// operationsPath is the path used for the polling URIs of Long Running Operations
+	operationsPath = "/mockarm/operations/"
+)
+
+// PollingStyle determines how the Server responds to requests which create, update or delete a Resource
+type PollingStyle string
+
+const (
+	// PollingStyleNone completes each operation immediately, returning the Resource in the response
+	PollingStyleNone PollingStyle = "None"
+
+	// PollingStyleAzureAsyncOperation returns a `201 Created` (or `202 Accepted` when patching/deleting) with an
+	// `Azure-AsyncOperation` header, which returns the `status` of the operation when polled
+	PollingStyleAzureAsyncOperation PollingStyle = "AzureAsyncOperation"
+
+	// PollingStyleLocation returns a `202 Accepted` with a `Location` header, which returns a `202 Accepted`
+	// whilst the operation is in progress and the Resource (or an empty body when deleting) once completed
+	PollingStyleLocation PollingStyle = "Location"
+)
+
+type ServerOptions struct {
+	// PollingStyle determines how Long Running Operations are modelled, defaults to PollingStyleNone
+	PollingStyle PollingStyle
+
+	// PollsUntilComplete is the number of times a Long Running Operation (or the Resource itself) must be
+	// polled before the operation is completed
+	PollsUntilComplete int
+}
+
+// Request is a request which has been received by the Server
+type Request struct {
+	Method string
+	Path   string
+}
+
+// Server is an in-process stand-in for Azure Resource Manager, storing Resources in-memory and
+// supporting PUT/GET/PATCH/DELETE with the polling semantics used for Long Running Operations.
+type Server struct {
+	lock sync.Mutex
+
+	options ServerOptions
+	server  *httptest.Server
+
+	// resources is a map of the lower-cased Resource ID to the Resource
+	resources map[string]map[string]interface{}
+
+	// operations is a map of the Operation ID to the Long Running Operation
+	operations map[string]*operation
+
+	// pendingOperations is a map of the lower-cased Resource ID to the Operation ID of an in-progress operation
+	pendingOperations map[string]string
+
+	requests []Request
+}
+
+type operation struct {
+	resourceId string
+	method     string
+	polls      int
+	completed  bool
+}
+
+// NewServer starts a new Server, which must be closed once it's no longer needed
+func NewServer(options ServerOptions) *Server {
+	if options.PollingStyle == "" {
+		options.PollingStyle = PollingStyleNone
+	}
+
+	s := &Server{
+		options:           options,
+		resources:         make(map[string]map[string]interface{}),
+		operations:        make(map[string]*operation),
+		pendingOperations: make(map[string]string),
+		requests:          make([]Request, 0),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+// URL returns the Resource Manager endpoint for this Server
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close stops the Server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Environment returns the Azure Public environment, with the Resource Manager endpoint pointing to this Server
+func (s *Server) Environment() environments.Environment {
+	env := *environments.AzurePublic()
+	env.ResourceManager = environments.ResourceManagerAPI(s.URL())
+	return env
+}
+
+// ClientOptions returns the ClientOptions used to build Service Clients which send requests to this Server
+func (s *Server) ClientOptions() *common.ClientOptions {
+	authorizer := &staticAuthorizer{}
+
+	azureEnvironment := azure.PublicCloud
+	azureEnvironment.ResourceManagerEndpoint = s.URL()
+
+	return &common.ClientOptions{
+		Authorizers: &common.Authorizers{
+			ResourceManager: authorizer,
+			AuthorizerFunc: func(api environments.Api) (auth.Authorizer, error) {
+				return authorizer, nil
+			},
+		},
+		Environment:    s.Environment(),
+		Features:       features.Default(),
+		SubscriptionId: SubscriptionId,
+		TenantId:       TenantId,
+
+		DisableTerraformPartnerID: true,
+		SkipProviderReg:           true,
+
+		AzureEnvironment:          azureEnvironment,
+		ResourceManagerEndpoint:   s.URL(),
+		ResourceManagerAuthorizer: autorest.NullAuthorizer{},
+	}
+}
+
+// Put stores the Resource with the specified ID, for example to create a parent Resource prior to a test
+func (s *Server) Put(id string, resource map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.resources[strings.ToLower(id)] = normaliseResource(id, resource, "Succeeded")
+}
+
+// Get returns the Resource with the specified ID, if it exists
+func (s *Server) Get(id string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	resource, ok := s.resources[strings.ToLower(id)]
+	return resource, ok
+}
+
+// Requests returns the requests received by the Server, in the order they were received
+func (s *Server) Requests() []Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	out := make([]Request, len(s.requests))
+	copy(out, s.requests)
+	return out
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+	})
+
+	if strings.HasPrefix(r.URL.Path, operationsPath) {
+		s.pollOperation(w, r)
+		return
+	}
+
+	if r.URL.Query().Get("api-version") == "" {
+		writeError(w, http.StatusBadRequest, "MissingApiVersionParameter", "The api-version query parameter (?api-version=) is required for all requests.")
+		return
+	}
+
+	id := strings.TrimSuffix(r.URL.Path, "/")
+	switch r.Method {
+	case http.MethodGet:
+		if isCollection(id) {
+			s.list(w, id)
+			return
+		}
+		s.get(w, id)
+
+	case http.MethodHead:
+		if _, ok := s.resources[strings.ToLower(id)]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case http.MethodPut, http.MethodPatch:
+		s.createOrUpdate(w, r, id)
+
+	case http.MethodDelete:
+		s.delete(w, r, id)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("The method %q is not supported by the mock Resource Manager.", r.Method))
+	}
+}
+
+func (s *Server) get(w http.ResponseWriter, id string) {
+	key := strings.ToLower(id)
+
+	// some SDKs poll the Resource itself rather than the operation, so this also counts as a poll
+	if operationId, ok := s.pendingOperations[key]; ok {
+		s.advance(operationId)
+	}
+
+	resource, ok := s.resources[key]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource %q was not found.", id))
+		return
+	}
+
+	writeJson(w, http.StatusOK, resource)
+}
+
+func (s *Server) list(w http.ResponseWriter, collectionId string) {
+	prefix := strings.ToLower(collectionId) + "/"
+
+	values := make([]interface{}, 0)
+	for key, resource := range s.resources {
+		if !strings.HasPrefix(key, prefix) || strings.Contains(strings.TrimPrefix(key, prefix), "/") {
+			continue
+		}
+		values = append(values, resource)
+	}
+
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"value": values,
+	})
+}
+
+func (s *Server) createOrUpdate(w http.ResponseWriter, r *http.Request, id string) {
+	key := strings.ToLower(id)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("reading request body: %+v", err))
+		return
+	}
+
+	payload := make(map[string]interface{})
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("parsing request body: %+v", err))
+			return
+		}
+	}
+
+	existing, exists := s.resources[key]
+	if r.Method == http.MethodPatch {
+		if !exists {
+			writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource %q was not found.", id))
+			return
+		}
+		payload = mergePatch(existing, payload).(map[string]interface{})
+	}
+
+	provisioningState := "Succeeded"
+	if s.options.PollingStyle != PollingStyleNone {
+		provisioningState = "Creating"
+		if exists {
+			provisioningState = "Updating"
+		}
+	}
+	resource := normaliseResource(id, payload, provisioningState)
+	s.resources[key] = resource
+
+	statusCode := http.StatusOK
+	if !exists {
+		statusCode = http.StatusCreated
+	}
+
+	switch s.options.PollingStyle {
+	case PollingStyleAzureAsyncOperation:
+		operationId := s.startOperation(id, r.Method)
+		w.Header().Set("Azure-AsyncOperation", s.operationUrl(operationId, r))
+		w.Header().Set("Retry-After", "0")
+		statusCode = http.StatusCreated
+		if r.Method == http.MethodPatch {
+			statusCode = http.StatusAccepted
+		}
+		writeJson(w, statusCode, resource)
+
+	case PollingStyleLocation:
+		operationId := s.startOperation(id, r.Method)
+		w.Header().Set("Location", s.operationUrl(operationId, r)+"&result=true")
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusAccepted)
+
+	default:
+		writeJson(w, statusCode, resource)
+	}
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, id string) {
+	key := strings.ToLower(id)
+	if _, ok := s.resources[key]; !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if s.options.PollingStyle == PollingStyleNone {
+		delete(s.resources, key)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	s.resources[key]["properties"].(map[string]interface{})["provisioningState"] = "Deleting"
+	operationId := s.startOperation(id, r.Method)
+	if s.options.PollingStyle == PollingStyleAzureAsyncOperation {
+		w.Header().Set("Azure-AsyncOperation", s.operationUrl(operationId, r))
+	} else {
+		w.Header().Set("Location", s.operationUrl(operationId, r)+"&result=true")
+	}
+	w.Header().Set("Retry-After", "0")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) pollOperation(w http.ResponseWriter, r *http.Request) {
+	operationId := strings.TrimPrefix(r.URL.Path, operationsPath)
+	op, ok := s.operations[operationId]
+	if !ok {
+		writeError(w, http.StatusNotFound, "OperationNotFound", fmt.Sprintf("The Operation %q was not found.", operationId))
+		return
+	}
+
+	s.advance(operationId)
+	w.Header().Set("Retry-After", "0")
+
+	// the `Location` header returns the result of the operation once completed
+	if r.URL.Query().Get("result") == "true" {
+		if !op.completed {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if resource, ok := s.resources[strings.ToLower(op.resourceId)]; ok && op.method != http.MethodDelete {
+			writeJson(w, http.StatusOK, resource)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	status := "InProgress"
+	if op.completed {
+		status = "Succeeded"
+	}
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"id":     r.URL.Path,
+		"name":   operationId,
+		"status": status,
+	})
+}
+
+func (s *Server) startOperation(resourceId, method string) string {
+	operationId := fmt.Sprintf("operation-%d", len(s.operations)+1)
+	s.operations[operationId] = &operation{
+		resourceId: resourceId,
+		method:     method,
+	}
+	s.pendingOperations[strings.ToLower(resourceId)] = operationId
+	return operationId
+}
+
+// advance polls the operation, completing it once it's been polled the configured number of times
+func (s *Server) advance(operationId string) {
+	op := s.operations[operationId]
+	if op.completed {
+		return
+	}
+
+	op.polls++
+	if op.polls < s.options.PollsUntilComplete {
+		return
+	}
+
+	op.completed = true
+	key := strings.ToLower(op.resourceId)
+	delete(s.pendingOperations, key)
+	if op.method == http.MethodDelete {
+		delete(s.resources, key)
+		return
+	}
+	if resource, ok := s.resources[key]; ok {
+		resource["properties"].(map[string]interface{})["provisioningState"] = "Succeeded"
+	}
+}
+
+func (s *Server) operationUrl(operationId string, r *http.Request) string {
+	return fmt.Sprintf("%s%s%s?api-version=%s", s.URL(), operationsPath, operationId, r.URL.Query().Get("api-version"))
+}
+
+// isCollection returns whether the URI refers to a collection of Resources (e.g. `/subscriptions/{id}/resourceGroups`)
+// rather than a single Resource, since the segments of a Resource ID alternate between the type and the name
+func isCollection(id string) bool {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+
+	providerIndex := -1
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "providers") && i+1 < len(segments) {
+			providerIndex = i
+		}
+	}
+
+	if providerIndex == -1 {
+		return len(segments)%2 == 1
+	}
+
+	return len(segments[providerIndex+2:])%2 == 1
+}
+
+// resourceType returns the ARM Resource Type (e.g. `Microsoft.Network/virtualNetworks/subnets`) from a Resource ID
+func resourceType(id string) string {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+
+	providerIndex := -1
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "providers") && i+1 < len(segments) {
+			providerIndex = i
+		}
+	}
+	if providerIndex == -1 {
+		if len(segments) > 2 {
+			return fmt.Sprintf("Microsoft.Resources/%s", segments[len(segments)-2])
+		}
+		return "Microsoft.Resources/subscriptions"
+	}
+
+	out := segments[providerIndex+1]
+	remaining := segments[providerIndex+2:]
+	for i := 0; i < len(remaining); i += 2 {
+		out = fmt.Sprintf("%s/%s", out, remaining[i])
+	}
+	return out
+}
+
+// normaliseResource sets the fields which Resource Manager returns for every Resource
+func normaliseResource(id string, input map[string]interface{}, provisioningState string) map[string]interface{} {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+
+	resource := make(map[string]interface{}, len(input)+3)
+	for k, v := range input {
+		resource[k] = v
+	}
+	resource["id"] = id
+	resource["name"] = segments[len(segments)-1]
+	resource["type"] = resourceType(id)
+
+	properties, ok := resource["properties"].(map[string]interface{})
+	if !ok {
+		properties = make(map[string]interface{})
+	}
+	properties["provisioningState"] = provisioningState
+	resource["properties"] = properties
+
+	return resource
+}
+
+// mergePatch applies the patch to the existing value as per RFC 7386 (JSON Merge Patch)
+func mergePatch(existing interface{}, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	existingMap, ok := existing.(map[string]interface{})
+	if !ok {
+		existingMap = make(map[string]interface{})
+	}
+
+	out := make(map[string]interface{}, len(existingMap))
+	for k, v := range existingMap {
+		out[k] = v
+	}
+	for k, v := range patchMap {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+		out[k] = mergePatch(out[k], v)
+	}
+
+	return out
+}
+
+func writeJson(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJson(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+var _ auth.Authorizer = &staticAuthorizer{}
+
+// staticAuthorizer is an auth.Authorizer returning a static token, since the Server doesn't validate tokens
+type staticAuthorizer struct{}
+
+func (a *staticAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: "mockarm",
+		TokenType:   "Bearer",
+	}, nil
+}
+
+func (a *staticAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
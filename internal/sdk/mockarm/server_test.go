@@ -0,0 +1,240 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mockarm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
+)
+
+const testResourceId = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Example/widgets/first"
+
+type testWidget struct {
+	Id         *string               `json:"id,omitempty"`
+	Name       *string               `json:"name,omitempty"`
+	Type       *string               `json:"type,omitempty"`
+	Tags       map[string]string     `json:"tags,omitempty"`
+	Properties *testWidgetProperties `json:"properties,omitempty"`
+}
+
+type testWidgetProperties struct {
+	Colour            *string `json:"colour,omitempty"`
+	ProvisioningState *string `json:"provisioningState,omitempty"`
+}
+
+func TestServerLifecycle(t *testing.T) {
+	testData := []struct {
+		Name    string
+		Options ServerOptions
+	}{
+		{
+			Name:    "No Polling",
+			Options: ServerOptions{},
+		},
+		{
+			Name: "Azure-AsyncOperation",
+			Options: ServerOptions{
+				PollingStyle:       PollingStyleAzureAsyncOperation,
+				PollsUntilComplete: 2,
+			},
+		},
+		{
+			Name: "Location",
+			Options: ServerOptions{
+				PollingStyle:       PollingStyleLocation,
+				PollsUntilComplete: 1,
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		server := NewServer(v.Options)
+		c := testClient(t, server)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+
+		colour := "blue"
+		input := testWidget{
+			Properties: &testWidgetProperties{
+				Colour: &colour,
+			},
+		}
+		if err := sendThenPoll(ctx, c, http.MethodPut, input); err != nil {
+			t.Fatalf("creating: %+v", err)
+		}
+
+		widget, err := getWidget(ctx, c)
+		if err != nil {
+			t.Fatalf("retrieving: %+v", err)
+		}
+		if widget == nil || widget.Name == nil || *widget.Name != "first" || widget.Type == nil || *widget.Type != "Microsoft.Example/widgets" {
+			t.Fatalf("expected the widget to have a name and type but got %+v", widget)
+		}
+		if *widget.Properties.Colour != "blue" || *widget.Properties.ProvisioningState != "Succeeded" {
+			t.Fatalf("expected the widget to be blue and provisioned but got %+v", *widget.Properties)
+		}
+
+		if err := sendThenPoll(ctx, c, http.MethodPatch, map[string]interface{}{"tags": map[string]string{"hello": "world"}}); err != nil {
+			t.Fatalf("updating: %+v", err)
+		}
+		widget, err = getWidget(ctx, c)
+		if err != nil {
+			t.Fatalf("retrieving: %+v", err)
+		}
+		if widget.Tags["hello"] != "world" || *widget.Properties.Colour != "blue" {
+			t.Fatalf("expected the patch to be merged into the widget but got %+v", widget)
+		}
+
+		if err := sendThenPoll(ctx, c, http.MethodDelete, nil); err != nil {
+			t.Fatalf("deleting: %+v", err)
+		}
+		widget, err = getWidget(ctx, c)
+		if err != nil {
+			t.Fatalf("retrieving: %+v", err)
+		}
+		if widget != nil {
+			t.Fatalf("expected the widget to be deleted but got %+v", widget)
+		}
+
+		cancel()
+		server.Close()
+	}
+}
+
+func TestServerList(t *testing.T) {
+	server := NewServer(ServerOptions{})
+	defer server.Close()
+
+	server.Put(testResourceId, map[string]interface{}{})
+	server.Put(testResourceId+"/gadgets/nested", map[string]interface{}{})
+	server.Put("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Example/widgets/second", map[string]interface{}{})
+
+	resp, err := http.Get(server.URL() + "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Example/widgets?api-version=2020-01-01")
+	if err != nil {
+		t.Fatalf("listing: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Value []testWidget `json:"value"`
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Fatalf("parsing response: %+v", err)
+	}
+	if len(result.Value) != 2 {
+		t.Fatalf("expected 2 widgets but got %d: %s", len(result.Value), string(body))
+	}
+}
+
+func TestServerRequiresApiVersion(t *testing.T) {
+	server := NewServer(ServerOptions{})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL() + testResourceId)
+	if err != nil {
+		t.Fatalf("retrieving: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected a 400 Bad Request but got %d", resp.StatusCode)
+	}
+}
+
+func testClient(t *testing.T, server *Server) *resourcemanager.Client {
+	o := server.ClientOptions()
+	c, err := resourcemanager.NewResourceManagerClient(o.Environment.ResourceManager, "widgets", "2020-01-01")
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+	o.Configure(c, o.Authorizers.ResourceManager)
+	return c
+}
+
+func sendThenPoll(ctx context.Context, c *resourcemanager.Client, method string, input interface{}) error {
+	req, err := c.NewRequest(ctx, client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusAccepted,
+			http.StatusCreated,
+			http.StatusNoContent,
+			http.StatusOK,
+		},
+		HttpMethod: method,
+		Path:       testResourceId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if input != nil {
+		if err := req.Marshal(input); err != nil {
+			return err
+		}
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the go-azure-sdk polls the Resource itself every 10 seconds when there's no Long Running Operation, or
+	// when deleting - so to keep these tests fast the Resource is polled directly in these cases
+	if method == http.MethodDelete || (resp.Header.Get("Azure-AsyncOperation") == "" && resp.Header.Get("Location") == "") {
+		for {
+			widget, err := getWidget(ctx, c)
+			if err != nil {
+				return err
+			}
+			if widget == nil || *widget.Properties.ProvisioningState == "Succeeded" {
+				return nil
+			}
+		}
+	}
+
+	poller, err := resourcemanager.PollerFromResponse(resp, c)
+	if err != nil {
+		return err
+	}
+
+	return poller.PollUntilDone(ctx)
+}
+
+func getWidget(ctx context.Context, c *resourcemanager.Client) (*testWidget, error) {
+	req, err := c.NewRequest(ctx, client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       testResourceId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var widget testWidget
+	if err := resp.Unmarshal(&widget); err != nil {
+		return nil, err
+	}
+
+	return &widget, nil
+}
@@ -99,7 +99,19 @@ type StateUpgradeData struct {
 	Upgraders     map[int]pluginsdk.StateUpgrade
 }
 
-// TODO: a generic state migration for updating ID's
+// ResourceWithIdentity is an optional interface
+//
+// Resources implementing this interface declare the Parser for their Resource ID alongside any legacy formats of
+// the Resource ID - from which the State Upgraders which rewrite the Resource ID into the current format are
+// generated, removing the need for a hand-written State Migration when only the Resource ID has changed.
+//
+// NOTE: Resources can implement either ResourceWithIdentity or ResourceWithStateMigration but not both.
+type ResourceWithIdentity interface {
+	Resource
+
+	// Identity returns the Parser and any legacy formats for the Resource ID of this Resource
+	Identity() ResourceIdentity
+}
 
 type ResourceWithCustomImporter interface {
 	Resource
@@ -135,6 +147,17 @@ type ResourceWithDeprecationReplacedBy interface {
 	DeprecatedInFavourOfResource() string
 }
 
+// ResourceWithReplacementMigration is an optional interface
+//
+// Resources implementing this interface describe how Terraform Configuration using this
+// deprecated Resource can be migrated to the Resource replacing it.
+type ResourceWithReplacementMigration interface {
+	ResourceWithDeprecationReplacedBy
+
+	// ReplacementMigration returns the mappings used to migrate to the Resource returned from DeprecatedInFavourOfResource
+	ReplacementMigration() ReplacementMigration
+}
+
 // ResourceWithDeprecationAndNoReplacement is an optional interface
 //
 // nolint gocritic
@@ -148,6 +171,18 @@ type ResourceWithDeprecationAndNoReplacement interface {
 	DeprecationMessage() string
 }
 
+// ResourceWithConsistencyCheck is an optional interface
+//
+// Resources implementing this interface are polled after being Created/Updated (prior to the Read function being
+// called) until the ConsistencyCheck passes the specified number of times in a row - which allows working around
+// eventually consistent APIs without a hand-written Retry loop.
+type ResourceWithConsistencyCheck interface {
+	Resource
+
+	// ConsistencyCheck returns the ConsistencyCheck used to determine when this Resource is consistent
+	ConsistencyCheck() ConsistencyCheck
+}
+
 // ResourceWithCustomizeDiff is an optional interface
 type ResourceWithCustomizeDiff interface {
 	Resource
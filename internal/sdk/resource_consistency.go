@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+const (
+	consistencyStatePending    = "Pending"
+	consistencyStateConsistent = "Consistent"
+
+	defaultConsistencyPollInterval        = 10 * time.Second
+	defaultConsistencyContinuousOccurence = 3
+)
+
+// ConsistencyCheckFunc retrieves the Resource (using the Resource ID available in the ResourceData) and returns
+// whether the Resource is consistent - for example that the API returned the Resource rather than a 404, or that a
+// property on the Resource has the expected value.
+//
+// Returning an error stops the polling, as such a 404 should instead return false so that polling continues.
+type ConsistencyCheckFunc func(ctx context.Context, metadata ResourceMetaData) (bool, error)
+
+// ConsistencyCheck defines how an eventually consistent Resource is polled after it's been Created/Updated
+type ConsistencyCheck struct {
+	// Func determines whether the Resource is consistent
+	Func ConsistencyCheckFunc
+
+	// ContinuousTargetOccurence is the number of times in a row that the Resource must be consistent, which
+	// defaults to 3 when unspecified.
+	ContinuousTargetOccurence int
+
+	// PollInterval is the duration between each check, which defaults to 10 seconds when unspecified.
+	PollInterval time.Duration
+
+	// SkipAfterUpdate specifies that the Resource only needs to be polled after it's been Created
+	SkipAfterUpdate bool
+}
+
+// Validate validates that the ConsistencyCheck is valid
+func (c ConsistencyCheck) Validate() error {
+	if c.Func == nil {
+		return fmt.Errorf("a Func must be specified")
+	}
+	if c.ContinuousTargetOccurence < 0 {
+		return fmt.Errorf("ContinuousTargetOccurence must be zero or greater but got %d", c.ContinuousTargetOccurence)
+	}
+	if c.PollInterval < 0 {
+		return fmt.Errorf("PollInterval must be zero or greater but got %s", c.PollInterval)
+	}
+	return nil
+}
+
+// waitForConsistency polls the ConsistencyCheck until the Resource has been consistent the required number of
+// times in a row, using the deadline of the context as the timeout
+func waitForConsistency(ctx context.Context, check ConsistencyCheck, operation string, metadata ResourceMetaData) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("internal-error: context had no deadline")
+	}
+
+	continuousTargetOccurence := check.ContinuousTargetOccurence
+	if continuousTargetOccurence == 0 {
+		continuousTargetOccurence = defaultConsistencyContinuousOccurence
+	}
+	pollInterval := check.PollInterval
+	if pollInterval == 0 {
+		pollInterval = defaultConsistencyPollInterval
+	}
+
+	id := metadata.ResourceData.Id()
+	attempt := 0
+	metadata.Logger.Infof("[DEBUG] Waiting for %q to be consistent after %s..", id, operation)
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{consistencyStatePending},
+		Target:  []string{consistencyStateConsistent},
+		Refresh: func() (interface{}, string, error) {
+			attempt++
+			consistent, err := check.Func(ctx, metadata)
+			if err != nil {
+				return nil, "", err
+			}
+			if !consistent {
+				metadata.Logger.Infof("[DEBUG] %q isn't consistent (attempt %d)", id, attempt)
+				return consistencyStatePending, consistencyStatePending, nil
+			}
+
+			metadata.Logger.Infof("[DEBUG] %q is consistent (attempt %d)", id, attempt)
+			return consistencyStateConsistent, consistencyStateConsistent, nil
+		},
+		PollInterval:              pollInterval,
+		ContinuousTargetOccurence: continuousTargetOccurence,
+		Timeout:                   time.Until(deadline),
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for %q to be consistent after %s: %+v", id, operation, err)
+	}
+
+	return nil
+}
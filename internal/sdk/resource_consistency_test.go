@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestWaitForConsistency(t *testing.T) {
+	testData := []struct {
+		Name                      string
+		Results                   []bool
+		ContinuousTargetOccurence int
+		ErrorOnAttempt            int
+		Timeout                   time.Duration
+		ExpectedAttempts          int
+		ExpectError               bool
+	}{
+		{
+			Name:                      "consistent immediately",
+			Results:                   []bool{true, true},
+			ContinuousTargetOccurence: 2,
+			ExpectedAttempts:          2,
+		},
+		{
+			Name:                      "consistent after not being found",
+			Results:                   []bool{false, false, true, true, true},
+			ContinuousTargetOccurence: 3,
+			ExpectedAttempts:          5,
+		},
+		{
+			Name:                      "inconsistent results reset the count",
+			Results:                   []bool{true, false, true, true},
+			ContinuousTargetOccurence: 2,
+			ExpectedAttempts:          4,
+		},
+		{
+			Name:             "defaults to three in a row",
+			Results:          []bool{true, true, true},
+			ExpectedAttempts: 3,
+		},
+		{
+			Name:                      "error stops polling",
+			Results:                   []bool{false, false, false},
+			ContinuousTargetOccurence: 2,
+			ErrorOnAttempt:            2,
+			ExpectedAttempts:          2,
+			ExpectError:               true,
+		},
+		{
+			Name:                      "never consistent",
+			Results:                   []bool{},
+			ContinuousTargetOccurence: 2,
+			Timeout:                   50 * time.Millisecond,
+			ExpectError:               true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		attempts := 0
+		check := ConsistencyCheck{
+			Func: func(ctx context.Context, metadata ResourceMetaData) (bool, error) {
+				attempts++
+				if attempts == v.ErrorOnAttempt {
+					return false, fmt.Errorf("retrieving %s: internal server error", metadata.ResourceData.Id())
+				}
+				if attempts > len(v.Results) {
+					return false, nil
+				}
+				return v.Results[attempts-1], nil
+			},
+			ContinuousTargetOccurence: v.ContinuousTargetOccurence,
+			PollInterval:              time.Millisecond,
+		}
+		if err := check.Validate(); err != nil {
+			t.Fatalf("validating the ConsistencyCheck: %+v", err)
+		}
+
+		timeout := v.Timeout
+		if timeout == 0 {
+			timeout = 10 * time.Second
+		}
+		ctx, cancel := context.WithTimeout(context.TODO(), timeout)
+
+		d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+		d.SetId("/some/resource/id")
+		metadata := ResourceMetaData{
+			Logger:       ConsoleLogger{},
+			ResourceData: d,
+		}
+
+		err := waitForConsistency(ctx, check, "Create", metadata)
+		cancel()
+		if err != nil {
+			if v.ExpectError {
+				if v.ExpectedAttempts > 0 && attempts != v.ExpectedAttempts {
+					t.Fatalf("expected %d attempts but got %d", v.ExpectedAttempts, attempts)
+				}
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.ExpectError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if attempts != v.ExpectedAttempts {
+			t.Fatalf("expected %d attempts but got %d", v.ExpectedAttempts, attempts)
+		}
+	}
+}
+
+func TestConsistencyCheckValidate(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    ConsistencyCheck
+		Expected bool
+	}{
+		{
+			Name:     "no func",
+			Input:    ConsistencyCheck{},
+			Expected: false,
+		},
+		{
+			Name: "negative occurrences",
+			Input: ConsistencyCheck{
+				Func: func(ctx context.Context, metadata ResourceMetaData) (bool, error) {
+					return true, nil
+				},
+				ContinuousTargetOccurence: -1,
+			},
+			Expected: false,
+		},
+		{
+			Name: "defaults",
+			Input: ConsistencyCheck{
+				Func: func(ctx context.Context, metadata ResourceMetaData) (bool, error) {
+					return true, nil
+				},
+			},
+			Expected: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		err := v.Input.Validate()
+		if v.Expected && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if !v.Expected && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -46,11 +47,23 @@ type stateRetriever interface {
 	GetOkExists(key string) (interface{}, bool)
 }
 
+// rawConfigRetriever is implemented by both the ResourceData and ResourceDiff, exposing the Terraform Configuration
+// which is used to determine whether an Optional property is null
+type rawConfigRetriever interface {
+	GetRawConfig() cty.Value
+}
+
 func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debugLogger Logger) error {
 	if reflect.TypeOf(input).Kind() != reflect.Ptr {
 		return fmt.Errorf("need a pointer")
 	}
 
+	// the Terraform Configuration isn't available during Read, in which case this is null
+	config := cty.NilVal
+	if v, ok := stateRetriever.(rawConfigRetriever); ok {
+		config = v.GetRawConfig()
+	}
+
 	objType := reflect.TypeOf(input).Elem()
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
@@ -58,6 +71,16 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 
 		if val, exists := field.Tag.Lookup("tfschema"); exists {
 			tfschemaValue, valExists := stateRetriever.GetOkExists(val)
+			attrConfig, _ := configAttribute(config, val)
+
+			if isOptionalType(field.Type) {
+				state := optionalStateFor(config, val, valExists)
+				if err := setOptionalValue(input, tfschemaValue, state, i, field.Name, attrConfig, debugLogger); err != nil {
+					return fmt.Errorf("while setting value %+v of model field %q: %+v", tfschemaValue, field.Name, err)
+				}
+				continue
+			}
+
 			if !valExists {
 				continue
 			}
@@ -66,7 +89,7 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 			debugLogger.Infof("Input Type: ", reflect.ValueOf(input).Elem().Field(i).Type())
 
 			fieldName := reflect.ValueOf(input).Elem().Field(i).String()
-			if err := setValue(input, tfschemaValue, i, fieldName, debugLogger); err != nil {
+			if err := setValue(input, tfschemaValue, i, fieldName, attrConfig, debugLogger); err != nil {
 				return fmt.Errorf("while setting value %+v of model field %q: %+v", tfschemaValue, fieldName, err)
 			}
 		}
@@ -74,7 +97,9 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 	return nil
 }
 
-func setValue(input, tfschemaValue interface{}, index int, fieldName string, debugLogger Logger) (errOut error) {
+// setValue sets the field at the specified index within the input, where config is the Terraform Configuration
+// for this field (if available) which is used to determine whether any nested Optional properties are null
+func setValue(input, tfschemaValue interface{}, index int, fieldName string, config cty.Value, debugLogger Logger) (errOut error) {
 	debugLogger.Infof("setting list value for %q..", fieldName)
 	defer func() {
 		if r := recover(); r != nil {
@@ -129,7 +154,7 @@ func setValue(input, tfschemaValue interface{}, index int, fieldName string, deb
 	}
 
 	if v, ok := tfschemaValue.(*schema.Set); ok {
-		return setListValue(input, index, fieldName, v.List(), debugLogger)
+		return setListValue(input, index, fieldName, v.List(), config, debugLogger)
 	}
 
 	if mapConfig, ok := tfschemaValue.(map[string]interface{}); ok {
@@ -143,13 +168,13 @@ func setValue(input, tfschemaValue interface{}, index int, fieldName string, deb
 	}
 
 	if v, ok := tfschemaValue.([]interface{}); ok {
-		return setListValue(input, index, fieldName, v, debugLogger)
+		return setListValue(input, index, fieldName, v, config, debugLogger)
 	}
 
 	return nil
 }
 
-func setListValue(input interface{}, index int, fieldName string, v []interface{}, debugLogger Logger) error {
+func setListValue(input interface{}, index int, fieldName string, v []interface{}, config cty.Value, debugLogger Logger) error {
 	switch fieldType := reflect.ValueOf(input).Elem().Field(index).Type(); fieldType {
 	case reflect.TypeOf([]string{}):
 		stringSlice := reflect.MakeSlice(reflect.TypeOf([]string{}), len(v), len(v))
@@ -183,17 +208,27 @@ func setListValue(input interface{}, index int, fieldName string, v []interface{
 		valueToSet := reflect.MakeSlice(reflect.ValueOf(input).Elem().Field(index).Type(), 0, 0)
 		debugLogger.Infof("List Type", valueToSet.Type())
 
-		for _, mapVal := range v {
+		for itemIndex, mapVal := range v {
 			if test, ok := mapVal.(map[string]interface{}); ok && test != nil {
 				elem := reflect.New(fieldType.Elem())
 				debugLogger.Infof("element ", elem)
+				elemConfig := configElement(config, itemIndex, test)
 				for j := 0; j < elem.Type().Elem().NumField(); j++ {
 					nestedField := elem.Type().Elem().Field(j)
 					debugLogger.Infof("nestedField ", nestedField)
 
 					if val, exists := nestedField.Tag.Lookup("tfschema"); exists {
-						nestedTFSchemaValue := test[val]
-						if err := setValue(elem.Interface(), nestedTFSchemaValue, j, fieldName, debugLogger); err != nil {
+						nestedTFSchemaValue, nestedExists := test[val]
+						nestedConfig, _ := configAttribute(elemConfig, val)
+						if isOptionalType(nestedField.Type) {
+							state := optionalStateFor(elemConfig, val, nestedExists)
+							if err := setOptionalValue(elem.Interface(), nestedTFSchemaValue, state, j, fieldName, nestedConfig, debugLogger); err != nil {
+								return err
+							}
+							continue
+						}
+
+						if err := setValue(elem.Interface(), nestedTFSchemaValue, j, fieldName, nestedConfig, debugLogger); err != nil {
 							return err
 						}
 					}
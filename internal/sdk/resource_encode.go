@@ -52,6 +52,18 @@ func recurse(objType reflect.Type, objVal reflect.Value, fieldName string, debug
 		field := objType.Field(i)
 		fieldVal := objVal.Field(i)
 		if tfschemaTag, exists := field.Tag.Lookup("tfschema"); exists {
+			if isOptionalType(field.Type) {
+				value, include, err := encodeOptionalValue(fieldVal, field.Name, debugLogger)
+				if err != nil {
+					return nil, fmt.Errorf("serializing optional field %q: %+v", field.Name, err)
+				}
+				if include {
+					debugLogger.Infof("Setting %q to %+v", tfschemaTag, value)
+					output[tfschemaTag] = value
+				}
+				continue
+			}
+
 			switch field.Type.Kind() {
 			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 				iv := fieldVal.Int()
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// ResourceIdParseFunc parses the specified Resource ID, returning the Resource ID in the current format
+type ResourceIdParseFunc func(input string) (resourceids.Id, error)
+
+// ResourceIdParser converts a typed Resource ID Parser (e.g. `servers.ParseServerIDInsensitively`) into
+// a ResourceIdParseFunc
+func ResourceIdParser[T resourceids.Id](parser func(input string) (T, error)) ResourceIdParseFunc {
+	return func(input string) (resourceids.Id, error) {
+		return parser(input)
+	}
+}
+
+// ResourceIdentity describes the Resource ID for a Resource, including any legacy formats of the Resource ID
+// which may exist in the Terraform State
+type ResourceIdentity struct {
+	// Parser parses the Resource ID in the current format.
+	// NOTE: this should parse the Resource ID insensitively (e.g. `ParseServerIDInsensitively`) so that a Resource ID
+	// with differing casing can be normalised
+	Parser ResourceIdParseFunc
+
+	// LegacyFormats is the list of Parsers for the previous formats of the Resource ID, ordered from oldest to newest,
+	// where each entry is the format of the Resource ID in that Schema Version of the Resource. Each of these Parsers
+	// must return the Resource ID in the current format.
+	//
+	// A nil entry can be used where the Resource ID only differs in casing, in which case the Parser is used.
+	LegacyFormats []ResourceIdParseFunc
+}
+
+// Validate validates that the ResourceIdentity is valid
+func (i ResourceIdentity) Validate() error {
+	if i.Parser == nil {
+		return fmt.Errorf("a Parser must be specified")
+	}
+	if len(i.LegacyFormats) == 0 {
+		return fmt.Errorf("at least one LegacyFormat must be specified")
+	}
+	return nil
+}
+
+// StateUpgraders returns the State Upgraders used to rewrite the Resource ID from each of the legacy formats into
+// the current format - where the Schema Version of the Resource is the number of legacy formats.
+//
+// Since only the Resource ID is changed the current Schema is used as the Schema for each previous version.
+func (i ResourceIdentity) StateUpgraders(resourceSchema map[string]*pluginsdk.Schema) StateUpgradeData {
+	upgraders := make(map[int]pluginsdk.StateUpgrade, len(i.LegacyFormats))
+	for version, legacyFormat := range i.LegacyFormats {
+		parsers := []ResourceIdParseFunc{i.Parser}
+		if legacyFormat != nil {
+			// the Resource ID may already be in the current format, for example when the Resource was imported
+			parsers = []ResourceIdParseFunc{legacyFormat, i.Parser}
+		}
+
+		upgraders[version] = resourceIdStateUpgrade{
+			fromVersion: version,
+			parsers:     parsers,
+			schema:      resourceSchema,
+		}
+	}
+
+	return StateUpgradeData{
+		SchemaVersion: len(i.LegacyFormats),
+		Upgraders:     upgraders,
+	}
+}
+
+var _ pluginsdk.StateUpgrade = resourceIdStateUpgrade{}
+
+type resourceIdStateUpgrade struct {
+	fromVersion int
+	parsers     []ResourceIdParseFunc
+	schema      map[string]*pluginsdk.Schema
+}
+
+func (u resourceIdStateUpgrade) Schema() map[string]*pluginsdk.Schema {
+	return u.schema
+}
+
+func (u resourceIdStateUpgrade) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		oldId, ok := rawState["id"].(string)
+		if !ok || oldId == "" {
+			return rawState, nil
+		}
+
+		var errs []error
+		for _, parser := range u.parsers {
+			id, err := parser(oldId)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			newId := id.ID()
+			log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
+			rawState["id"] = newId
+			return rawState, nil
+		}
+
+		return nil, fmt.Errorf("upgrading the Resource ID %q from Schema Version %d: %+v", oldId, u.fromVersion, errs[0])
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type identityTestId struct {
+	ResourceGroupName string
+	Name              string
+}
+
+func (id identityTestId) ID() string {
+	return fmt.Sprintf("/resourceGroups/%s/things/%s", id.ResourceGroupName, id.Name)
+}
+
+func (id identityTestId) String() string {
+	return fmt.Sprintf("Thing %q (Resource Group %q)", id.Name, id.ResourceGroupName)
+}
+
+func parseIdentityTestIdInsensitively(input string) (*identityTestId, error) {
+	segments := strings.Split(strings.TrimPrefix(input, "/"), "/")
+	if len(segments) != 4 || !strings.EqualFold(segments[0], "resourceGroups") || !strings.EqualFold(segments[2], "things") {
+		return nil, fmt.Errorf("parsing %q as a Thing ID", input)
+	}
+	return &identityTestId{
+		ResourceGroupName: segments[1],
+		Name:              segments[3],
+	}, nil
+}
+
+// parseLegacyIdentityTestId parses the legacy format of the Resource ID, which used the segment `widgets`
+func parseLegacyIdentityTestId(input string) (*identityTestId, error) {
+	segments := strings.Split(strings.TrimPrefix(input, "/"), "/")
+	if len(segments) != 4 || segments[0] != "resourceGroups" || segments[2] != "widgets" {
+		return nil, fmt.Errorf("parsing %q as a legacy Thing ID", input)
+	}
+	return &identityTestId{
+		ResourceGroupName: segments[1],
+		Name:              segments[3],
+	}, nil
+}
+
+func TestResourceIdentityValidate(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    ResourceIdentity
+		Expected bool
+	}{
+		{
+			Name:     "empty",
+			Input:    ResourceIdentity{},
+			Expected: false,
+		},
+		{
+			Name: "no legacy formats",
+			Input: ResourceIdentity{
+				Parser: ResourceIdParser(parseIdentityTestIdInsensitively),
+			},
+			Expected: false,
+		},
+		{
+			Name: "no parser",
+			Input: ResourceIdentity{
+				LegacyFormats: []ResourceIdParseFunc{nil},
+			},
+			Expected: false,
+		},
+		{
+			Name: "valid",
+			Input: ResourceIdentity{
+				Parser:        ResourceIdParser(parseIdentityTestIdInsensitively),
+				LegacyFormats: []ResourceIdParseFunc{nil},
+			},
+			Expected: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		err := v.Input.Validate()
+		if v.Expected && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if !v.Expected && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+	}
+}
+
+func TestResourceIdentityStateUpgraders(t *testing.T) {
+	identity := ResourceIdentity{
+		Parser: ResourceIdParser(parseIdentityTestIdInsensitively),
+		LegacyFormats: []ResourceIdParseFunc{
+			// v0 -> v1: the segment `widgets` was renamed to `things`
+			ResourceIdParser(parseLegacyIdentityTestId),
+			// v1 -> v2: the casing of the Resource ID was normalised
+			nil,
+		},
+	}
+	resourceSchema := map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+	}
+
+	data := identity.StateUpgraders(resourceSchema)
+	if data.SchemaVersion != 2 {
+		t.Fatalf("expected the SchemaVersion to be 2 but got %d", data.SchemaVersion)
+	}
+	if upgraders := pluginsdk.StateUpgrades(data.Upgraders); len(upgraders) != 2 {
+		t.Fatalf("expected 2 State Upgraders but got %d", len(upgraders))
+	}
+
+	testData := []struct {
+		Name        string
+		FromVersion int
+		Input       string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:        "v0 legacy format",
+			FromVersion: 0,
+			Input:       "/resourceGroups/group1/widgets/thing1",
+			Expected:    "/resourceGroups/group1/things/thing1",
+		},
+		{
+			Name:        "v0 already in the current format",
+			FromVersion: 0,
+			Input:       "/resourceGroups/group1/things/thing1",
+			Expected:    "/resourceGroups/group1/things/thing1",
+		},
+		{
+			Name:        "v0 invalid",
+			FromVersion: 0,
+			Input:       "/resourceGroups/group1/gadgets/thing1",
+			ExpectError: true,
+		},
+		{
+			Name:        "v1 incorrect casing",
+			FromVersion: 1,
+			Input:       "/resourcegroups/group1/Things/thing1",
+			Expected:    "/resourceGroups/group1/things/thing1",
+		},
+		{
+			Name:        "v1 legacy format is no longer valid",
+			FromVersion: 1,
+			Input:       "/resourceGroups/group1/widgets/thing1",
+			ExpectError: true,
+		},
+		{
+			Name:        "empty id",
+			FromVersion: 1,
+			Input:       "",
+			Expected:    "",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		rawState := map[string]interface{}{
+			"id":   v.Input,
+			"name": "thing1",
+		}
+		actual, err := data.Upgraders[v.FromVersion].UpgradeFunc()(context.TODO(), rawState, nil)
+		if err != nil {
+			if v.ExpectError {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.ExpectError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if actual["id"].(string) != v.Expected {
+			t.Fatalf("expected the id to be %q but got %q", v.Expected, actual["id"])
+		}
+		if actual["name"].(string) != "thing1" {
+			t.Fatalf("expected the other fields to be unchanged but got %+v", actual)
+		}
+	}
+}
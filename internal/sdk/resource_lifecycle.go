@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// ResourceLifecycleTest runs the full lifecycle of a typed Resource (Create, Read, Update, Import and Delete)
+// through the same Plugin SDK shim used by the Provider. Combined with the local stand-in for Azure Resource
+// Manager within the `mockarm` package, this allows the encoding/decoding and polling logic for a Resource
+// to be tested without credentials.
+type ResourceLifecycleTest struct {
+	// Resource is the typed Resource being tested
+	Resource Resource
+
+	// Client is passed to the Resource, the Service Clients used by the Resource should be built using
+	// the ClientOptions for the mock server (e.g. `mockarm.Server.ClientOptions()`)
+	Client *clients.Client
+
+	// Config is the configuration used to Create the Resource
+	Config map[string]interface{}
+
+	// UpdatedConfig is an optional configuration used to Update the Resource
+	UpdatedConfig map[string]interface{}
+
+	// ImportStateVerifyIgnore is a list of attributes which are not returned from the API, and should be ignored
+	// when comparing the imported State to the State following Create/Update
+	ImportStateVerifyIgnore []string
+
+	// CheckFunc is an optional function which is called with the State after the Resource is Created and Updated
+	CheckFunc func(state *terraform.InstanceState) error
+}
+
+// Run runs the lifecycle for this Resource, returning the first step which fails
+func (rlt ResourceLifecycleTest) Run(ctx context.Context) error {
+	wrapper := NewResourceWrapper(rlt.Resource)
+	resource, err := wrapper.Resource()
+	if err != nil {
+		return fmt.Errorf("building Resource %q: %+v", rlt.Resource.ResourceType(), err)
+	}
+
+	state, err := rlt.applyConfig(ctx, resource, nil, rlt.Config)
+	if err != nil {
+		return fmt.Errorf("creating: %+v", err)
+	}
+
+	if rlt.UpdatedConfig != nil {
+		if _, ok := rlt.Resource.(ResourceWithUpdate); !ok {
+			return fmt.Errorf("an UpdatedConfig was specified but %q doesn't implement ResourceWithUpdate", rlt.Resource.ResourceType())
+		}
+
+		state, err = rlt.applyConfig(ctx, resource, state, rlt.UpdatedConfig)
+		if err != nil {
+			return fmt.Errorf("updating: %+v", err)
+		}
+	}
+
+	if err := rlt.importAndVerify(ctx, resource, state); err != nil {
+		return fmt.Errorf("importing: %+v", err)
+	}
+
+	if _, diags := resource.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, rlt.Client); diags.HasError() {
+		return fmt.Errorf("deleting: %+v", diagnosticsToError(diags))
+	}
+
+	remaining, diags := resource.RefreshWithoutUpgrade(ctx, state, rlt.Client)
+	if diags.HasError() {
+		return fmt.Errorf("reading after delete: %+v", diagnosticsToError(diags))
+	}
+	if remaining != nil && remaining.ID != "" {
+		return fmt.Errorf("%q still exists after being deleted", remaining.ID)
+	}
+
+	return nil
+}
+
+// applyConfig plans and applies the configuration, then confirms that the Resource is read back without a diff
+func (rlt ResourceLifecycleTest) applyConfig(ctx context.Context, resource *schema.Resource, state *terraform.InstanceState, config map[string]interface{}) (*terraform.InstanceState, error) {
+	resourceConfig := terraform.NewResourceConfigRaw(config)
+	if diags := resource.Validate(resourceConfig); diags.HasError() {
+		return nil, fmt.Errorf("validating configuration: %+v", diagnosticsToError(diags))
+	}
+
+	diff, err := resource.Diff(ctx, state, resourceConfig, rlt.Client)
+	if err != nil {
+		return nil, fmt.Errorf("planning: %+v", err)
+	}
+	if diff == nil {
+		diff = terraform.NewInstanceDiff()
+	}
+
+	newState, diags := resource.Apply(ctx, state, diff, rlt.Client)
+	if diags.HasError() {
+		return nil, fmt.Errorf("applying: %+v", diagnosticsToError(diags))
+	}
+	if newState == nil || newState.ID == "" {
+		return nil, fmt.Errorf("no ID was set after applying")
+	}
+
+	newState, diags = resource.RefreshWithoutUpgrade(ctx, newState, rlt.Client)
+	if diags.HasError() {
+		return nil, fmt.Errorf("reading: %+v", diagnosticsToError(diags))
+	}
+	if newState == nil || newState.ID == "" {
+		return nil, fmt.Errorf("the Resource was not found after applying")
+	}
+
+	if rlt.CheckFunc != nil {
+		if err := rlt.CheckFunc(newState); err != nil {
+			return nil, fmt.Errorf("checking state: %+v", err)
+		}
+	}
+
+	// a diff at this point means the Resource isn't being encoded/decoded consistently
+	planAfterApply, err := resource.Diff(ctx, newState, resourceConfig, rlt.Client)
+	if err != nil {
+		return nil, fmt.Errorf("planning after apply: %+v", err)
+	}
+	if planAfterApply != nil && !planAfterApply.Empty() {
+		return nil, fmt.Errorf("expected an empty plan after applying but got changes for %s", diffAttributeNames(planAfterApply))
+	}
+
+	return newState, nil
+}
+
+// importAndVerify imports the Resource using its ID, and confirms the imported State matches the existing State
+func (rlt ResourceLifecycleTest) importAndVerify(ctx context.Context, resource *schema.Resource, state *terraform.InstanceState) error {
+	if resource.Importer == nil || resource.Importer.StateContext == nil {
+		return fmt.Errorf("%q doesn't support being imported", rlt.Resource.ResourceType())
+	}
+
+	data := resource.Data(&terraform.InstanceState{ID: state.ID})
+	imported, err := resource.Importer.StateContext(ctx, data, rlt.Client)
+	if err != nil {
+		return err
+	}
+	if len(imported) != 1 {
+		return fmt.Errorf("expected a single Resource to be imported but got %d", len(imported))
+	}
+
+	importedState, diags := resource.RefreshWithoutUpgrade(ctx, imported[0].State(), rlt.Client)
+	if diags.HasError() {
+		return fmt.Errorf("reading: %+v", diagnosticsToError(diags))
+	}
+	if importedState == nil {
+		return fmt.Errorf("the Resource %q was not found", state.ID)
+	}
+
+	ignored := func(key string) bool {
+		for _, v := range rlt.ImportStateVerifyIgnore {
+			if key == v || strings.HasPrefix(key, v+".") {
+				return true
+			}
+		}
+		return false
+	}
+
+	differences := make([]string, 0)
+	for key, value := range state.Attributes {
+		if ignored(key) || strings.HasPrefix(key, "timeouts.") {
+			continue
+		}
+		if importedState.Attributes[key] != value {
+			differences = append(differences, fmt.Sprintf("%s (expected %q but got %q)", key, value, importedState.Attributes[key]))
+		}
+	}
+	for key, value := range importedState.Attributes {
+		if _, ok := state.Attributes[key]; !ok && !ignored(key) && !strings.HasPrefix(key, "timeouts.") {
+			differences = append(differences, fmt.Sprintf("%s (expected no value but got %q)", key, value))
+		}
+	}
+	if len(differences) > 0 {
+		sort.Strings(differences)
+		return fmt.Errorf("the imported State differs from the existing State: %s", strings.Join(differences, ", "))
+	}
+
+	return nil
+}
+
+func diffAttributeNames(diff *terraform.InstanceDiff) string {
+	names := make([]string, 0)
+	for name := range diff.Attributes {
+		names = append(names, fmt.Sprintf("%q", name))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func diagnosticsToError(diags diag.Diagnostics) error {
+	messages := make([]string, 0)
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			messages = append(messages, d.Summary)
+		}
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "\n"))
+}
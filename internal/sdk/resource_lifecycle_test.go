@@ -0,0 +1,311 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/mockarm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestResourceLifecycleAgainstMockServer(t *testing.T) {
+	for _, pollingStyle := range []mockarm.PollingStyle{mockarm.PollingStyleAzureAsyncOperation, mockarm.PollingStyleLocation} {
+		t.Logf("[DEBUG] Test %q", string(pollingStyle))
+
+		server := mockarm.NewServer(mockarm.ServerOptions{
+			PollingStyle:       pollingStyle,
+			PollsUntilComplete: 2,
+		})
+
+		o := server.ClientOptions()
+		c, err := resourcemanager.NewResourceManagerClient(o.Environment.ResourceManager, "resourcegroups", "2022-09-01")
+		if err != nil {
+			t.Fatalf("building client: %+v", err)
+		}
+		o.Configure(c, o.Authorizers.ResourceManager)
+
+		test := ResourceLifecycleTest{
+			Resource: lifecycleTestResource{
+				client: c,
+			},
+			Client: &clients.Client{},
+			Config: map[string]interface{}{
+				"name":     "example",
+				"location": "westeurope",
+			},
+			UpdatedConfig: map[string]interface{}{
+				"name":     "example",
+				"location": "westeurope",
+				"tags": map[string]interface{}{
+					"hello": "world",
+				},
+			},
+			CheckFunc: func(state *terraform.InstanceState) error {
+				if state.Attributes["provisioning_state"] != "Succeeded" {
+					return fmt.Errorf("expected `provisioning_state` to be `Succeeded` but got %q", state.Attributes["provisioning_state"])
+				}
+				return nil
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		if err := test.Run(ctx); err != nil {
+			t.Fatalf("running lifecycle: %+v", err)
+		}
+		cancel()
+		server.Close()
+	}
+}
+
+type lifecycleTestModel struct {
+	Name              string            `tfschema:"name"`
+	Location          string            `tfschema:"location"`
+	Tags              map[string]string `tfschema:"tags"`
+	ProvisioningState string            `tfschema:"provisioning_state"`
+}
+
+type lifecycleTestPayload struct {
+	Location   *string                         `json:"location,omitempty"`
+	Tags       *map[string]string              `json:"tags,omitempty"`
+	Properties *lifecycleTestPayloadProperties `json:"properties,omitempty"`
+}
+
+type lifecycleTestPayloadProperties struct {
+	ProvisioningState *string `json:"provisioningState,omitempty"`
+}
+
+var _ ResourceWithUpdate = lifecycleTestResource{}
+
+// lifecycleTestResource is a minimal Resource Group resource, which uses a go-azure-sdk client directly
+type lifecycleTestResource struct {
+	client *resourcemanager.Client
+}
+
+func (r lifecycleTestResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"location": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"tags": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r lifecycleTestResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"provisioning_state": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r lifecycleTestResource) ModelObject() interface{} {
+	return &lifecycleTestModel{}
+}
+
+func (r lifecycleTestResource) ResourceType() string {
+	return "validator_resource_group"
+}
+
+func (r lifecycleTestResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return commonids.ValidateResourceGroupID
+}
+
+func (r lifecycleTestResource) Create() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			var model lifecycleTestModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := commonids.NewResourceGroupID(mockarm.SubscriptionId, model.Name)
+			payload := lifecycleTestPayload{
+				Location: &model.Location,
+				Tags:     &model.Tags,
+			}
+			if err := r.sendThenPoll(ctx, http.MethodPut, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r lifecycleTestResource) Read() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			id, err := commonids.ParseResourceGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			req, err := r.client.NewRequest(ctx, client.RequestOptions{
+				ContentType:         "application/json; charset=utf-8",
+				ExpectedStatusCodes: []int{http.StatusOK},
+				HttpMethod:          http.MethodGet,
+				Path:                id.ID(),
+			})
+			if err != nil {
+				return err
+			}
+
+			resp, err := req.Execute(ctx)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			var payload lifecycleTestPayload
+			if err := resp.Unmarshal(&payload); err != nil {
+				return fmt.Errorf("parsing %s: %+v", id, err)
+			}
+
+			model := lifecycleTestModel{
+				Name: id.ResourceGroupName,
+			}
+			if payload.Location != nil {
+				model.Location = *payload.Location
+			}
+			if payload.Tags != nil {
+				model.Tags = *payload.Tags
+			}
+			if payload.Properties != nil && payload.Properties.ProvisioningState != nil {
+				model.ProvisioningState = *payload.Properties.ProvisioningState
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r lifecycleTestResource) Update() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			id, err := commonids.ParseResourceGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model lifecycleTestModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			payload := lifecycleTestPayload{
+				Tags: &model.Tags,
+			}
+			if err := r.sendThenPoll(ctx, http.MethodPatch, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r lifecycleTestResource) Delete() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			id, err := commonids.ParseResourceGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := r.sendThenPoll(ctx, http.MethodDelete, *id, nil); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r lifecycleTestResource) sendThenPoll(ctx context.Context, method string, id commonids.ResourceGroupId, input interface{}) error {
+	req, err := r.client.NewRequest(ctx, client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusAccepted,
+			http.StatusCreated,
+			http.StatusNoContent,
+			http.StatusOK,
+		},
+		HttpMethod: method,
+		Path:       id.ID(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if input != nil {
+		if err := req.Marshal(input); err != nil {
+			return err
+		}
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the go-azure-sdk polls every 10 seconds when deleting, so the Resource is polled directly to keep this test fast
+	if method == http.MethodDelete {
+		for {
+			req, err := r.client.NewRequest(ctx, client.RequestOptions{
+				ContentType:         "application/json; charset=utf-8",
+				ExpectedStatusCodes: []int{http.StatusOK, http.StatusNotFound},
+				HttpMethod:          http.MethodGet,
+				Path:                id.ID(),
+			})
+			if err != nil {
+				return err
+			}
+			resp, err := req.Execute(ctx)
+			if err != nil {
+				return err
+			}
+			if resp.StatusCode == http.StatusNotFound {
+				return nil
+			}
+		}
+	}
+
+	poller, err := resourcemanager.PollerFromResponse(resp, r.client)
+	if err != nil {
+		return err
+	}
+
+	return poller.PollUntilDone(ctx)
+}
@@ -0,0 +1,336 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+type optionalState int
+
+const (
+	// optionalUnset means no value is available for the property, for example as it's not set in the
+	// Terraform Configuration or the Terraform State
+	optionalUnset optionalState = iota
+
+	// optionalNull means the property is null within the Terraform Configuration
+	optionalNull
+
+	// optionalValue means the property has a value, which may be the zero value (e.g. `false` or `0`)
+	optionalValue
+)
+
+// Optional is a field within a Typed Model which distinguishes between a property which is unset, a property
+// which is explicitly null within the Terraform Configuration, and a property which is set to a value (including
+// the zero value, such as `false`, `0` or an empty string) - which can't be distinguished using a plain field.
+//
+// When Decoding:
+//   - if the Terraform Configuration is available (e.g. during Create/Update/CustomizeDiff) a property which is null
+//     within the Terraform Configuration is Null, a property which isn't known until apply is Unset, otherwise the
+//     property has a Value.
+//   - otherwise (e.g. during Read) a property which exists within the Terraform State has a Value, otherwise it's Unset.
+//
+// When Encoding an Unset property isn't written (leaving the existing value within the Terraform State), a Null
+// property is removed from the Terraform State and a property with a Value is written as normal.
+//
+// Pointer fields (e.g. `*bool`) can be used in the same manner, where a nil pointer is both Unset and Null.
+//
+// Example Usage:
+//
+//	type ExampleModel struct {
+//		Enabled sdk.Optional[bool] `tfschema:"enabled"`
+//	}
+//
+//	if v, ok := model.Enabled.Get(); ok {
+//		payload.Properties.Enabled = pointer.To(v)
+//	}
+type Optional[T any] struct {
+	state optionalState
+	value T
+}
+
+// OptionalOf returns an Optional with the specified value
+func OptionalOf[T any](value T) Optional[T] {
+	return Optional[T]{
+		state: optionalValue,
+		value: value,
+	}
+}
+
+// OptionalNull returns an Optional which is explicitly null
+func OptionalNull[T any]() Optional[T] {
+	return Optional[T]{
+		state: optionalNull,
+	}
+}
+
+// OptionalFromPointer returns an Optional containing the value of the pointer, which is Null when the pointer is nil
+// (for example when an optional field isn't returned from the API)
+func OptionalFromPointer[T any](input *T) Optional[T] {
+	if input == nil {
+		return OptionalNull[T]()
+	}
+	return OptionalOf(*input)
+}
+
+// Get returns the value and whether a value is set
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionalValue
+}
+
+// HasValue returns whether a value (which may be the zero value) is set
+func (o Optional[T]) HasValue() bool {
+	return o.state == optionalValue
+}
+
+// IsNull returns whether this is explicitly null
+func (o Optional[T]) IsNull() bool {
+	return o.state == optionalNull
+}
+
+// IsUnset returns whether this is unset, that is neither null nor set to a value
+func (o Optional[T]) IsUnset() bool {
+	return o.state == optionalUnset
+}
+
+// ValueOrDefault returns the value when set, otherwise the specified default value
+func (o Optional[T]) ValueOrDefault(defaultValue T) T {
+	if o.state == optionalValue {
+		return o.value
+	}
+	return defaultValue
+}
+
+// Pointer returns a pointer to the value when set, otherwise nil - which is intended for use with the API models
+func (o Optional[T]) Pointer() *T {
+	if o.state != optionalValue {
+		return nil
+	}
+	value := o.value
+	return &value
+}
+
+func (o Optional[T]) String() string {
+	switch o.state {
+	case optionalNull:
+		return "null"
+	case optionalValue:
+		return fmt.Sprintf("%+v", o.value)
+	}
+	return "unset"
+}
+
+// optionalField is implemented by Optional, allowing it to be Decoded/Encoded using reflection
+type optionalField interface {
+	optionalType() reflect.Type
+	getOptional() (optionalState, reflect.Value)
+	setOptional(state optionalState, value reflect.Value)
+}
+
+func (o *Optional[T]) optionalType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (o *Optional[T]) getOptional() (optionalState, reflect.Value) {
+	return o.state, reflect.ValueOf(&o.value).Elem()
+}
+
+func (o *Optional[T]) setOptional(state optionalState, value reflect.Value) {
+	o.state = state
+	var zero T
+	o.value = zero
+	if state == optionalValue && value.IsValid() {
+		o.value = value.Interface().(T)
+	}
+}
+
+var optionalFieldType = reflect.TypeOf((*optionalField)(nil)).Elem()
+
+// isOptionalType returns whether the field is either an Optional or a pointer, which distinguish between
+// unset/null values and zero values
+func isOptionalType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		return true
+	}
+	return fieldType.Kind() == reflect.Struct && reflect.PtrTo(fieldType).Implements(optionalFieldType)
+}
+
+// optionalInnerType returns the type of the value within the Optional or pointer
+func optionalInnerType(fieldType reflect.Type) reflect.Type {
+	if fieldType.Kind() == reflect.Ptr {
+		return fieldType.Elem()
+	}
+	return reflect.New(fieldType).Interface().(optionalField).optionalType()
+}
+
+// optionalHolder returns a new struct containing a single field of the specified type, allowing values to be
+// Decoded/Encoded into a type which isn't directly part of the Model
+func optionalHolder(innerType reflect.Type) reflect.Value {
+	holderType := reflect.StructOf([]reflect.StructField{
+		{
+			Name: "Value",
+			Type: innerType,
+			Tag:  `tfschema:"value"`,
+		},
+	})
+	return reflect.New(holderType)
+}
+
+// optionalStateFor determines the state of a property, using the Terraform Configuration when it's available
+func optionalStateFor(config cty.Value, key string, exists bool) optionalState {
+	// a value which isn't known until apply (e.g. during CustomizeDiff) is Unset, rather than the zero value
+	if !config.IsKnown() {
+		return optionalUnset
+	}
+
+	if attr, ok := configAttribute(config, key); ok {
+		if !attr.IsKnown() {
+			return optionalUnset
+		}
+		if attr.IsNull() {
+			return optionalNull
+		}
+		return optionalValue
+	}
+
+	if exists {
+		return optionalValue
+	}
+	return optionalUnset
+}
+
+// setOptionalValue sets the Optional (or pointer) field at the specified index within the input
+func setOptionalValue(input, tfschemaValue interface{}, state optionalState, index int, fieldName string, config cty.Value, debugLogger Logger) error {
+	field := reflect.ValueOf(input).Elem().Field(index)
+	debugLogger.Infof("[OPTIONAL] Decode %q as %d", fieldName, state)
+
+	var value reflect.Value
+	if state == optionalValue {
+		holder := optionalHolder(optionalInnerType(field.Type()))
+		if tfschemaValue != nil {
+			if err := setValue(holder.Interface(), tfschemaValue, 0, fieldName, config, debugLogger); err != nil {
+				return err
+			}
+		}
+		value = holder.Elem().Field(0)
+	}
+
+	if field.Kind() == reflect.Ptr {
+		if !value.IsValid() {
+			field.Set(reflect.Zero(field.Type()))
+			return nil
+		}
+		pointer := reflect.New(field.Type().Elem())
+		pointer.Elem().Set(value)
+		field.Set(pointer)
+		return nil
+	}
+
+	field.Addr().Interface().(optionalField).setOptional(state, value)
+	return nil
+}
+
+// encodeOptionalValue returns the value for the Optional (or pointer) field and whether it should be written
+func encodeOptionalValue(fieldVal reflect.Value, fieldName string, debugLogger Logger) (interface{}, bool, error) {
+	var value reflect.Value
+	if fieldVal.Kind() == reflect.Ptr {
+		if fieldVal.IsNil() {
+			return nil, true, nil
+		}
+		value = fieldVal.Elem()
+	} else {
+		// the field may not be addressable (e.g. within a slice of nested objects), so it's copied
+		copied := reflect.New(fieldVal.Type())
+		copied.Elem().Set(fieldVal)
+		state, v := copied.Interface().(optionalField).getOptional()
+		switch state {
+		case optionalUnset:
+			return nil, false, nil
+		case optionalNull:
+			return nil, true, nil
+		}
+		value = v
+	}
+
+	holder := optionalHolder(value.Type()).Elem()
+	holder.Field(0).Set(value)
+	serialized, err := recurse(holder.Type(), holder, fieldName, debugLogger)
+	if err != nil {
+		return nil, false, err
+	}
+	return serialized["value"], true, nil
+}
+
+// configAttribute returns the value of the attribute within the Terraform Configuration for the object, and
+// whether the Terraform Configuration is available
+func configAttribute(config cty.Value, key string) (cty.Value, bool) {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() {
+		return cty.NilVal, false
+	}
+	if !config.Type().HasAttribute(key) {
+		return cty.NilVal, false
+	}
+	return config.GetAttr(key), true
+}
+
+// configElement returns the Terraform Configuration for the item at the specified index within a list or set,
+// where the decoded value is used to find the matching item within a set, since the items within a set are
+// ordered differently within the Terraform Configuration
+func configElement(config cty.Value, index int, decoded map[string]interface{}) cty.Value {
+	if config.IsNull() || !config.IsKnown() || !config.CanIterateElements() {
+		return cty.NilVal
+	}
+
+	elements := config.AsValueSlice()
+	if config.Type().IsListType() || config.Type().IsTupleType() {
+		if index < len(elements) {
+			return elements[index]
+		}
+		return cty.NilVal
+	}
+
+	for _, element := range elements {
+		if configElementMatches(element, decoded) {
+			return element
+		}
+	}
+	return cty.NilVal
+}
+
+// configElementMatches returns whether each of the primitive attributes set within the Terraform Configuration for
+// the item within a set match the decoded value
+func configElementMatches(element cty.Value, decoded map[string]interface{}) bool {
+	if element.IsNull() || !element.IsKnown() || !element.Type().IsObjectType() {
+		return false
+	}
+
+	for key, attr := range element.AsValueMap() {
+		if attr.IsNull() || !attr.IsKnown() || !attr.Type().IsPrimitiveType() {
+			continue
+		}
+
+		value, ok := decoded[key]
+		if !ok {
+			return false
+		}
+		switch attr.Type() {
+		case cty.String:
+			if v, ok := value.(string); !ok || v != attr.AsString() {
+				return false
+			}
+		case cty.Bool:
+			if v, ok := value.(bool); !ok || v != attr.True() {
+				return false
+			}
+		case cty.Number:
+			if fmt.Sprint(value) != attr.AsBigFloat().Text('f', -1) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,424 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type optionalNestedType struct {
+	Name    string           `tfschema:"name"`
+	Enabled Optional[bool]   `tfschema:"enabled"`
+	Count   *int             `tfschema:"count"`
+	Tier    Optional[string] `tfschema:"tier"`
+}
+
+type optionalType struct {
+	Enabled  Optional[bool]                 `tfschema:"enabled"`
+	Number   Optional[int64]                `tfschema:"number"`
+	Name     Optional[string]               `tfschema:"name"`
+	Price    *float64                       `tfschema:"price"`
+	Zones    Optional[[]string]             `tfschema:"zones"`
+	Tags     Optional[map[string]string]    `tfschema:"tags"`
+	List     []optionalNestedType           `tfschema:"list"`
+	Set      []optionalNestedType           `tfschema:"set"`
+	Optional Optional[[]optionalNestedType] `tfschema:"optional_list"`
+}
+
+func TestDecode_OptionalWithoutConfig(t *testing.T) {
+	// NOTE: this scenario covers Read, where the Terraform Configuration isn't available
+	price := float64(0)
+	decodeTestData{
+		State: map[string]interface{}{
+			"enabled": false,
+			"number":  0,
+			"price":   float64(0),
+			"zones":   []interface{}{"1"},
+			"list": []interface{}{
+				map[string]interface{}{
+					"name":    "first",
+					"enabled": false,
+					"count":   0,
+				},
+			},
+		},
+		Input: &optionalType{},
+		Expected: &optionalType{
+			Enabled: OptionalOf(false),
+			Number:  OptionalOf(int64(0)),
+			Price:   &price,
+			Zones:   OptionalOf([]string{"1"}),
+			List: []optionalNestedType{
+				{
+					Name:    "first",
+					Enabled: OptionalOf(false),
+					Count:   pointerToInt(0),
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestDecode_OptionalWithConfig(t *testing.T) {
+	// NOTE: this scenario covers Create/Update, where the Terraform Configuration is available
+	nestedType := cty.Object(map[string]cty.Type{
+		"name":    cty.String,
+		"enabled": cty.Bool,
+		"count":   cty.Number,
+		"tier":    cty.String,
+	})
+	config := cty.ObjectVal(map[string]cty.Value{
+		"enabled": cty.False,
+		"number":  cty.NullVal(cty.Number),
+		"name":    cty.StringVal(""),
+		"price":   cty.NullVal(cty.Number),
+		"zones":   cty.NullVal(cty.List(cty.String)),
+		"tags": cty.MapVal(map[string]cty.Value{
+			"environment": cty.StringVal("test"),
+		}),
+		"list": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("first"),
+				"enabled": cty.False,
+				"count":   cty.NullVal(cty.Number),
+				"tier":    cty.NullVal(cty.String),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("second"),
+				"enabled": cty.NullVal(cty.Bool),
+				"count":   cty.NumberIntVal(0),
+				"tier":    cty.StringVal(""),
+			}),
+		}),
+		// the items within a set are ordered differently within the Terraform Configuration
+		"set": cty.SetVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("second"),
+				"enabled": cty.True,
+				"count":   cty.NullVal(cty.Number),
+				"tier":    cty.NullVal(cty.String),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("first"),
+				"enabled": cty.NullVal(cty.Bool),
+				"count":   cty.NumberIntVal(0),
+				"tier":    cty.NullVal(cty.String),
+			}),
+		}),
+		"optional_list": cty.NullVal(cty.List(nestedType)),
+	})
+
+	testData := decodeTestData{
+		State: map[string]interface{}{
+			"enabled": false,
+			"number":  0,
+			"name":    "",
+			"tags": map[string]interface{}{
+				"environment": "test",
+			},
+			"list": []interface{}{
+				map[string]interface{}{
+					"name":    "first",
+					"enabled": false,
+					"count":   0,
+					"tier":    "",
+				},
+				map[string]interface{}{
+					"name":    "second",
+					"enabled": false,
+					"count":   0,
+					"tier":    "",
+				},
+			},
+			"set": schema.NewSet(func(i interface{}) int {
+				return schema.HashString(i.(map[string]interface{})["name"])
+			}, []interface{}{
+				map[string]interface{}{
+					"name":    "first",
+					"enabled": false,
+					"count":   0,
+					"tier":    "",
+				},
+				map[string]interface{}{
+					"name":    "second",
+					"enabled": true,
+					"count":   0,
+					"tier":    "",
+				},
+			}),
+		},
+		Input: &optionalType{},
+		Expected: &optionalType{
+			Enabled: OptionalOf(false),
+			Number:  OptionalNull[int64](),
+			Name:    OptionalOf(""),
+			Zones:   OptionalNull[[]string](),
+			Tags: OptionalOf(map[string]string{
+				"environment": "test",
+			}),
+			List: []optionalNestedType{
+				{
+					Name:    "first",
+					Enabled: OptionalOf(false),
+					Tier:    OptionalNull[string](),
+				},
+				{
+					Name:    "second",
+					Enabled: OptionalNull[bool](),
+					Count:   pointerToInt(0),
+					Tier:    OptionalOf(""),
+				},
+			},
+			Optional: OptionalNull[[]optionalNestedType](),
+		},
+	}
+
+	input := &optionalType{}
+	state := testDataGetterWithConfig{
+		testDataGetter: testData.stateWrapper(),
+		config:         config,
+	}
+	if err := decodeReflectedType(input, state, ConsoleLogger{}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	// the order of the items within the set is based on the hash, so this is compared separately
+	expectedSet := map[string]optionalNestedType{
+		"first": {
+			Name:    "first",
+			Enabled: OptionalNull[bool](),
+			Count:   pointerToInt(0),
+			Tier:    OptionalNull[string](),
+		},
+		"second": {
+			Name:    "second",
+			Enabled: OptionalOf(true),
+			Tier:    OptionalNull[string](),
+		},
+	}
+	if len(input.Set) != len(expectedSet) {
+		t.Fatalf("expected %d items within the set but got %d", len(expectedSet), len(input.Set))
+	}
+	for _, item := range input.Set {
+		if !reflect.DeepEqual(item, expectedSet[item.Name]) {
+			t.Fatalf("\nExpected: %+v\n\n Received %+v\n\n", expectedSet[item.Name], item)
+		}
+	}
+
+	input.Set = nil
+	if !reflect.DeepEqual(input, testData.Expected) {
+		t.Fatalf("\nExpected: %+v\n\n Received %+v\n\n", testData.Expected, input)
+	}
+}
+
+func TestDecode_OptionalWithUnknownConfig(t *testing.T) {
+	// NOTE: this scenario covers CustomizeDiff, where values within the Terraform Configuration may not be known
+	// until apply - which are Unset rather than the zero value
+	nestedType := cty.Object(map[string]cty.Type{
+		"name":    cty.String,
+		"enabled": cty.Bool,
+		"count":   cty.Number,
+		"tier":    cty.String,
+	})
+	config := cty.ObjectVal(map[string]cty.Value{
+		"enabled": cty.UnknownVal(cty.Bool),
+		"number":  cty.NumberIntVal(0),
+		"name":    cty.UnknownVal(cty.String),
+		"price":   cty.UnknownVal(cty.Number),
+		"zones":   cty.UnknownVal(cty.List(cty.String)),
+		"tags":    cty.NullVal(cty.Map(cty.String)),
+		"list": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("first"),
+				"enabled": cty.UnknownVal(cty.Bool),
+				"count":   cty.UnknownVal(cty.Number),
+				"tier":    cty.StringVal(""),
+			}),
+			cty.UnknownVal(nestedType),
+		}),
+		"set":           cty.NullVal(cty.Set(nestedType)),
+		"optional_list": cty.UnknownVal(cty.List(nestedType)),
+	})
+
+	testData := decodeTestData{
+		State: map[string]interface{}{
+			"enabled": false,
+			"number":  0,
+			"name":    "",
+			"price":   float64(0),
+			"list": []interface{}{
+				map[string]interface{}{
+					"name":    "first",
+					"enabled": false,
+					"count":   0,
+					"tier":    "",
+				},
+				map[string]interface{}{
+					"name":    "",
+					"enabled": false,
+					"count":   0,
+					"tier":    "",
+				},
+			},
+		},
+		Input: &optionalType{},
+		Expected: &optionalType{
+			Number: OptionalOf(int64(0)),
+			Tags:   OptionalNull[map[string]string](),
+			List: []optionalNestedType{
+				{
+					Name: "first",
+					Tier: OptionalOf(""),
+				},
+				{},
+			},
+		},
+	}
+
+	input := &optionalType{}
+	state := testDataGetterWithConfig{
+		testDataGetter: testData.stateWrapper(),
+		config:         config,
+	}
+	if err := decodeReflectedType(input, state, ConsoleLogger{}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if !reflect.DeepEqual(input, testData.Expected) {
+		t.Fatalf("\nExpected: %+v\n\n Received %+v\n\n", testData.Expected, input)
+	}
+}
+
+func TestResourceEncode_Optional(t *testing.T) {
+	price := float64(0)
+	encodeTestData{
+		Input: &optionalType{
+			Enabled: OptionalOf(false),
+			Number:  OptionalNull[int64](),
+			Price:   &price,
+			Zones:   OptionalOf([]string{"1", "2"}),
+			List: []optionalNestedType{
+				{
+					Name:    "first",
+					Enabled: OptionalOf(false),
+					Tier:    OptionalNull[string](),
+				},
+			},
+			Optional: OptionalOf([]optionalNestedType{
+				{
+					Name:  "second",
+					Count: pointerToInt(0),
+				},
+			}),
+		},
+		Expected: map[string]interface{}{
+			"enabled": false,
+			"number":  nil,
+			"price":   float64(0),
+			"zones":   []string{"1", "2"},
+			"list": []interface{}{
+				map[string]interface{}{
+					"name":    "first",
+					"enabled": false,
+					"count":   nil,
+					"tier":    nil,
+				},
+			},
+			"set": []interface{}{},
+			"optional_list": []interface{}{
+				map[string]interface{}{
+					"name":  "second",
+					"count": int64(0),
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestOptional(t *testing.T) {
+	testData := []struct {
+		Name             string
+		Input            Optional[string]
+		ExpectedValue    string
+		ExpectedHasValue bool
+		ExpectedIsNull   bool
+		ExpectedIsUnset  bool
+		ExpectedPointer  *string
+	}{
+		{
+			Name:            "unset",
+			Input:           Optional[string]{},
+			ExpectedValue:   "default",
+			ExpectedIsUnset: true,
+		},
+		{
+			Name:           "null",
+			Input:          OptionalNull[string](),
+			ExpectedValue:  "default",
+			ExpectedIsNull: true,
+		},
+		{
+			Name:           "null from a nil pointer",
+			Input:          OptionalFromPointer[string](nil),
+			ExpectedValue:  "default",
+			ExpectedIsNull: true,
+		},
+		{
+			Name:             "zero value",
+			Input:            OptionalOf(""),
+			ExpectedValue:    "",
+			ExpectedHasValue: true,
+			ExpectedPointer:  pointerToString(""),
+		},
+		{
+			Name:             "value",
+			Input:            OptionalFromPointer(pointerToString("example")),
+			ExpectedValue:    "example",
+			ExpectedHasValue: true,
+			ExpectedPointer:  pointerToString("example"),
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		if actual := v.Input.ValueOrDefault("default"); actual != v.ExpectedValue {
+			t.Fatalf("expected the value to be %q but got %q", v.ExpectedValue, actual)
+		}
+		if actual := v.Input.HasValue(); actual != v.ExpectedHasValue {
+			t.Fatalf("expected HasValue to be %t but got %t", v.ExpectedHasValue, actual)
+		}
+		if actual := v.Input.IsNull(); actual != v.ExpectedIsNull {
+			t.Fatalf("expected IsNull to be %t but got %t", v.ExpectedIsNull, actual)
+		}
+		if actual := v.Input.IsUnset(); actual != v.ExpectedIsUnset {
+			t.Fatalf("expected IsUnset to be %t but got %t", v.ExpectedIsUnset, actual)
+		}
+		if actual := v.Input.Pointer(); !cmp.Equal(actual, v.ExpectedPointer) {
+			t.Fatalf("expected the pointer to be %+v but got %+v", v.ExpectedPointer, actual)
+		}
+	}
+}
+
+type testDataGetterWithConfig struct {
+	testDataGetter
+	config cty.Value
+}
+
+func (td testDataGetterWithConfig) GetRawConfig() cty.Value {
+	return td.config
+}
+
+func pointerToInt(input int) *int {
+	return &input
+}
+
+func pointerToString(input string) *string {
+	return &input
+}
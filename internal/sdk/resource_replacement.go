@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReplacementMigration describes how the Terraform Configuration for a deprecated Resource can be
+// migrated to the Resource(s) replacing it - which is used by the `config-migrator` tool.
+//
+// The existing Resource is imported into the replacement Resource, as such the replacement Resource
+// must use the same Resource ID.
+type ReplacementMigration struct {
+	// Replacements are the Resources which can replace the deprecated Resource, where the first
+	// Replacement matching the Terraform Configuration is used
+	Replacements []ResourceReplacement
+}
+
+// ResourceReplacement describes how the Terraform Configuration for a deprecated Resource maps to
+// the Resource replacing it.
+type ResourceReplacement struct {
+	// ResourceType is the type of the Resource replacing the deprecated Resource, e.g. `azurerm_linux_web_app`
+	ResourceType string
+
+	// WhenPropertiesSet are the paths to properties (e.g. `site_config.linux_fx_version`) which must all be
+	// set in the Terraform Configuration for this Replacement to be used - when empty this Replacement matches
+	// any Terraform Configuration
+	WhenPropertiesSet []string
+
+	// RenamedProperties maps the path to a property within the deprecated Resource (e.g. `site_config.min_tls_version`)
+	// to the name of the same property within the replacement Resource (e.g. `minimum_tls_version`)
+	RenamedProperties map[string]string
+
+	// RemovedProperties maps the path to a property (or block) within the deprecated Resource which isn't available
+	// within the replacement Resource to a note describing how it should be migrated, since these are removed from
+	// the Terraform Configuration and require manual changes
+	RemovedProperties map[string]string
+}
+
+// Validate validates the ReplacementMigration, optionally ensuring that each Replacement uses one of the
+// specified Resource Types (e.g. the value of `DeprecatedInFavourOfResource`)
+func (m ReplacementMigration) Validate(resourceTypes ...string) error {
+	if len(m.Replacements) == 0 {
+		return fmt.Errorf("at least one Replacement must be specified")
+	}
+
+	for i, replacement := range m.Replacements {
+		if replacement.ResourceType == "" {
+			return fmt.Errorf("the ResourceType for Replacement %d must not be empty", i)
+		}
+
+		if len(resourceTypes) > 0 {
+			found := false
+			for _, resourceType := range resourceTypes {
+				if resourceType == replacement.ResourceType {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("the ResourceType for Replacement %d must be one of %q but got %q", i, resourceTypes, replacement.ResourceType)
+			}
+		}
+
+		for path, renamed := range replacement.RenamedProperties {
+			if _, ok := replacement.RemovedProperties[path]; ok {
+				return fmt.Errorf("the property %q for Replacement %d cannot be both renamed and removed", path, i)
+			}
+			if renamed == "" || strings.Contains(renamed, ".") {
+				return fmt.Errorf("the property %q for Replacement %d must be renamed to a property within the same block but got %q", path, i, renamed)
+			}
+		}
+	}
+
+	return nil
+}
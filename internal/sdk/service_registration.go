@@ -64,3 +64,15 @@ type UntypedServiceRegistrationWithAGitHubLabel interface {
 
 	AssociatedGitHubLabel() string
 }
+
+// UntypedServiceRegistrationWithReplacementMigrations is a superset of UntypedServiceRegistration allowing
+// the deprecated Resources within this Service to describe how Terraform Configuration using them can be
+// migrated to the Resources replacing them.
+//
+// NOTE: Typed Resources should implement ResourceWithReplacementMigration instead
+type UntypedServiceRegistrationWithReplacementMigrations interface {
+	UntypedServiceRegistration
+
+	// ReplacementMigrations returns the ReplacementMigration for each deprecated Resource (keyed by the Resource Type)
+	ReplacementMigrations() map[string]ReplacementMigration
+}
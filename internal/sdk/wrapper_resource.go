@@ -11,6 +11,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -51,11 +53,15 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		Schema: *resourceSchema,
 
 		CreateContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+			ctx = rw.withLockOwner(ctx, "Create", d)
 			metaData := runArgs(d, meta, rw.logger)
 			err := rw.resource.Create().Func(ctx, metaData)
 			if err != nil {
 				return err
 			}
+			if err := rw.waitForConsistency(ctx, "Create", metaData); err != nil {
+				return err
+			}
 			// NOTE: whilst this may look like we should use the Read
 			// functions timeout here, we're still /technically/ in the
 			// Create function so reusing that timeout should be sufficient
@@ -64,10 +70,12 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 
 		// looks like these could be reused, easiest if they're not
 		ReadContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+			ctx = rw.withLockOwner(ctx, "Read", d)
 			metaData := runArgs(d, meta, rw.logger)
 			return rw.resource.Read().Func(ctx, metaData)
 		}),
 		DeleteContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+			ctx = rw.withLockOwner(ctx, "Delete", d)
 			metaData := runArgs(d, meta, rw.logger)
 			return rw.resource.Delete().Func(ctx, metaData)
 		}),
@@ -114,12 +122,16 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 	// implementations can opt to interface
 	if v, ok := rw.resource.(ResourceWithUpdate); ok {
 		resource.UpdateContext = rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+			ctx = rw.withLockOwner(ctx, "Update", d)
 			metaData := runArgs(d, meta, rw.logger)
 
 			err := v.Update().Func(ctx, metaData)
 			if err != nil {
 				return err
 			}
+			if err := rw.waitForConsistency(ctx, "Update", metaData); err != nil {
+				return err
+			}
 			// whilst this may look like we should use the Update timeout here
 			// we're still "technically" in the update method, so reusing the
 			// Update's timeout should be fine
@@ -160,6 +172,12 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			return nil, fmt.Errorf("Resource %q must return a non-empty DeprecatedInFavourOfResource if implementing ResourceWithDeprecationReplacedBy", rw.resource.ResourceType())
 		}
 
+		if m, ok := rw.resource.(ResourceWithReplacementMigration); ok {
+			if err := m.ReplacementMigration().Validate(replacementResourceType); err != nil {
+				return nil, fmt.Errorf("Resource %q has an invalid ReplacementMigration: %+v", rw.resource.ResourceType(), err)
+			}
+		}
+
 		resource.DeprecationMessage = fmt.Sprintf(`The %[1]q resource has been deprecated and replaced by the %[2]q resource.
 
 The existing %[1]q resource will remain available until the next
@@ -173,11 +191,54 @@ and we recommend using the %[2]q resource instead.
 		resource.SchemaVersion = stateUpgradeData.SchemaVersion
 		resource.StateUpgraders = pluginsdk.StateUpgrades(stateUpgradeData.Upgraders)
 	}
-	// TODO: State Migrations
+	if v, ok := rw.resource.(ResourceWithConsistencyCheck); ok {
+		if err := v.ConsistencyCheck().Validate(); err != nil {
+			return nil, fmt.Errorf("Resource %q has an invalid ConsistencyCheck: %+v", rw.resource.ResourceType(), err)
+		}
+	}
+	if v, ok := rw.resource.(ResourceWithIdentity); ok {
+		if _, ok := rw.resource.(ResourceWithStateMigration); ok {
+			return nil, fmt.Errorf("Resource %q can implement either ResourceWithIdentity or ResourceWithStateMigration but not both", rw.resource.ResourceType())
+		}
+
+		identity := v.Identity()
+		if err := identity.Validate(); err != nil {
+			return nil, fmt.Errorf("Resource %q has an invalid Identity: %+v", rw.resource.ResourceType(), err)
+		}
+
+		stateUpgradeData := identity.StateUpgraders(*resourceSchema)
+		resource.SchemaVersion = stateUpgradeData.SchemaVersion
+		resource.StateUpgraders = pluginsdk.StateUpgrades(stateUpgradeData.Upgraders)
+	}
 
 	return &resource, nil
 }
 
+// waitForConsistency polls the Resource until it's consistent, when the Resource implements ResourceWithConsistencyCheck
+func (rw *ResourceWrapper) waitForConsistency(ctx context.Context, operation string, metaData ResourceMetaData) error {
+	v, ok := rw.resource.(ResourceWithConsistencyCheck)
+	if !ok {
+		return nil
+	}
+
+	check := v.ConsistencyCheck()
+	if operation == "Update" && check.SkipAfterUpdate {
+		return nil
+	}
+
+	return waitForConsistency(ctx, check, operation, metaData)
+}
+
+// withLockOwner identifies this Resource as the owner of any locks acquired using the returned context, which
+// is reported when a lock is held for longer than expected
+func (rw *ResourceWrapper) withLockOwner(ctx context.Context, operation string, d *schema.ResourceData) context.Context {
+	owner := fmt.Sprintf("%s (%s)", rw.resource.ResourceType(), operation)
+	if id := d.Id(); id != "" {
+		owner = fmt.Sprintf("%s (%s %q)", rw.resource.ResourceType(), operation, id)
+	}
+	return locks.WithOwner(ctx, owner)
+}
+
 func (rw *ResourceWrapper) diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return diagnosticsWrapper(in, rw.logger)
 }
@@ -186,9 +247,15 @@ func diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, met
 	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 		out := make([]diag.Diagnostic, 0)
 		if err := in(ctx, d, meta); err != nil {
+			summary := err.Error()
+			if common.IsReadOnlyModeError(err) {
+				// the full error (including the blocked request) remains available in the Detail
+				summary = "This operation would modify a resource, which isn't possible since the Provider is running in read-only mode (`read_only` is enabled)"
+			}
+
 			out = append(out, diag.Diagnostic{
 				Severity:      diag.Error,
-				Summary:       err.Error(),
+				Summary:       summary,
 				Detail:        err.Error(),
 				AttributePath: nil,
 			})
@@ -59,6 +59,16 @@ func validateModelObjectRecursively(prefix string, objType reflect.Type, objVal
 			}
 		}
 
+		// Optional (and pointer) fields can contain a list of nested objects, which also need to be validated
+		if isOptionalType(field.Type) && optionalInnerType(field.Type).Kind() == reflect.Slice {
+			innerType := optionalInnerType(field.Type).Elem()
+			innerVal := reflect.Indirect(reflect.New(innerType))
+			fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
+			if err := validateModelObjectRecursively(fieldName, innerType, innerVal); err != nil {
+				return err
+			}
+		}
+
 		if _, exists := field.Tag.Lookup("tfschema"); !exists {
 			fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
 			return fmt.Errorf("field %q is missing an `tfschema` label", fieldName)
@@ -69,3 +69,33 @@ func TestValidateNestedObjectInvalid(t *testing.T) {
 		t.Fatalf("expected an error but didn't get one")
 	}
 }
+
+func TestValidateOptionalNestedObjectValid(t *testing.T) {
+	type Pet struct {
+		Name string        `tfschema:"name"`
+		Age  Optional[int] `tfschema:"age"`
+		Fed  *bool         `tfschema:"fed"`
+	}
+	type Person struct {
+		Name  string             `tfschema:"name"`
+		Pets  Optional[[]Pet]    `tfschema:"pets"`
+		Zones Optional[[]string] `tfschema:"zones"`
+	}
+	if err := ValidateModelObject(&Person{}); err != nil {
+		t.Fatalf("error: %+v", err)
+	}
+}
+
+func TestValidateOptionalNestedObjectInvalid(t *testing.T) {
+	type Pet struct {
+		Name string `tfschema:"name"`
+		Age  int
+	}
+	type Person struct {
+		Name string          `tfschema:"name"`
+		Pets Optional[[]Pet] `tfschema:"pets"`
+	}
+	if err := ValidateModelObject(&Person{}); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
@@ -257,7 +257,9 @@ func (r FunctionAppFunctionResource) Create() sdk.ResourceFunc {
 				return fmt.Errorf("waiting for %s to be ready", *appId)
 			}
 
-			locks.ByID(appId.ID())
+			if err := locks.ByID(ctx, appId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(appId.ID())
 
 			future, err := client.CreateFunction(ctx, id.ResourceGroup, id.SiteName, id.FunctionName, fnEnvelope)
@@ -381,7 +383,9 @@ func (r FunctionAppFunctionResource) Delete() sdk.ResourceFunc {
 			}
 
 			fnID := parse.NewFunctionAppID(id.SubscriptionId, id.ResourceGroup, id.FunctionName).ID()
-			locks.ByID(fnID)
+			if err := locks.ByID(ctx, fnID); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(fnID)
 
 			if _, err = client.DeleteFunction(ctx, id.ResourceGroup, id.SiteName, id.FunctionName); err != nil {
@@ -459,7 +463,9 @@ func (r FunctionAppFunctionResource) Update() sdk.ResourceFunc {
 			}
 
 			fnID := parse.NewFunctionAppID(id.SubscriptionId, id.ResourceGroup, id.FunctionName).ID()
-			locks.ByID(fnID)
+			if err := locks.ByID(ctx, fnID); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(fnID)
 
 			future, err := client.CreateFunction(ctx, id.ResourceGroup, id.SiteName, id.FunctionName, existing)
@@ -816,9 +816,13 @@ func (r LinuxFunctionAppSlotResource) Update() sdk.ResourceFunc {
 				if err != nil {
 					return err
 				}
-				locks.ByID(oldPlan.ID())
+				if err := locks.ByID(ctx, oldPlan.ID()); err != nil {
+					return err
+				}
 				defer locks.UnlockByID(oldPlan.ID())
-				locks.ByID(newPlan.ID())
+				if err := locks.ByID(ctx, newPlan.ID()); err != nil {
+					return err
+				}
 				defer locks.UnlockByID(newPlan.ID())
 				if existing.SiteProperties == nil {
 					return fmt.Errorf("updating Service Plan for Linux %s: Slot SiteProperties was nil", *id)
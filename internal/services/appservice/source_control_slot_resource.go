@@ -157,7 +157,9 @@ func (r SourceControlSlotResource) Create() sdk.ResourceFunc {
 			}
 
 			appId := parse.NewWebAppID(id.SubscriptionId, id.ResourceGroup, id.SiteName).ID()
-			locks.ByID(appId)
+			if err := locks.ByID(ctx, appId); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(appId)
 
 			existing, err := client.GetConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
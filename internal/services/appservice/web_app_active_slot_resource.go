@@ -98,7 +98,9 @@ func (r WebAppActiveSlotResource) Create() sdk.ResourceFunc {
 				PreserveVnet: &activeSlot.OverwriteNetworking,
 			}
 
-			locks.ByID(appId.ID())
+			if err := locks.ByID(ctx, appId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(appId.ID())
 
 			future, err := client.SwapSlotWithProduction(ctx, id.ResourceGroup, id.SiteName, csmSlotEntity)
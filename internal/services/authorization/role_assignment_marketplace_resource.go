@@ -11,6 +11,7 @@ const (
 )
 
 var _ sdk.Resource = RoleAssignmentMarketplaceResource{}
+var _ sdk.ResourceWithConsistencyCheck = RoleAssignmentMarketplaceResource{}
 
 type RoleAssignmentMarketplaceResource struct {
 	base roleAssignmentBaseResource
@@ -28,6 +29,10 @@ func (r RoleAssignmentMarketplaceResource) Create() sdk.ResourceFunc {
 	return r.base.createFunc(r.ResourceType(), MarketplaceScope)
 }
 
+func (r RoleAssignmentMarketplaceResource) ConsistencyCheck() sdk.ConsistencyCheck {
+	return r.base.consistencyCheck()
+}
+
 func (r RoleAssignmentMarketplaceResource) Delete() sdk.ResourceFunc {
 	return r.base.deleteFunc()
 }
@@ -338,46 +338,37 @@ func (br roleAssignmentBaseResource) retryRoleAssignmentsClient(ctx context.Cont
 			return pluginsdk.NonRetryableError(fmt.Errorf("creation of Role Assignment %s did not return an id value", id))
 		}
 
-		deadline, ok := ctx.Deadline()
-		if !ok {
-			return pluginsdk.NonRetryableError(fmt.Errorf("could not retrieve context deadline for %s", metadata.ResourceData.Id()))
-		}
-
-		stateConf := &pluginsdk.StateChangeConf{
-			Pending: []string{
-				"pending",
-			},
-			Target: []string{
-				"ready",
-			},
-			Refresh:                   br.roleAssignmentCreateStateRefreshFunc(ctx, roleAssignmentsClient, id),
-			MinTimeout:                5 * time.Second,
-			ContinuousTargetOccurence: 5,
-			Timeout:                   time.Until(deadline),
-		}
-
-		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
-			return pluginsdk.NonRetryableError(fmt.Errorf("failed waiting for Role Assignment %s to finish replicating: %+v", id, err))
-		}
-
 		return nil
 	}
 }
 
-func (br roleAssignmentBaseResource) roleAssignmentCreateStateRefreshFunc(ctx context.Context, client *roleassignments.RoleAssignmentsClient, id parse.ScopedRoleAssignmentId) pluginsdk.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		options := roleassignments.DefaultGetByIdOperationOptions()
-		if id.TenantId != "" {
-			options.TenantId = &id.TenantId
-		}
+// consistencyCheck waits for the Role Assignment to finish replicating, since it can take some time to be
+// returned from the API once it's been created
+func (br roleAssignmentBaseResource) consistencyCheck() sdk.ConsistencyCheck {
+	return sdk.ConsistencyCheck{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) (bool, error) {
+			client := metadata.Client.Authorization.ScopedRoleAssignmentsClient
+			id, err := parse.ScopedRoleAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return false, err
+			}
 
-		resp, err := client.GetById(ctx, commonids.NewScopeID(id.ScopedId.ID()), options)
-		if err != nil {
-			if response.WasNotFound(resp.HttpResponse) {
-				return resp, "pending", nil
+			options := roleassignments.DefaultGetByIdOperationOptions()
+			if id.TenantId != "" {
+				options.TenantId = &id.TenantId
 			}
-			return resp, "failed", err
-		}
-		return resp, "ready", nil
+
+			resp, err := client.GetById(ctx, commonids.NewScopeID(id.ScopedId.ID()), options)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return false, nil
+				}
+				return false, fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			return true, nil
+		},
+		ContinuousTargetOccurence: 5,
+		PollInterval:              5 * time.Second,
 	}
 }
@@ -4,6 +4,7 @@
 package cdn
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -83,7 +84,7 @@ func resourceCdnFrontDoorCustomDomainAssociationCreate(d *pluginsdk.ResourceData
 	}
 
 	// make sure the routes exist and are valid for this custom domain...
-	routes, err := validateRoutes(d, meta, cdId)
+	routes, err := validateRoutes(ctx, d, meta, cdId)
 	if err != nil {
 		return fmt.Errorf("creating %s: %+v", id, err)
 	}
@@ -141,7 +142,7 @@ func resourceCdnFrontDoorCustomDomainAssociationUpdate(d *pluginsdk.ResourceData
 		}
 
 		// make sure the routes exist and are valid for this custom domain...
-		routes, err := validateRoutes(d, meta, cdId)
+		routes, err := validateRoutes(ctx, d, meta, cdId)
 		if err != nil {
 			return fmt.Errorf("updating %s: %+v", id, err)
 		}
@@ -153,6 +154,9 @@ func resourceCdnFrontDoorCustomDomainAssociationUpdate(d *pluginsdk.ResourceData
 }
 
 func resourceCdnFrontDoorCustomDomainAssociationDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
 	// since you are deleting the resource you cannot grab the value from the config
 	// because it will be empty, you have to get it from the states old value...
 	oCdId, _ := d.GetChange("cdn_frontdoor_custom_domain_id")
@@ -176,7 +180,7 @@ func resourceCdnFrontDoorCustomDomainAssociationDelete(d *pluginsdk.ResourceData
 	}
 
 	if len(*v) != 0 {
-		if err := removeCustomDomainAssociationFromRoutes(d, meta, v, cdId); err != nil {
+		if err := removeCustomDomainAssociationFromRoutes(ctx, d, meta, v, cdId); err != nil {
 			return fmt.Errorf("deleting %s: %+v", id, err)
 		}
 	}
@@ -186,7 +190,7 @@ func resourceCdnFrontDoorCustomDomainAssociationDelete(d *pluginsdk.ResourceData
 	return nil
 }
 
-func validateRoutes(d *pluginsdk.ResourceData, meta interface{}, id *parse.FrontDoorCustomDomainId) ([]interface{}, error) {
+func validateRoutes(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, id *parse.FrontDoorCustomDomainId) ([]interface{}, error) {
 	out := make([]interface{}, 0)
 	o, n := d.GetChange("cdn_frontdoor_route_ids")
 	oRoutes := o.([]interface{})
@@ -230,7 +234,7 @@ func validateRoutes(d *pluginsdk.ResourceData, meta interface{}, id *parse.Front
 		// now get the delta between the old and the new list, if any custom domains were removed from
 		// the list we need to remove the custom domain association from those routes...
 		if delta, _ := routeDelta(oIds, nIds); len(*delta) != 0 {
-			if err = removeCustomDomainAssociationFromRoutes(d, meta, delta, id); err != nil {
+			if err = removeCustomDomainAssociationFromRoutes(ctx, d, meta, delta, id); err != nil {
 				return out, err
 			}
 		}
@@ -4,6 +4,7 @@
 package cdn
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -375,11 +376,13 @@ func getRouteProperties(d *pluginsdk.ResourceData, meta interface{}, id *parse.F
 	return customDomains, props, nil
 }
 
-func removeCustomDomainAssociationFromRoutes(d *pluginsdk.ResourceData, meta interface{}, routes *[]parse.FrontDoorRouteId, customDomainID *parse.FrontDoorCustomDomainId) error {
+func removeCustomDomainAssociationFromRoutes(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, routes *[]parse.FrontDoorRouteId, customDomainID *parse.FrontDoorCustomDomainId) error {
 	if len(*routes) != 0 && routes != nil {
 		for _, route := range *routes {
 			// lock the route resource for update...
-			locks.ByName(route.RouteName, cdnFrontDoorRouteResourceName)
+			if err := locks.ByName(ctx, route.RouteName, cdnFrontDoorRouteResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(route.RouteName, cdnFrontDoorRouteResourceName)
 
 			// Check to see if the route still exists and grab its properties...
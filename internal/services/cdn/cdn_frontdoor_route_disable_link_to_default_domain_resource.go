@@ -87,7 +87,9 @@ func resourceCdnFrontDoorRouteDisableLinkToDefaultDomainCreate(d *pluginsdk.Reso
 
 	id := parse.NewFrontDoorRouteDisableLinkToDefaultDomainID(routeId.SubscriptionId, routeId.ResourceGroup, routeId.ProfileName, routeId.AfdEndpointName, routeId.RouteName, uuid)
 
-	locks.ByName(routeId.RouteName, cdnFrontDoorRouteResourceName)
+	if err := locks.ByName(routeCtx, routeId.RouteName, cdnFrontDoorRouteResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(routeId.RouteName, cdnFrontDoorRouteResourceName)
 
 	for _, v := range customDomains {
@@ -96,7 +98,9 @@ func resourceCdnFrontDoorRouteDisableLinkToDefaultDomainCreate(d *pluginsdk.Reso
 			return fmt.Errorf("creating %s: %+v", id, err)
 		}
 
-		locks.ByName(customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName)
+		if err := locks.ByName(routeCtx, customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName)
 	}
 
@@ -229,7 +233,9 @@ func resourceCdnFrontDoorRouteDisableLinkToDefaultDomainUpdate(d *pluginsdk.Reso
 			return err
 		}
 
-		locks.ByName(routeId.RouteName, cdnFrontDoorRouteResourceName)
+		if err := locks.ByName(routeCtx, routeId.RouteName, cdnFrontDoorRouteResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(routeId.RouteName, cdnFrontDoorRouteResourceName)
 
 		for _, v := range customDomains {
@@ -238,7 +244,9 @@ func resourceCdnFrontDoorRouteDisableLinkToDefaultDomainUpdate(d *pluginsdk.Reso
 				return fmt.Errorf("updating %s: %+v", id, err)
 			}
 
-			locks.ByName(customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName)
+			if err := locks.ByName(routeCtx, customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName)
 		}
 
@@ -325,7 +333,9 @@ func resourceCdnFrontDoorRouteDisableLinkToDefaultDomainDelete(d *pluginsdk.Reso
 		return err
 	}
 
-	locks.ByName(route.RouteName, cdnFrontDoorRouteResourceName)
+	if err := locks.ByName(ctx, route.RouteName, cdnFrontDoorRouteResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(route.RouteName, cdnFrontDoorRouteResourceName)
 
 	resp, err := client.Get(ctx, route.ResourceGroup, route.ProfileName, route.AfdEndpointName, route.RouteName)
@@ -71,7 +71,9 @@ func resourceCognitiveAccountCustomerManagedKeyCreateUpdate(d *pluginsdk.Resourc
 		return err
 	}
 
-	locks.ByName(id.AccountName, "azurerm_cognitive_account")
+	if err := locks.ByName(ctx, id.AccountName, "azurerm_cognitive_account"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AccountName, "azurerm_cognitive_account")
 
 	resp, err := client.AccountsGet(ctx, *id)
@@ -174,7 +176,9 @@ func resourceCognitiveAccountCustomerManagedKeyDelete(d *pluginsdk.ResourceData,
 		return err
 	}
 
-	locks.ByName(id.AccountName, "azurerm_cognitive_account")
+	if err := locks.ByName(ctx, id.AccountName, "azurerm_cognitive_account"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AccountName, "azurerm_cognitive_account")
 
 	resp, err := client.AccountsGet(ctx, *id)
@@ -222,7 +222,9 @@ func (r CognitiveDeploymentResource) Create() sdk.ResourceFunc {
 			client := metadata.Client.Cognitive.DeploymentsClient
 			accountId, err := cognitiveservicesaccounts.ParseAccountID(model.CognitiveAccountId)
 
-			locks.ByID(accountId.ID())
+			if err := locks.ByID(ctx, accountId.ID()); err != nil {
+				return err
+			}
 
 			if err != nil {
 				return err
@@ -406,7 +406,9 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 
 	id := parse.NewVirtualMachineID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.Name, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, VirtualMachineResourceName)
 
 	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -1011,7 +1013,9 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		return err
 	}
 
-	locks.ByName(id.Name, VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.Name, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, VirtualMachineResourceName)
 
 	log.Printf("[DEBUG] Retrieving Linux Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
@@ -1584,7 +1588,9 @@ func resourceLinuxVirtualMachineDelete(d *pluginsdk.ResourceData, meta interface
 		return err
 	}
 
-	locks.ByName(id.Name, VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.Name, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, VirtualMachineResourceName)
 
 	log.Printf("[DEBUG] Retrieving Linux Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
@@ -815,7 +815,9 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		// check instanceView State
 		vmClient := meta.(*clients.Client).Compute.VMClient
 
-		locks.ByName(name, VirtualMachineResourceName)
+		if err := locks.ByName(ctx, name, VirtualMachineResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(name, VirtualMachineResourceName)
 
 		vm, err := vmClient.Get(ctx, virtualMachine.ResourceGroup, virtualMachine.Name, "")
@@ -433,7 +433,9 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 
 	id := parse.NewVirtualMachineID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.Name, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, VirtualMachineResourceName)
 
 	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, compute.InstanceViewTypesUserData)
@@ -1052,7 +1054,9 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		return err
 	}
 
-	locks.ByName(id.Name, VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.Name, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, VirtualMachineResourceName)
 
 	log.Printf("[DEBUG] Retrieving Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
@@ -1645,7 +1649,9 @@ func resourceWindowsVirtualMachineDelete(d *pluginsdk.ResourceData, meta interfa
 		return err
 	}
 
-	locks.ByName(id.Name, VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.Name, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, VirtualMachineResourceName)
 
 	log.Printf("[DEBUG] Retrieving Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
@@ -0,0 +1,331 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containerapps
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerapps/2022-03-01/containerapps"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerapps/2022-03-01/managedenvironments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps/helpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type ContainerAppCustomDomainResource struct{}
+
+type ContainerAppCustomDomainModel struct {
+	Name                   string `tfschema:"name"`
+	ContainerAppId         string `tfschema:"container_app_id"`
+	CertificateBindingType string `tfschema:"certificate_binding_type"`
+	CertificateId          string `tfschema:"certificate_id"`
+}
+
+var _ sdk.ResourceWithUpdate = ContainerAppCustomDomainResource{}
+
+func (r ContainerAppCustomDomainResource) ModelObject() interface{} {
+	return &ContainerAppCustomDomainModel{}
+}
+
+func (r ContainerAppCustomDomainResource) ResourceType() string {
+	return "azurerm_container_app_custom_domain"
+}
+
+func (r ContainerAppCustomDomainResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ContainerAppCustomDomainID
+}
+
+func (r ContainerAppCustomDomainResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The hostname of the Custom Domain. Must be the CN or a named SAN in the certificate.",
+		},
+
+		"container_app_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: containerapps.ValidateContainerAppID,
+			Description:  "The ID of the Container App to which this Custom Domain should be bound.",
+		},
+
+		"certificate_binding_type": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			RequiredWith: []string{"certificate_id"},
+			ValidateFunc: validation.StringInSlice(containerapps.PossibleValuesForBindingType(), false),
+			Description:  "The Binding type. Possible values include `Disabled` and `SniEnabled`.",
+		},
+
+		"certificate_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			RequiredWith: []string{"certificate_binding_type"},
+			ValidateFunc: managedenvironments.ValidateCertificateID,
+			Description:  "The ID of the Container App Environment Certificate to use for this Custom Domain.",
+		},
+	}
+}
+
+func (r ContainerAppCustomDomainResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r ContainerAppCustomDomainResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerApps.ContainerAppClient
+
+			var model ContainerAppCustomDomainModel
+			if err := metadata.Decode(&model); err != nil {
+				return err
+			}
+
+			appId, err := containerapps.ParseContainerAppID(model.ContainerAppId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewContainerAppCustomDomainID(appId.SubscriptionId, appId.ResourceGroupName, appId.ContainerAppName, model.Name)
+
+			if err := locks.ByID(ctx, appId.ID()); err != nil {
+				return err
+			}
+			defer locks.UnlockByID(appId.ID())
+
+			app, err := containerAppForCustomDomain(ctx, client, *appId)
+			if err != nil {
+				return err
+			}
+
+			ingress := app.Properties.Configuration.Ingress
+			if ingress == nil {
+				return fmt.Errorf("adding %s: `ingress` must be configured on %s before a Custom Domain can be bound", id, *appId)
+			}
+
+			domains := pointer.From(ingress.CustomDomains)
+			if findContainerAppCustomDomain(domains, id.CustomDomainName) != nil {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			domains = append(domains, expandContainerAppCustomDomain(model))
+			ingress.CustomDomains = pointer.To(domains)
+
+			if err := client.CreateOrUpdateThenPoll(ctx, *appId, *app); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			return nil
+		},
+	}
+}
+
+func (r ContainerAppCustomDomainResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerApps.ContainerAppClient
+
+			id, err := parse.ContainerAppCustomDomainID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			appId := containerapps.NewContainerAppID(id.SubscriptionId, id.ResourceGroup, id.ContainerAppName)
+
+			existing, err := client.Get(ctx, appId)
+			if err != nil {
+				if response.WasNotFound(existing.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", appId, err)
+			}
+
+			var domain *containerapps.CustomDomain
+			if model := existing.Model; model != nil && model.Properties != nil && model.Properties.Configuration != nil && model.Properties.Configuration.Ingress != nil {
+				domain = findContainerAppCustomDomain(pointer.From(model.Properties.Configuration.Ingress.CustomDomains), id.CustomDomainName)
+			}
+			if domain == nil {
+				return metadata.MarkAsGone(id)
+			}
+
+			state := ContainerAppCustomDomainModel{
+				Name:           domain.Name,
+				ContainerAppId: appId.ID(),
+			}
+
+			if domain.CertificateId != nil {
+				state.CertificateId = *domain.CertificateId
+				if domain.BindingType != nil {
+					state.CertificateBindingType = string(*domain.BindingType)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ContainerAppCustomDomainResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerApps.ContainerAppClient
+
+			id, err := parse.ContainerAppCustomDomainID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model ContainerAppCustomDomainModel
+			if err := metadata.Decode(&model); err != nil {
+				return err
+			}
+
+			appId := containerapps.NewContainerAppID(id.SubscriptionId, id.ResourceGroup, id.ContainerAppName)
+
+			if err := locks.ByID(ctx, appId.ID()); err != nil {
+				return err
+			}
+			defer locks.UnlockByID(appId.ID())
+
+			app, err := containerAppForCustomDomain(ctx, client, appId)
+			if err != nil {
+				return err
+			}
+
+			var domain *containerapps.CustomDomain
+			if ingress := app.Properties.Configuration.Ingress; ingress != nil {
+				domain = findContainerAppCustomDomain(pointer.From(ingress.CustomDomains), id.CustomDomainName)
+			}
+			if domain == nil {
+				return fmt.Errorf("updating %s: the Custom Domain was not found on %s", *id, appId)
+			}
+
+			updated := expandContainerAppCustomDomain(model)
+			domain.BindingType = updated.BindingType
+			domain.CertificateId = updated.CertificateId
+
+			if err := client.CreateOrUpdateThenPoll(ctx, appId, *app); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ContainerAppCustomDomainResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerApps.ContainerAppClient
+
+			id, err := parse.ContainerAppCustomDomainID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			appId := containerapps.NewContainerAppID(id.SubscriptionId, id.ResourceGroup, id.ContainerAppName)
+
+			if err := locks.ByID(ctx, appId.ID()); err != nil {
+				return err
+			}
+			defer locks.UnlockByID(appId.ID())
+
+			app, err := containerAppForCustomDomain(ctx, client, appId)
+			if err != nil {
+				return err
+			}
+
+			ingress := app.Properties.Configuration.Ingress
+			if ingress == nil || ingress.CustomDomains == nil {
+				return nil
+			}
+
+			domains := make([]containerapps.CustomDomain, 0)
+			for _, v := range *ingress.CustomDomains {
+				if !strings.EqualFold(v.Name, id.CustomDomainName) {
+					domains = append(domains, v)
+				}
+			}
+			ingress.CustomDomains = pointer.To(domains)
+
+			if err := client.CreateOrUpdateThenPoll(ctx, appId, *app); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// containerAppForCustomDomain retrieves the Container App with its secrets populated, so that the
+// full model can be sent back to the API when binding or unbinding a Custom Domain
+func containerAppForCustomDomain(ctx context.Context, client *containerapps.ContainerAppsClient, id containerapps.ContainerAppId) (*containerapps.ContainerApp, error) {
+	existing, err := client.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	if existing.Model == nil || existing.Model.Properties == nil {
+		return nil, fmt.Errorf("retrieving %s: `properties` was nil", id)
+	}
+	app := existing.Model
+
+	if app.Properties.Configuration == nil {
+		app.Properties.Configuration = &containerapps.Configuration{}
+	}
+
+	secretsResp, err := client.ListSecrets(ctx, id)
+	if err != nil || secretsResp.Model == nil {
+		if !response.WasStatusCode(secretsResp.HttpResponse, http.StatusNoContent) {
+			return nil, fmt.Errorf("retrieving secrets for %s: %+v", id, err)
+		}
+	}
+	app.Properties.Configuration.Secrets = helpers.UnpackContainerSecretsCollection(secretsResp.Model)
+
+	return app, nil
+}
+
+func findContainerAppCustomDomain(input []containerapps.CustomDomain, name string) *containerapps.CustomDomain {
+	for i := range input {
+		if strings.EqualFold(input[i].Name, name) {
+			return &input[i]
+		}
+	}
+
+	return nil
+}
+
+func expandContainerAppCustomDomain(input ContainerAppCustomDomainModel) containerapps.CustomDomain {
+	output := containerapps.CustomDomain{
+		Name:        input.Name,
+		BindingType: pointer.To(containerapps.BindingTypeDisabled),
+	}
+
+	if input.CertificateId != "" {
+		output.CertificateId = pointer.To(input.CertificateId)
+		output.BindingType = pointer.To(containerapps.BindingType(input.CertificateBindingType))
+	}
+
+	return output
+}
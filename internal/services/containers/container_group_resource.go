@@ -757,7 +757,9 @@ func resourceContainerGroupCreate(d *pluginsdk.ResourceData, meta interface{}) e
 				return fmt.Errorf(`parsing subnet id %q: %v`, item.Id, err)
 			}
 
-			locks.ByID(subnet.ID())
+			if err := locks.ByID(ctx, subnet.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(subnet.ID())
 		}
 	}
@@ -969,7 +971,9 @@ func resourceContainerGroupDelete(d *pluginsdk.ResourceData, meta interface{}) e
 					return fmt.Errorf(`parsing subnet id %q: %v`, item.Id, err)
 				}
 
-				locks.ByID(subnet.ID())
+				if err := locks.ByID(ctx, subnet.ID()); err != nil {
+					return err
+				}
 				defer locks.UnlockByID(subnet.ID())
 			}
 		}
@@ -146,7 +146,9 @@ func (r ContainerRegistryTokenPasswordResource) Create() sdk.ResourceFunc {
 				return fmt.Errorf("expanding `password`: %v", err)
 			}
 
-			locks.ByID(tokenId.ID())
+			if err := locks.ByID(ctx, tokenId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(tokenId.ID())
 
 			genPasswords, err := r.generatePassword(ctx, *metadata.Client.Containers, *tokenId, *passwords)
@@ -241,7 +243,9 @@ func (r ContainerRegistryTokenPasswordResource) Delete() sdk.ResourceFunc {
 
 			tokenId := tokens.NewTokenID(id.SubscriptionId, id.ResourceGroup, id.RegistryName, id.TokenName)
 
-			locks.ByID(tokenId.ID())
+			if err := locks.ByID(ctx, tokenId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(tokenId.ID())
 
 			param := tokens.TokenUpdateParameters{
@@ -282,7 +286,9 @@ func (r ContainerRegistryTokenPasswordResource) Update() sdk.ResourceFunc {
 				return fmt.Errorf("expanding `password`: %v", err)
 			}
 
-			locks.ByID(tokenId.ID())
+			if err := locks.ByID(ctx, tokenId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(tokenId.ID())
 
 			genPasswords, err := r.generatePassword(ctx, *metadata.Client.Containers, tokenId, *passwords)
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/fleets"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type KubernetesFleetManagerDataSourceModel struct {
+	Name              string                                  `tfschema:"name"`
+	ResourceGroupName string                                  `tfschema:"resource_group_name"`
+	Location          string                                  `tfschema:"location"`
+	HubProfile        []KubernetesFleetManagerHubProfileModel `tfschema:"hub_profile"`
+	Tags              map[string]string                       `tfschema:"tags"`
+}
+
+type KubernetesFleetManagerHubProfileModel struct {
+	DnsPrefix         string `tfschema:"dns_prefix"`
+	Fqdn              string `tfschema:"fqdn"`
+	KubernetesVersion string `tfschema:"kubernetes_version"`
+}
+
+type KubernetesFleetManagerDataSource struct{}
+
+var _ sdk.DataSource = KubernetesFleetManagerDataSource{}
+
+func (r KubernetesFleetManagerDataSource) ResourceType() string {
+	return "azurerm_kubernetes_fleet_manager"
+}
+
+func (r KubernetesFleetManagerDataSource) ModelObject() interface{} {
+	return &KubernetesFleetManagerDataSourceModel{}
+}
+
+func (r KubernetesFleetManagerDataSource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return fleets.ValidateFleetID
+}
+
+func (r KubernetesFleetManagerDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+	}
+}
+
+func (r KubernetesFleetManagerDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"location": commonschema.LocationComputed(),
+
+		"hub_profile": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"dns_prefix": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"fqdn": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"kubernetes_version": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+
+		"tags": commonschema.TagsDataSource(),
+	}
+}
+
+func (r KubernetesFleetManagerDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerService.V20220902Preview.Fleets
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var state KubernetesFleetManagerDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := fleets.NewFleetID(subscriptionId, state.ResourceGroupName, state.Name)
+
+			resp, err := client.Get(ctx, id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return fmt.Errorf("%s was not found", id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			if model := resp.Model; model != nil {
+				state.Location = location.Normalize(model.Location)
+				state.Tags = pointer.From(model.Tags)
+
+				if props := model.Properties; props != nil && props.HubProfile != nil {
+					state.HubProfile = []KubernetesFleetManagerHubProfileModel{
+						{
+							DnsPrefix:         props.HubProfile.DnsPrefix,
+							Fqdn:              pointer.From(props.HubProfile.Fqdn),
+							KubernetesVersion: pointer.From(props.HubProfile.KubernetesVersion),
+						},
+					}
+				}
+			}
+
+			metadata.SetID(id)
+
+			return metadata.Encode(&state)
+		},
+	}
+}
@@ -60,6 +60,7 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 
 func (r Registration) DataSources() []sdk.DataSource {
 	dataSources := []sdk.DataSource{
+		KubernetesFleetManagerDataSource{},
 		KubernetesNodePoolSnapshotDataSource{},
 	}
 	dataSources = append(dataSources, r.autoRegistration.DataSources()...)
@@ -73,6 +74,7 @@ func (r Registration) Resources() []sdk.Resource {
 		ContainerRegistryTokenPasswordResource{},
 		ContainerConnectedRegistryResource{},
 		KubernetesClusterExtensionResource{},
+		KubernetesFleetMemberResource{},
 		KubernetesFluxConfigurationResource{},
 	}
 	resources = append(resources, r.autoRegistration.Resources()...)
@@ -0,0 +1,16 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// KubernetesFleetChildName validates the name of a resource within a Kubernetes Fleet, such as a Member
+func KubernetesFleetChildName(v interface{}, k string) (warnings []string, errors []error) {
+	return validation.StringMatch(regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,48}[a-z0-9])?$`), fmt.Sprintf("%q must be between 1 and 50 characters, contain only lowercase letters, numbers and hyphens and start and end with a lowercase letter or number", k))(v, k)
+}
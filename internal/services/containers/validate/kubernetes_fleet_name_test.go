@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+)
+
+func TestKubernetesFleetChildName(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "a",
+			ErrCount: 0,
+		},
+		{
+			Value:    "stage-1",
+			ErrCount: 0,
+		},
+		{
+			Value:    "-stage",
+			ErrCount: 1,
+		},
+		{
+			Value:    "stage-",
+			ErrCount: 1,
+		},
+		{
+			Value:    "Stage",
+			ErrCount: 1,
+		},
+		{
+			Value:    "stage_1",
+			ErrCount: 1,
+		},
+		{
+			Value:    "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwx",
+			ErrCount: 0,
+		},
+		{
+			Value:    "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxy",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validate.KubernetesFleetChildName(tc.Value, "name")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %q to trigger %d validation errors but got: %v", tc.Value, tc.ErrCount, errors)
+		}
+	}
+}
@@ -139,7 +139,9 @@ func (r CosmosDbMongoRoleDefinitionResource) Create() sdk.ResourceFunc {
 			mongoRoleDefinitionId := fmt.Sprintf("%s.%s", databaseId.Name, model.RoleName)
 			id := mongorbacs.NewMongodbRoleDefinitionID(databaseId.SubscriptionId, databaseId.ResourceGroup, databaseId.DatabaseAccountName, mongoRoleDefinitionId)
 
-			locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+			if err := locks.ByName(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 			existing, err := client.MongoDBResourcesGetMongoRoleDefinition(ctx, id)
@@ -183,7 +185,9 @@ func (r CosmosDbMongoRoleDefinitionResource) Update() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+			if err := locks.ByName(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 			var model CosmosDbMongoRoleDefinitionResourceModel
@@ -275,7 +279,9 @@ func (r CosmosDbMongoRoleDefinitionResource) Delete() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+			if err := locks.ByName(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 			if err := client.MongoDBResourcesDeleteMongoRoleDefinitionThenPoll(ctx, *id); err != nil {
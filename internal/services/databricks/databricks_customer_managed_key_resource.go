@@ -94,7 +94,9 @@ func databricksWorkspaceCustomerManagedKeyCreateUpdate(d *pluginsdk.ResourceData
 
 	// Not sure if I should also lock the key vault here too
 	// or at the very least the key?
-	locks.ByName(id.WorkspaceName, "azurerm_databricks_workspace")
+	if err := locks.ByName(ctx, id.WorkspaceName, "azurerm_databricks_workspace"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.WorkspaceName, "azurerm_databricks_workspace")
 	var encryptionEnabled bool
 
@@ -227,7 +229,9 @@ func databricksWorkspaceCustomerManagedKeyDelete(d *pluginsdk.ResourceData, meta
 	}
 
 	// Not sure if I should also lock the key vault here too
-	locks.ByName(id.WorkspaceName, "azurerm_databricks_workspace")
+	if err := locks.ByName(ctx, id.WorkspaceName, "azurerm_databricks_workspace"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.WorkspaceName, "azurerm_databricks_workspace")
 
 	workspace, err := client.Get(ctx, *id)
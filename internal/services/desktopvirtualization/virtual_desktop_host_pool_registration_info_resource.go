@@ -85,7 +85,9 @@ func resourceVirtualDesktopHostPoolRegistrationInfoCreateUpdate(d *pluginsdk.Res
 		return err
 	}
 
-	locks.ByName(hostPoolId.HostPoolName, hostPoolResourceType)
+	if err := locks.ByName(ctx, hostPoolId.HostPoolName, hostPoolResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(hostPoolId.HostPoolName, hostPoolResourceType)
 
 	// This is a virtual resource so the last segment is hardcoded
@@ -164,7 +166,9 @@ func resourceVirtualDesktopHostPoolRegistrationInfoDelete(d *pluginsdk.ResourceD
 
 	hostPoolId := hostpool.NewHostPoolID(id.SubscriptionId, id.ResourceGroup, id.HostPoolName)
 
-	locks.ByName(hostPoolId.HostPoolName, hostPoolResourceType)
+	if err := locks.ByName(ctx, hostPoolId.HostPoolName, hostPoolResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(hostPoolId.HostPoolName, hostPoolResourceType)
 
 	resp, err := client.Get(ctx, hostPoolId)
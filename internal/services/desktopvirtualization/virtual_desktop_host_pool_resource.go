@@ -256,7 +256,9 @@ func resourceVirtualDesktopHostPoolUpdate(d *pluginsdk.ResourceData, meta interf
 		return err
 	}
 
-	locks.ByName(id.HostPoolName, hostPoolResourceType)
+	if err := locks.ByName(ctx, id.HostPoolName, hostPoolResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.HostPoolName, hostPoolResourceType)
 
 	payload := hostpool.HostPoolPatch{}
@@ -380,7 +382,9 @@ func resourceVirtualDesktopHostPoolDelete(d *pluginsdk.ResourceData, meta interf
 		return err
 	}
 
-	locks.ByName(id.HostPoolName, hostPoolResourceType)
+	if err := locks.ByName(ctx, id.HostPoolName, hostPoolResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.HostPoolName, hostPoolResourceType)
 
 	options := hostpool.DeleteOperationOptions{
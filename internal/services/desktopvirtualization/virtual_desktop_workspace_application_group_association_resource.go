@@ -78,10 +78,14 @@ func resourceVirtualDesktopWorkspaceApplicationGroupAssociationCreate(d *plugins
 	}
 	associationId := parse.NewWorkspaceApplicationGroupAssociationId(*workspaceId, *applicationGroupId).ID()
 
-	locks.ByName(workspaceId.WorkspaceName, workspaceResourceType)
+	if err := locks.ByName(ctx, workspaceId.WorkspaceName, workspaceResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(workspaceId.WorkspaceName, workspaceResourceType)
 
-	locks.ByName(applicationGroupId.ApplicationGroupName, applicationGroupType)
+	if err := locks.ByName(ctx, applicationGroupId.ApplicationGroupName, applicationGroupType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(applicationGroupId.ApplicationGroupName, applicationGroupType)
 
 	existing, err := client.Get(ctx, *workspaceId)
@@ -169,10 +173,14 @@ func resourceVirtualDesktopWorkspaceApplicationGroupAssociationDelete(d *plugins
 		return err
 	}
 
-	locks.ByName(id.Workspace.WorkspaceName, workspaceResourceType)
+	if err := locks.ByName(ctx, id.Workspace.WorkspaceName, workspaceResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Workspace.WorkspaceName, workspaceResourceType)
 
-	locks.ByName(id.ApplicationGroup.ApplicationGroupName, applicationGroupType)
+	if err := locks.ByName(ctx, id.ApplicationGroup.ApplicationGroupName, applicationGroupType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ApplicationGroup.ApplicationGroupName, applicationGroupType)
 
 	existing, err := client.Get(ctx, id.Workspace)
@@ -137,7 +137,9 @@ func (r DisksPoolIscsiTargetLunResource) Destroy(ctx context.Context, clients *c
 
 	iscsiTargetId := id.IscsiTargetId
 
-	locks.ByID(iscsiTargetId.ID())
+	if err := locks.ByID(ctx, iscsiTargetId.ID()); err != nil {
+		return nil, err
+	}
 	defer locks.UnlockByID(iscsiTargetId.ID())
 
 	client := clients.Disks.DisksPoolIscsiTargetClient
@@ -83,7 +83,9 @@ func (d DiskPoolManagedDiskAttachmentResource) Create() sdk.ResourceFunc {
 			if err != nil {
 				return err
 			}
-			locks.ByID(attachment.DiskPoolId)
+			if err := locks.ByID(ctx, attachment.DiskPoolId); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(attachment.DiskPoolId)
 			id := parse.NewDiskPoolManagedDiskAttachmentId(*poolId, *diskId)
 
@@ -176,7 +178,9 @@ func (d DiskPoolManagedDiskAttachmentResource) Delete() sdk.ResourceFunc {
 			if err != nil {
 				return err
 			}
-			locks.ByID(diskToDetach.DiskPoolId)
+			if err := locks.ByID(ctx, diskToDetach.DiskPoolId); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(diskToDetach.DiskPoolId)
 
 			client := metadata.Client.Disks.DiskPoolsClient
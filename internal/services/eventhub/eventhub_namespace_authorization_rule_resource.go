@@ -89,7 +89,9 @@ func resourceEventHubNamespaceAuthorizationRuleCreateUpdate(d *pluginsdk.Resourc
 		}
 	}
 
-	locks.ByName(id.NamespaceName, eventHubNamespaceResourceName)
+	if err := locks.ByName(ctx, id.NamespaceName, eventHubNamespaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NamespaceName, eventHubNamespaceResourceName)
 
 	parameters := authorizationrulesnamespaces.AuthorizationRule{
@@ -166,7 +168,9 @@ func resourceEventHubNamespaceAuthorizationRuleDelete(d *pluginsdk.ResourceData,
 		return err
 	}
 
-	locks.ByName(id.NamespaceName, eventHubNamespaceResourceName)
+	if err := locks.ByName(ctx, id.NamespaceName, eventHubNamespaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NamespaceName, eventHubNamespaceResourceName)
 
 	if _, err := eventhubClient.NamespacesDeleteAuthorizationRule(ctx, *id); err != nil {
@@ -98,7 +98,9 @@ func resourceEventHubNamespaceCustomerManagedKeyCreateUpdate(d *pluginsdk.Resour
 		return err
 	}
 
-	locks.ByName(id.NamespaceName, "azurerm_eventhub_namespace")
+	if err := locks.ByName(ctx, id.NamespaceName, "azurerm_eventhub_namespace"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NamespaceName, "azurerm_eventhub_namespace")
 
 	resp, err := client.Get(ctx, *id)
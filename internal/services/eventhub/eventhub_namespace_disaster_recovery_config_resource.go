@@ -92,7 +92,9 @@ func resourceEventHubNamespaceDisasterRecoveryConfigCreate(d *pluginsdk.Resource
 		}
 	}
 
-	locks.ByName(id.NamespaceName, eventHubNamespaceResourceName)
+	if err := locks.ByName(ctx, id.NamespaceName, eventHubNamespaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NamespaceName, eventHubNamespaceResourceName)
 
 	parameters := disasterrecoveryconfigs.ArmDisasterRecovery{
@@ -123,7 +125,9 @@ func resourceEventHubNamespaceDisasterRecoveryConfigUpdate(d *pluginsdk.Resource
 		return err
 	}
 
-	locks.ByName(id.NamespaceName, eventHubNamespaceResourceName)
+	if err := locks.ByName(ctx, id.NamespaceName, eventHubNamespaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NamespaceName, eventHubNamespaceResourceName)
 
 	pairingStatus, err := client.Get(ctx, *id)
@@ -209,7 +213,9 @@ func resourceEventHubNamespaceDisasterRecoveryConfigDelete(d *pluginsdk.Resource
 		return err
 	}
 
-	locks.ByName(id.NamespaceName, eventHubNamespaceResourceName)
+	if err := locks.ByName(ctx, id.NamespaceName, eventHubNamespaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NamespaceName, eventHubNamespaceResourceName)
 
 	pairingStatus, err := client.Get(ctx, *id)
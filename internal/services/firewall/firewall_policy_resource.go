@@ -124,7 +124,9 @@ func resourceFirewallPolicyCreateUpdate(d *pluginsdk.ResourceData, meta interfac
 		}
 	}
 
-	locks.ByName(id.Name, AzureFirewallPolicyResourceName)
+	if err := locks.ByName(ctx, id.Name, AzureFirewallPolicyResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, AzureFirewallPolicyResourceName)
 
 	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, props)
@@ -257,7 +259,9 @@ func resourceFirewallPolicyDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.Name, AzureFirewallPolicyResourceName)
+	if err := locks.ByName(ctx, id.Name, AzureFirewallPolicyResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, AzureFirewallPolicyResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
@@ -448,7 +448,9 @@ func resourceFirewallPolicyRuleCollectionGroupCreateUpdate(d *pluginsdk.Resource
 		}
 	}
 
-	locks.ByName(policyId.Name, AzureFirewallPolicyResourceName)
+	if err := locks.ByName(ctx, policyId.Name, AzureFirewallPolicyResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(policyId.Name, AzureFirewallPolicyResourceName)
 
 	param := network.FirewallPolicyRuleCollectionGroup{
@@ -546,7 +548,9 @@ func resourceFirewallPolicyRuleCollectionGroupDelete(d *pluginsdk.ResourceData,
 		return err
 	}
 
-	locks.ByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
+	if err := locks.ByName(ctx, id.FirewallPolicyName, AzureFirewallPolicyResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.FirewallPolicyName, id.RuleCollectionGroupName)
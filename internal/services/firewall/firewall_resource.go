@@ -340,17 +340,25 @@ func resourceFirewallCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 
 	if policyId, ok := d.GetOk("firewall_policy_id"); ok {
 		id, _ := parse.FirewallPolicyID(policyId.(string))
-		locks.ByName(id.Name, AzureFirewallPolicyResourceName)
+		if err := locks.ByName(ctx, id.Name, AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.Name, AzureFirewallPolicyResourceName)
 	}
 
-	locks.ByName(id.AzureFirewallName, AzureFirewallResourceName)
+	if err := locks.ByName(ctx, id.AzureFirewallName, AzureFirewallResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AzureFirewallName, AzureFirewallResourceName)
 
-	locks.MultipleByName(vnetToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, vnetToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(vnetToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(subnetToLock, SubnetResourceName)
+	if err := locks.MultipleByName(ctx, subnetToLock, SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(subnetToLock, SubnetResourceName)
 
 	if !d.IsNewResource() {
@@ -516,17 +524,25 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	if read.FirewallPolicy != nil && read.FirewallPolicy.ID != nil {
 		id, _ := parse.FirewallPolicyID(*read.FirewallPolicy.ID)
-		locks.ByName(id.Name, AzureFirewallPolicyResourceName)
+		if err := locks.ByName(ctx, id.Name, AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.Name, AzureFirewallPolicyResourceName)
 	}
 
-	locks.ByName(id.AzureFirewallName, AzureFirewallResourceName)
+	if err := locks.ByName(ctx, id.AzureFirewallName, AzureFirewallResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AzureFirewallName, AzureFirewallResourceName)
 
-	locks.MultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, &virtualNetworkNamesToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(&subnetNamesToLock, SubnetResourceName)
+	if err := locks.MultipleByName(ctx, &subnetNamesToLock, SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&subnetNamesToLock, SubnetResourceName)
 
 	// Change this back to using the SDK method once https://github.com/Azure/azure-sdk-for-go/issues/17013 is addressed.
@@ -30,6 +30,7 @@ func (r ServicesAccountResource) ResourceType() string {
 var _ sdk.Resource = AccountResource{}
 var _ sdk.ResourceWithUpdate = AccountResource{}
 var _ sdk.ResourceWithDeprecationReplacedBy = AccountResource{}
+var _ sdk.ResourceWithReplacementMigration = AccountResource{}
 
 // AccountResource remove this in 4.0
 type AccountResource struct{}
@@ -38,6 +39,17 @@ func (r AccountResource) DeprecatedInFavourOfResource() string {
 	return "azurerm_graph_services_account"
 }
 
+func (r AccountResource) ReplacementMigration() sdk.ReplacementMigration {
+	return sdk.ReplacementMigration{
+		Replacements: []sdk.ResourceReplacement{
+			{
+				// the schema is identical, only the Resource Type has changed
+				ResourceType: r.DeprecatedInFavourOfResource(),
+			},
+		},
+	}
+}
+
 func (r AccountResource) ModelObject() interface{} {
 	return &AccountResourceSchema{}
 }
@@ -663,7 +663,9 @@ func resourceIotHubCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 
 	id := parse.NewIotHubID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, IothubResourceName)
+	if err := locks.ByName(ctx, id.Name, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, IothubResourceName)
 
 	if d.IsNewResource() {
@@ -927,7 +929,9 @@ func resourceIotHubDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	locks.ByName(id.Name, IothubResourceName)
+	if err := locks.ByName(ctx, id.Name, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, IothubResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
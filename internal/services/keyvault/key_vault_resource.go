@@ -246,7 +246,9 @@ func resourceKeyVaultCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	// Locking this resource so we don't make modifications to it at the same time if there is a
 	// key vault access policy trying to update it as well
-	locks.ByName(id.VaultName, keyVaultResourceName)
+	if err := locks.ByName(ctx, id.VaultName, keyVaultResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VaultName, keyVaultResourceName)
 
 	// check for the presence of an existing, live one which should be imported into the state
@@ -352,7 +354,9 @@ func resourceKeyVaultCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 	if err := client.CreateOrUpdateThenPoll(ctx, id, parameters); err != nil {
@@ -418,7 +422,9 @@ func resourceKeyVaultUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	// Locking this resource so we don't make modifications to it at the same time if there is a
 	// key vault access policy trying to update it as well
-	locks.ByName(id.VaultName, keyVaultResourceName)
+	if err := locks.ByName(ctx, id.VaultName, keyVaultResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VaultName, keyVaultResourceName)
 
 	d.Partial(true)
@@ -497,7 +503,9 @@ func resourceKeyVaultUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 			}
 		}
 
-		locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+		if err := locks.MultipleByName(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 		update.Properties.NetworkAcls = networkAcls
@@ -729,7 +737,9 @@ func resourceKeyVaultDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VaultName, keyVaultResourceName)
+	if err := locks.ByName(ctx, id.VaultName, keyVaultResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VaultName, keyVaultResourceName)
 
 	read, err := client.Get(ctx, *id)
@@ -771,7 +781,9 @@ func resourceKeyVaultDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 	if _, err := client.Delete(ctx, *id); err != nil {
@@ -90,7 +90,9 @@ func resourceKustoClusterCustomerManagedKeyCreateUpdate(d *pluginsdk.ResourceDat
 		return err
 	}
 
-	locks.ByName(clusterID.ClusterName, "azurerm_kusto_cluster")
+	if err := locks.ByName(ctx, clusterID.ClusterName, "azurerm_kusto_cluster"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(clusterID.ClusterName, "azurerm_kusto_cluster")
 
 	cluster, err := clusterClient.Get(ctx, *clusterID)
@@ -248,7 +250,9 @@ func resourceKustoClusterCustomerManagedKeyDelete(d *pluginsdk.ResourceData, met
 		return err
 	}
 
-	locks.ByName(clusterID.ClusterName, "azurerm_kusto_cluster")
+	if err := locks.ByName(ctx, clusterID.ClusterName, "azurerm_kusto_cluster"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(clusterID.ClusterName, "azurerm_kusto_cluster")
 
 	// confirm it still exists prior to trying to update it, else we'll get an error
@@ -123,7 +123,9 @@ func resourceKustoDatabaseScriptCreateUpdate(d *pluginsdk.ResourceData, meta int
 	}
 
 	clusterId := clusters.NewClusterID(databaseId.SubscriptionId, databaseId.ResourceGroupName, databaseId.ClusterName)
-	locks.ByID(clusterId.ID())
+	if err := locks.ByID(ctx, clusterId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(clusterId.ID())
 
 	forceUpdateTag := d.Get("force_an_update_when_value_changed").(string)
@@ -202,7 +204,9 @@ func resourceKustoDatabaseScriptDelete(d *pluginsdk.ResourceData, meta interface
 	}
 
 	// DELETE operation for script does not support running concurrently at cluster level
-	locks.ByName(id.ClusterName, "azurerm_kusto_cluster")
+	if err := locks.ByName(ctx, id.ClusterName, "azurerm_kusto_cluster"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ClusterName, "azurerm_kusto_cluster")
 
 	err = client.DeleteThenPoll(ctx, *id)
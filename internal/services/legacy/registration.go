@@ -4,11 +4,14 @@
 package legacy
 
 import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 type Registration struct{}
 
+var _ sdk.UntypedServiceRegistrationWithReplacementMigrations = Registration{}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Legacy"
@@ -35,3 +38,10 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 
 	return resources
 }
+
+// ReplacementMigrations returns the ReplacementMigration for each deprecated Resource within this Service
+func (r Registration) ReplacementMigrations() map[string]sdk.ReplacementMigration {
+	return map[string]sdk.ReplacementMigration{
+		"azurerm_virtual_machine": virtualMachineReplacementMigration(),
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	compute2 "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
@@ -638,6 +639,61 @@ func resourceVirtualMachine() *pluginsdk.Resource {
 	}
 }
 
+// virtualMachineReplacementMigration describes how the `azurerm_virtual_machine` resource can be migrated to the
+// `azurerm_linux_virtual_machine` and `azurerm_windows_virtual_machine` resources
+func virtualMachineReplacementMigration() sdk.ReplacementMigration {
+	renamedProperties := func() map[string]string {
+		return map[string]string{
+			"boot_diagnostics.storage_uri":      "storage_account_uri",
+			"storage_image_reference":           "source_image_reference",
+			"storage_os_disk":                   "os_disk",
+			"storage_os_disk.managed_disk_type": "storage_account_type",
+			"vm_size":                           "size",
+		}
+	}
+	removedProperties := func() map[string]string {
+		return map[string]string{
+			"boot_diagnostics.enabled":         "Boot Diagnostics are enabled when the `boot_diagnostics` block is specified",
+			"delete_data_disks_on_termination": "Data Disks are managed using the `azurerm_managed_disk` and `azurerm_virtual_machine_data_disk_attachment` resources",
+			"delete_os_disk_on_termination":    "the OS Disk is deleted with the Virtual Machine, which is configurable using the `virtual_machine` block within the `features` block of the Provider",
+			"os_profile":                       "the `computer_name`, `admin_username`, `admin_password` and `custom_data` properties are specified directly on the Virtual Machine",
+			"os_profile_secrets":               "certificates are specified using the `secret` block",
+			"primary_network_interface_id":     "the first Network Interface within `network_interface_ids` is the Primary Network Interface",
+			"storage_data_disk":                "Data Disks are managed using the `azurerm_managed_disk` and `azurerm_virtual_machine_data_disk_attachment` resources",
+			"storage_image_reference.id":       "a custom image is specified using the `source_image_id` property",
+			"storage_os_disk.create_option":    "the OS Disk is always created from the source image",
+			"storage_os_disk.image_uri":        "a custom image is specified using the `source_image_id` property",
+			"storage_os_disk.managed_disk_id":  "attaching an existing OS Disk isn't supported",
+			"storage_os_disk.os_type":          "the Operating System is determined by the Resource Type",
+			"storage_os_disk.vhd_uri":          "Unmanaged Disks aren't supported",
+			"zones":                            "the Availability Zone is specified using the `zone` property",
+		}
+	}
+
+	linuxRemovedProperties := removedProperties()
+	linuxRemovedProperties["os_profile_linux_config"] = "the `disable_password_authentication` property is specified directly on the Virtual Machine and SSH Keys are specified using the `admin_ssh_key` block"
+
+	windowsRemovedProperties := removedProperties()
+	windowsRemovedProperties["os_profile_windows_config"] = "the `provision_vm_agent`, `enable_automatic_updates` and `timezone` properties are specified directly on the Virtual Machine, in addition to the `winrm_listener` and `additional_unattend_content` blocks"
+
+	return sdk.ReplacementMigration{
+		Replacements: []sdk.ResourceReplacement{
+			{
+				ResourceType:      "azurerm_linux_virtual_machine",
+				WhenPropertiesSet: []string{"os_profile_linux_config"},
+				RenamedProperties: renamedProperties(),
+				RemovedProperties: linuxRemovedProperties,
+			},
+			{
+				ResourceType:      "azurerm_windows_virtual_machine",
+				WhenPropertiesSet: []string{"os_profile_windows_config"},
+				RenamedProperties: renamedProperties(),
+				RemovedProperties: windowsRemovedProperties,
+			},
+		},
+	}
+}
+
 func resourceVirtualMachineCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Legacy.VMClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
@@ -753,7 +809,9 @@ func resourceVirtualMachineCreateUpdate(d *pluginsdk.ResourceData, meta interfac
 		vm.Plan = expandAzureRmVirtualMachinePlan(d)
 	}
 
-	locks.ByName(id.Name, compute2.VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.Name, compute2.VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, compute2.VirtualMachineResourceName)
 
 	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, vm)
@@ -938,7 +996,9 @@ func resourceVirtualMachineDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.Name, compute2.VirtualMachineResourceName)
+	if err := locks.ByName(ctx, id.Name, compute2.VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, compute2.VirtualMachineResourceName)
 
 	virtualMachine, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
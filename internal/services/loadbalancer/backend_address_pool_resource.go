@@ -173,10 +173,14 @@ func resourceArmLoadBalancerBackendAddressPoolCreateUpdate(d *pluginsdk.Resource
 		}
 	}
 
-	locks.ByName(name, backendAddressPoolResourceName)
+	if err := locks.ByName(ctx, name, backendAddressPoolResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, backendAddressPoolResourceName)
 
-	locks.ByID(loadBalancerId.ID())
+	if err := locks.ByID(ctx, loadBalancerId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(loadBalancerId.ID())
 
 	lb, err := lbClient.Get(ctx, loadBalancerId.ResourceGroup, loadBalancerId.Name, "")
@@ -382,10 +386,14 @@ func resourceArmLoadBalancerBackendAddressPoolDelete(d *pluginsdk.ResourceData,
 
 	loadBalancerId := parse.NewLoadBalancerID(id.SubscriptionId, id.ResourceGroup, id.LoadBalancerName)
 	loadBalancerID := loadBalancerId.ID()
-	locks.ByID(loadBalancerID)
+	if err := locks.ByID(ctx, loadBalancerID); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(loadBalancerID)
 
-	locks.ByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
+	if err := locks.ByName(ctx, id.BackendAddressPoolName, backendAddressPoolResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
 
 	lb, err := lbClient.Get(ctx, loadBalancerId.ResourceGroup, loadBalancerId.Name, "")
@@ -88,7 +88,9 @@ func resourceLogAnalyticsClusterCreate(d *pluginsdk.ResourceData, meta interface
 
 	id := clusters.NewClusterID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	existing, err := client.Get(ctx, id)
@@ -182,7 +184,9 @@ func resourceLogAnalyticsClusterUpdate(d *pluginsdk.ResourceData, meta interface
 		return err
 	}
 
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	resp, err := client.Get(ctx, *id)
@@ -228,7 +232,9 @@ func resourceLogAnalyticsClusterDelete(d *pluginsdk.ResourceData, meta interface
 		return err
 	}
 
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	err = client.DeleteThenPoll(ctx, *id)
@@ -64,7 +64,9 @@ func resourceLogicAppComponentUpdate(d *pluginsdk.ResourceData, meta interface{}
 	log.Printf("[DEBUG] Preparing arguments for Logic App Workspace %s %s %q", workflowId, kind, name)
 
 	// lock to prevent against Actions or Triggers conflicting
-	locks.ByName(workflowId.WorkflowName, logicAppResourceName)
+	if err := locks.ByName(ctx, workflowId.WorkflowName, logicAppResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(workflowId.WorkflowName, logicAppResourceName)
 
 	read, err := client.Get(ctx, workflowId)
@@ -145,7 +147,9 @@ func resourceLogicAppComponentRemove(d *pluginsdk.ResourceData, meta interface{}
 	log.Printf("[DEBUG] Preparing arguments for Logic App Workspace %q (Resource Group %q) %s %q Deletion", id.WorkflowName, id.ResourceGroupName, kind, name)
 
 	// lock to prevent against Actions, Parameters or Actions conflicting
-	locks.ByName(id.WorkflowName, logicAppResourceName)
+	if err := locks.ByName(ctx, id.WorkflowName, logicAppResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.WorkflowName, logicAppResourceName)
 
 	read, err := client.Get(ctx, id)
@@ -227,7 +231,9 @@ func retreiveLogicAppTriggerCallbackUrl(d *pluginsdk.ResourceData, meta interfac
 	log.Printf("[DEBUG] Preparing arguments for Logic App Workspace %q (Resource Group %q) %s %q", id.WorkflowName, id.ResourceGroupName, "trigger", id.TriggerName)
 
 	// lock to prevent against Actions, Parameters or Actions conflicting
-	locks.ByName(id.WorkflowName, logicAppResourceName)
+	if err := locks.ByName(ctx, id.WorkflowName, logicAppResourceName); err != nil {
+		return nil, err
+	}
 	defer locks.UnlockByName(id.WorkflowName, logicAppResourceName)
 
 	result, err := client.TriggersClient.ListCallbackUrl(ctx, id)
@@ -250,7 +256,9 @@ func retrieveLogicAppComponent(d *pluginsdk.ResourceData, meta interface{}, kind
 	log.Printf("[DEBUG] Preparing arguments for %s: %s %q", id.ID(), kind, name)
 
 	// lock to prevent against Actions, Parameters or Actions conflicting
-	locks.ByName(id.WorkflowName, logicAppResourceName)
+	if err := locks.ByName(ctx, id.WorkflowName, logicAppResourceName); err != nil {
+		return nil, nil, err
+	}
 	defer locks.UnlockByName(id.WorkflowName, logicAppResourceName)
 
 	read, err := client.Get(ctx, id)
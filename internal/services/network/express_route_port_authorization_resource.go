@@ -91,7 +91,9 @@ func resourceExpressRoutePortAuthorizationCreate(d *pluginsdk.ResourceData, meta
 
 	// can run only one create/update/delete operation of expressRoutePort at the same time
 	portID := parse.NewExpressRoutePortID(id.SubscriptionId, id.ResourceGroup, id.ExpressRoutePortName)
-	locks.ByID(portID.ID())
+	if err := locks.ByID(ctx, portID.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(portID.ID())
 
 	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.ExpressRoutePortName, id.AuthorizationName, properties)
@@ -150,7 +152,9 @@ func resourceExpressRoutePortAuthorizationDelete(d *pluginsdk.ResourceData, meta
 	}
 
 	portID := parse.NewExpressRoutePortID(id.SubscriptionId, id.ResourceGroup, id.ExpressRoutePortName)
-	locks.ByID(portID.ID())
+	if err := locks.ByID(ctx, portID.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(portID.ID())
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.ExpressRoutePortName, id.AuthorizationName)
@@ -93,19 +93,25 @@ func resourceIpGroupCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	for _, fw := range d.Get("firewall_ids").([]interface{}) {
 		id, _ := firewallParse.FirewallID(fw.(string))
-		locks.ByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
+		if err := locks.ByName(ctx, id.AzureFirewallName, firewall.AzureFirewallResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
 	}
 
 	for _, fwpol := range d.Get("firewall_policy_ids").([]interface{}) {
 		id, _ := firewallParse.FirewallPolicyID(fwpol.(string))
-		locks.ByName(id.Name, firewall.AzureFirewallPolicyResourceName)
+		if err := locks.ByName(ctx, id.Name, firewall.AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.Name, firewall.AzureFirewallPolicyResourceName)
 	}
 
 	id := parse.NewIpGroupID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	if d.IsNewResource() {
@@ -196,19 +202,25 @@ func resourceIpGroupUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	for _, fw := range d.Get("firewall_ids").([]interface{}) {
 		id, _ := firewallParse.FirewallID(fw.(string))
-		locks.ByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
+		if err := locks.ByName(ctx, id.AzureFirewallName, firewall.AzureFirewallResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
 	}
 
 	for _, fwpol := range d.Get("firewall_policy_ids").([]interface{}) {
 		id, _ := firewallParse.FirewallPolicyID(fwpol.(string))
-		locks.ByName(id.Name, firewall.AzureFirewallPolicyResourceName)
+		if err := locks.ByName(ctx, id.Name, firewall.AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.Name, firewall.AzureFirewallPolicyResourceName)
 	}
 
 	id := parse.NewIpGroupID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	exisiting, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -271,7 +283,9 @@ func resourceIpGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	read, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -287,13 +301,17 @@ func resourceIpGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	for _, fw := range *read.Firewalls {
 		id, _ := firewallParse.FirewallID(*fw.ID)
-		locks.ByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
+		if err := locks.ByName(ctx, id.AzureFirewallName, firewall.AzureFirewallResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
 	}
 
 	for _, fwpol := range *read.FirewallPolicies {
 		id, _ := firewallParse.FirewallPolicyID(*fwpol.ID)
-		locks.ByName(id.Name, firewall.AzureFirewallPolicyResourceName)
+		if err := locks.ByName(ctx, id.Name, firewall.AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.Name, firewall.AzureFirewallPolicyResourceName)
 	}
 
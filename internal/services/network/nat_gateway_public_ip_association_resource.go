@@ -68,7 +68,9 @@ func resourceNATGatewayPublicIpAssociationCreate(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(parsedNatGatewayId.Name, natGatewayResourceName)
+	if err := locks.ByName(ctx, parsedNatGatewayId.Name, natGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(parsedNatGatewayId.Name, natGatewayResourceName)
 
 	natGateway, err := client.Get(ctx, parsedNatGatewayId.ResourceGroup, parsedNatGatewayId.Name, "")
@@ -179,7 +181,9 @@ func resourceNATGatewayPublicIpAssociationDelete(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(id.NatGateway.Name, natGatewayResourceName)
+	if err := locks.ByName(ctx, id.NatGateway.Name, natGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NatGateway.Name, natGatewayResourceName)
 
 	natGateway, err := client.Get(ctx, id.NatGateway.ResourceGroup, id.NatGateway.Name, "")
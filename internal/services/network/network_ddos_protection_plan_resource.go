@@ -98,10 +98,14 @@ func resourceNetworkDDoSProtectionPlanCreateUpdate(d *pluginsdk.ResourceData, me
 		return fmt.Errorf("extracting names of Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.Name, azureNetworkDDoSProtectionPlanResourceName)
+	if err := locks.ByName(ctx, id.Name, azureNetworkDDoSProtectionPlanResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, azureNetworkDDoSProtectionPlanResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
 	parameters := network.DdosProtectionPlan{
@@ -186,10 +190,14 @@ func resourceNetworkDDoSProtectionPlanDelete(d *pluginsdk.ResourceData, meta int
 		return fmt.Errorf("extracting names of Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.Name, azureNetworkDDoSProtectionPlanResourceName)
+	if err := locks.ByName(ctx, id.Name, azureNetworkDDoSProtectionPlanResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, azureNetworkDDoSProtectionPlanResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
@@ -84,7 +84,9 @@ func resourceNetworkInterfaceApplicationSecurityGroupAssociationCreate(d *plugin
 		return err
 	}
 
-	locks.ByName(id.NetworkInterfaceName, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, id.NetworkInterfaceName, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkInterfaceName, networkInterfaceResourceName)
 
 	read, err := client.Get(ctx, *id, networkinterfaces.DefaultGetOperationOptions())
@@ -202,7 +204,9 @@ func resourceNetworkInterfaceApplicationSecurityGroupAssociationDelete(d *plugin
 
 	applicationSecurityGroupId := splitId[1]
 
-	locks.ByName(nicID.NetworkInterfaceName, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, nicID.NetworkInterfaceName, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(nicID.NetworkInterfaceName, networkInterfaceResourceName)
 
 	read, err := client.Get(ctx, *nicID, networkinterfaces.DefaultGetOperationOptions())
@@ -87,7 +87,9 @@ func resourceNetworkInterfaceNatRuleAssociationCreate(d *pluginsdk.ResourceData,
 		return err
 	}
 
-	locks.ByName(id.Name, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, id.Name, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, networkInterfaceResourceName)
 
 	read, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -250,7 +252,9 @@ func resourceNetworkInterfaceNatRuleAssociationDelete(d *pluginsdk.ResourceData,
 
 	natRuleId := splitId[1]
 
-	locks.ByName(nicID.NetworkInterfaceName, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, nicID.NetworkInterfaceName, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(nicID.NetworkInterfaceName, networkInterfaceResourceName)
 
 	read, err := client.Get(ctx, nicID.ResourceGroup, nicID.NetworkInterfaceName, "")
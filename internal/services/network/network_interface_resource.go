@@ -229,7 +229,9 @@ func resourceNetworkInterfaceCreate(d *pluginsdk.ResourceData, meta interface{})
 		EnableAcceleratedNetworking: &enableAcceleratedNetworking,
 	}
 
-	locks.ByName(id.NetworkInterfaceName, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, id.NetworkInterfaceName, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkInterfaceName, networkInterfaceResourceName)
 
 	dns, hasDns := d.GetOk("dns_servers")
@@ -260,7 +262,9 @@ func resourceNetworkInterfaceCreate(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("determining locking details: %+v", err)
 	}
 
-	lockingDetails.lock()
+	if err := lockingDetails.lock(ctx); err != nil {
+		return err
+	}
 	defer lockingDetails.unlock()
 
 	if len(*ipConfigs) > 0 {
@@ -294,7 +298,9 @@ func resourceNetworkInterfaceUpdate(d *pluginsdk.ResourceData, meta interface{})
 		return err
 	}
 
-	locks.ByName(id.NetworkInterfaceName, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, id.NetworkInterfaceName, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkInterfaceName, networkInterfaceResourceName)
 
 	// first get the existing one so that we can pull things as needed
@@ -357,7 +363,9 @@ func resourceNetworkInterfaceUpdate(d *pluginsdk.ResourceData, meta interface{})
 			return fmt.Errorf("determining locking details: %+v", err)
 		}
 
-		lockingDetails.lock()
+		if err := lockingDetails.lock(ctx); err != nil {
+			return err
+		}
 		defer lockingDetails.unlock()
 
 		// then map the fields managed in other resources back
@@ -495,7 +503,9 @@ func resourceNetworkInterfaceDelete(d *pluginsdk.ResourceData, meta interface{})
 		return err
 	}
 
-	locks.ByName(id.NetworkInterfaceName, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, id.NetworkInterfaceName, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkInterfaceName, networkInterfaceResourceName)
 
 	existing, err := client.Get(ctx, *id, networkinterfaces.DefaultGetOperationOptions())
@@ -524,7 +534,9 @@ func resourceNetworkInterfaceDelete(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("determining locking details: %+v", err)
 	}
 
-	lockingDetails.lock()
+	if err := lockingDetails.lock(ctx); err != nil {
+		return err
+	}
 	defer lockingDetails.unlock()
 
 	err = client.DeleteThenPoll(ctx, *id)
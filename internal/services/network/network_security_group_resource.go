@@ -210,7 +210,9 @@ func resourceNetworkSecurityGroupCreateUpdate(d *pluginsdk.ResourceData, meta in
 		return fmt.Errorf("Building list of Network Security Group Rules: %+v", sgErr)
 	}
 
-	locks.ByName(id.Name, networkSecurityGroupResourceName)
+	if err := locks.ByName(ctx, id.Name, networkSecurityGroupResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, networkSecurityGroupResourceName)
 
 	sg := network.SecurityGroup{
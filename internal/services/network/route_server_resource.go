@@ -117,7 +117,9 @@ func resourceRouteServerCreateUpdate(d *pluginsdk.ResourceData, meta interface{}
 
 	id := parse.NewVirtualHubID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, "azurerm_route_server")
+	if err := locks.ByName(ctx, id.Name, "azurerm_route_server"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, "azurerm_route_server")
 
 	if d.IsNewResource() {
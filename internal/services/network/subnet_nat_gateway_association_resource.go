@@ -74,11 +74,17 @@ func resourceSubnetNatGatewayAssociationCreate(d *pluginsdk.ResourceData, meta i
 		return fmt.Errorf("parsing NAT gateway id '%s': %+v", natGatewayId, err)
 	}
 
-	locks.ByName(parsedGatewayId.Name, natGatewayResourceName)
+	if err := locks.ByName(ctx, parsedGatewayId.Name, natGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(parsedGatewayId.Name, natGatewayResourceName)
-	locks.ByName(parsedSubnetId.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, parsedSubnetId.VirtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(parsedSubnetId.VirtualNetworkName, VirtualNetworkResourceName)
-	locks.ByName(parsedSubnetId.SubnetName, SubnetResourceName)
+	if err := locks.ByName(ctx, parsedSubnetId.SubnetName, SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(parsedSubnetId.SubnetName, SubnetResourceName)
 
 	subnet, err := client.Get(ctx, parsedSubnetId.ResourceGroupName, parsedSubnetId.VirtualNetworkName, parsedSubnetId.SubnetName, "")
@@ -209,9 +215,13 @@ func resourceSubnetNatGatewayAssociationDelete(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(parsedGatewayId.Name, natGatewayResourceName)
+	if err := locks.ByName(ctx, parsedGatewayId.Name, natGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(parsedGatewayId.Name, natGatewayResourceName)
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, id.VirtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
 
 	// ensure we get the latest state
@@ -76,14 +76,18 @@ func resourceSubnetRouteTableAssociationCreate(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(parsedRouteTableId.RouteTableName, routeTableResourceName)
+	if err := locks.ByName(ctx, parsedRouteTableId.RouteTableName, routeTableResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(parsedRouteTableId.RouteTableName, routeTableResourceName)
 
 	subnetName := parsedSubnetId.SubnetName
 	virtualNetworkName := parsedSubnetId.VirtualNetworkName
 	resourceGroup := parsedSubnetId.ResourceGroupName
 
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, virtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
 
 	subnet, err := client.Get(ctx, resourceGroup, virtualNetworkName, subnetName, "")
@@ -228,10 +232,14 @@ func resourceSubnetRouteTableAssociationDelete(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(parsedRouteTableId.RouteTableName, routeTableResourceName)
+	if err := locks.ByName(ctx, parsedRouteTableId.RouteTableName, routeTableResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(parsedRouteTableId.RouteTableName, routeTableResourceName)
 
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, virtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
 
 	// then re-retrieve it to ensure we've got the latest state
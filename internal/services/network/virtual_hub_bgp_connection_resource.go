@@ -87,7 +87,9 @@ func resourceVirtualHubBgpConnectionCreate(d *pluginsdk.ResourceData, meta inter
 		return err
 	}
 
-	locks.ByName(virtHubId.Name, virtualHubResourceName)
+	if err := locks.ByName(ctx, virtHubId.Name, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtHubId.Name, virtualHubResourceName)
 
 	id := parse.NewBgpConnectionID(virtHubId.SubscriptionId, virtHubId.ResourceGroup, virtHubId.Name, d.Get("name").(string))
@@ -143,7 +145,9 @@ func resourceVirtualHubBgpConnectionUpdate(d *pluginsdk.ResourceData, meta inter
 		return err
 	}
 
-	locks.ByName(virtHubId.Name, virtualHubResourceName)
+	if err := locks.ByName(ctx, virtHubId.Name, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtHubId.Name, virtualHubResourceName)
 
 	id, err := parse.BgpConnectionID(d.Id())
@@ -229,7 +233,9 @@ func resourceVirtualHubBgpConnectionDelete(d *pluginsdk.ResourceData, meta inter
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByName(ctx, id.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualHubName, id.Name)
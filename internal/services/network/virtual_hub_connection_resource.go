@@ -170,7 +170,9 @@ func resourceVirtualHubConnectionCreateOrUpdate(d *pluginsdk.ResourceData, meta
 
 	id := parse.NewHubVirtualNetworkConnectionID(virtualHubId.SubscriptionId, virtualHubId.ResourceGroup, virtualHubId.Name, d.Get("name").(string))
 
-	locks.ByName(virtualHubId.Name, virtualHubResourceName)
+	if err := locks.ByName(ctx, virtualHubId.Name, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualHubId.Name, virtualHubResourceName)
 
 	remoteVirtualNetworkId, err := commonids.ParseVirtualNetworkID(d.Get("remote_virtual_network_id").(string))
@@ -178,7 +180,9 @@ func resourceVirtualHubConnectionCreateOrUpdate(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(remoteVirtualNetworkId.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, remoteVirtualNetworkId.VirtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(remoteVirtualNetworkId.VirtualNetworkName, VirtualNetworkResourceName)
 
 	if d.IsNewResource() {
@@ -283,7 +287,9 @@ func resourceVirtualHubConnectionDelete(d *pluginsdk.ResourceData, meta interfac
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByName(ctx, id.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualHubName, id.Name)
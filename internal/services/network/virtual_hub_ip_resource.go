@@ -99,7 +99,9 @@ func resourceVirtualHubIPCreateUpdate(d *pluginsdk.ResourceData, meta interface{
 		return err
 	}
 
-	locks.ByName(virtHubId.Name, virtualHubResourceName)
+	if err := locks.ByName(ctx, virtHubId.Name, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtHubId.Name, virtualHubResourceName)
 
 	id := parse.NewVirtualHubIpConfigurationID(virtHubId.SubscriptionId, virtHubId.ResourceGroup, virtHubId.Name, d.Get("name").(string))
@@ -208,7 +210,9 @@ func resourceVirtualHubIPDelete(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByName(ctx, id.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualHubName, id.IpConfigurationName)
@@ -157,7 +157,9 @@ func resourceVirtualHubCreateUpdate(d *pluginsdk.ResourceData, meta interface{})
 
 	id := parse.NewVirtualHubID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, virtualHubResourceName)
+	if err := locks.ByName(ctx, id.Name, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, virtualHubResourceName)
 
 	if d.IsNewResource() {
@@ -312,7 +314,9 @@ func resourceVirtualHubDelete(d *pluginsdk.ResourceData, meta interface{}) error
 		return err
 	}
 
-	locks.ByName(id.Name, virtualHubResourceName)
+	if err := locks.ByName(ctx, id.Name, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, virtualHubResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
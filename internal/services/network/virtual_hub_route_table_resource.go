@@ -125,7 +125,9 @@ func resourceVirtualHubRouteTableCreateUpdate(d *pluginsdk.ResourceData, meta in
 		return err
 	}
 
-	locks.ByName(virtHubId.Name, virtualHubResourceName)
+	if err := locks.ByName(ctx, virtHubId.Name, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtHubId.Name, virtualHubResourceName)
 
 	id := parse.NewHubRouteTableID(virtHubId.SubscriptionId, virtHubId.ResourceGroup, virtHubId.Name, d.Get("name").(string))
@@ -209,7 +211,9 @@ func resourceVirtualHubRouteTableDelete(d *pluginsdk.ResourceData, meta interfac
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByName(ctx, id.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualHubName, id.Name)
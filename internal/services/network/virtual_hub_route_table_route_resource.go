@@ -102,7 +102,9 @@ func resourceVirtualHubRouteTableRouteCreateUpdate(d *pluginsdk.ResourceData, me
 		return err
 	}
 
-	locks.ByName(routeTableId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByName(ctx, routeTableId.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(routeTableId.VirtualHubName, virtualHubResourceName)
 
 	routeTable, err := client.Get(ctx, routeTableId.ResourceGroup, routeTableId.VirtualHubName, routeTableId.Name)
@@ -222,7 +224,9 @@ func resourceVirtualHubRouteTableRouteDelete(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(route.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByName(ctx, route.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(route.VirtualHubName, virtualHubResourceName)
 
 	// get latest list of routes
@@ -355,7 +355,9 @@ func resourceVpnGatewayConnectionResourceCreateUpdate(d *pluginsdk.ResourceData,
 		}
 	}
 
-	locks.ByName(gatewayId.Name, VPNGatewayResourceName)
+	if err := locks.ByName(ctx, gatewayId.Name, VPNGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(gatewayId.Name, VPNGatewayResourceName)
 
 	payload := virtualwans.VpnConnection{
@@ -450,7 +452,9 @@ func resourceVpnGatewayConnectionResourceDelete(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(id.GatewayName, VPNGatewayResourceName)
+	if err := locks.ByName(ctx, id.GatewayName, VPNGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.GatewayName, VPNGatewayResourceName)
 
 	if err := client.VpnConnectionsDeleteThenPoll(ctx, *id); err != nil {
@@ -106,7 +106,9 @@ func (r LocalRuleStackCertificate) Create() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(rulestackId.ID())
+			if err := locks.ByID(ctx, rulestackId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(rulestackId.ID())
 
 			id := certificateobjectlocalrulestack.NewLocalRulestackCertificateID(rulestackId.SubscriptionId, rulestackId.ResourceGroupName, rulestackId.LocalRulestackName, model.Name)
@@ -204,11 +206,15 @@ func (r LocalRuleStackCertificate) Delete() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(id.ID())
+			if err := locks.ByID(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			rulestackId := localrulestacks.NewLocalRulestackID(id.SubscriptionId, id.ResourceGroupName, id.LocalRulestackName)
-			locks.ByID(rulestackId.ID())
+			if err := locks.ByID(ctx, rulestackId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(rulestackId.ID())
 
 			if _, err = client.Delete(ctx, *id); err != nil {
@@ -237,7 +243,9 @@ func (r LocalRuleStackCertificate) Update() sdk.ResourceFunc {
 				return err
 			}
 			rulestackId := localrulestacks.NewLocalRulestackID(id.SubscriptionId, id.ResourceGroupName, id.LocalRulestackName)
-			locks.ByID(rulestackId.ID())
+			if err := locks.ByID(ctx, rulestackId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(rulestackId.ID())
 
 			existing, err := client.Get(ctx, *id)
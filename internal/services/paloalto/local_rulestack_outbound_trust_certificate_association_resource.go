@@ -67,11 +67,15 @@ func (l LocalRulestackOutboundTrustCertificateAssociationResource) Create() sdk.
 				return err
 			}
 
-			locks.ByID(certificateId.ID())
+			if err := locks.ByID(ctx, certificateId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(certificateId.ID())
 			rulestackId := localrulestacks.NewLocalRulestackID(certificateId.SubscriptionId, certificateId.ResourceGroupName, certificateId.LocalRulestackName)
 
-			locks.ByID(rulestackId.ID())
+			if err := locks.ByID(ctx, rulestackId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(rulestackId.ID())
 
 			existing, err := client.Get(ctx, rulestackId)
@@ -148,11 +152,15 @@ func (l LocalRulestackOutboundTrustCertificateAssociationResource) Delete() sdk.
 			if err != nil {
 				return err
 			}
-			locks.ByID(certId.ID())
+			if err := locks.ByID(ctx, certId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(certId.ID())
 
 			rulestackId := localrulestacks.NewLocalRulestackID(certId.SubscriptionId, certId.ResourceGroupName, certId.LocalRulestackName)
-			locks.ByID(rulestackId.ID())
+			if err := locks.ByID(ctx, rulestackId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(rulestackId.ID())
 
 			existing, err := client.Get(ctx, rulestackId)
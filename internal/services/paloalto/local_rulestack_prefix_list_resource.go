@@ -98,7 +98,9 @@ func (r LocalRuleStackPrefixList) Create() sdk.ResourceFunc {
 			if err != nil {
 				return err
 			}
-			locks.ByID(rulestackId.ID())
+			if err := locks.ByID(ctx, rulestackId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(rulestackId.ID())
 
 			id := prefixlistlocalrulestack.NewLocalRulestackPrefixListID(rulestackId.SubscriptionId, rulestackId.ResourceGroupName, rulestackId.LocalRulestackName, model.Name)
@@ -193,7 +195,9 @@ func (r LocalRuleStackPrefixList) Delete() sdk.ResourceFunc {
 			}
 
 			rulestackId := localrulestacks.NewLocalRulestackID(id.SubscriptionId, id.ResourceGroupName, id.LocalRulestackName)
-			locks.ByID(rulestackId.ID())
+			if err := locks.ByID(ctx, rulestackId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(rulestackId.ID())
 
 			if _, err = client.Delete(ctx, *id); err != nil {
@@ -224,7 +228,9 @@ func (r LocalRuleStackPrefixList) Update() sdk.ResourceFunc {
 			}
 
 			rulestackId := localrulestacks.NewLocalRulestackID(id.SubscriptionId, id.ResourceGroupName, id.LocalRulestackName)
-			locks.ByID(rulestackId.ID())
+			if err := locks.ByID(ctx, rulestackId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(rulestackId.ID())
 
 			existing, err := client.Get(ctx, *id)
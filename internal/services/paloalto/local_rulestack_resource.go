@@ -143,7 +143,9 @@ func (r LocalRuleStack) Create() sdk.ResourceFunc {
 			}
 
 			id := localrulestacks.NewLocalRulestackID(metadata.Client.Account.SubscriptionId, model.ResourceGroupName, model.Name)
-			locks.ByID(id.ID())
+			if err := locks.ByID(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			existing, err := client.Get(ctx, id)
@@ -291,7 +293,9 @@ func (r LocalRuleStack) Update() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(id.ID())
+			if err := locks.ByID(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			model := LocalRuleStackModel{}
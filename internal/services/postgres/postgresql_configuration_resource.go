@@ -70,7 +70,9 @@ func resourcePostgreSQLConfigurationCreateUpdate(d *pluginsdk.ResourceData, meta
 	id := configurations.NewConfigurationID(subscriptionId, d.Get("resource_group_name").(string), d.Get("server_name").(string), d.Get("name").(string))
 	// TODO: support RequiresImport - this is possible to tell if it's the non-default value from the API (see Delete)
 
-	locks.ByName(id.ServerName, postgreSQLServerResourceName)
+	if err := locks.ByName(ctx, id.ServerName, postgreSQLServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ServerName, postgreSQLServerResourceName)
 
 	properties := configurations.Configuration{
@@ -131,7 +133,9 @@ func resourcePostgreSQLConfigurationDelete(d *pluginsdk.ResourceData, meta inter
 		return err
 	}
 
-	locks.ByName(id.ServerName, postgreSQLServerResourceName)
+	if err := locks.ByName(ctx, id.ServerName, postgreSQLServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ServerName, postgreSQLServerResourceName)
 
 	// "delete" = resetting this to the default value
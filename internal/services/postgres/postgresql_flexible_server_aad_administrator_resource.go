@@ -90,7 +90,9 @@ func resourcePostgresqlFlexibleServerAdministratorCreate(d *pluginsdk.ResourceDa
 
 	id := administrators.NewAdministratorID(subscriptionId, d.Get("resource_group_name").(string), d.Get("server_name").(string), d.Get("object_id").(string))
 
-	locks.ByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
+	if err := locks.ByName(ctx, id.FlexibleServerName, postgresqlFlexibleServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
 
 	if d.IsNewResource() {
@@ -170,7 +172,9 @@ func resourcePostgresqlFlexibleServerAdministratorDelete(d *pluginsdk.ResourceDa
 		return err
 	}
 
-	locks.ByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
+	if err := locks.ByName(ctx, id.FlexibleServerName, postgresqlFlexibleServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
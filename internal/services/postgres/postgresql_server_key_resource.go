@@ -97,7 +97,9 @@ func resourcePostgreSQLServerKeyCreateUpdate(d *pluginsdk.ResourceData, meta int
 		return fmt.Errorf("cannot compose name for %s: %+v", serverId, err)
 	}
 
-	locks.ByName(serverId.ServerName, postgreSQLServerResourceName)
+	if err := locks.ByName(ctx, serverId.ServerName, postgreSQLServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(serverId.ServerName, postgreSQLServerResourceName)
 
 	id := serverkeys.NewKeyID(serverId.SubscriptionId, serverId.ResourceGroupName, serverId.ServerName, *name)
@@ -181,7 +183,9 @@ func resourcePostgreSQLServerKeyDelete(d *pluginsdk.ResourceData, meta interface
 		return err
 	}
 
-	locks.ByName(id.ServerName, postgreSQLServerResourceName)
+	if err := locks.ByName(ctx, id.ServerName, postgreSQLServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ServerName, postgreSQLServerResourceName)
 
 	if err = client.DeleteThenPoll(ctx, *id); err != nil {
@@ -440,10 +440,14 @@ func resourceRedisCacheCreate(d *pluginsdk.ResourceData, meta interface{}) error
 			return err
 		}
 
-		locks.ByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
+		if err := locks.ByName(ctx, parsed.VirtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
 
-		locks.ByName(parsed.SubnetName, network.SubnetResourceName)
+		if err := locks.ByName(ctx, parsed.SubnetName, network.SubnetResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(parsed.SubnetName, network.SubnetResourceName)
 
 		parameters.Properties.SubnetId = utils.String(v.(string))
@@ -757,10 +761,14 @@ func resourceRedisCacheDelete(d *pluginsdk.ResourceData, meta interface{}) error
 			return err
 		}
 
-		locks.ByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
+		if err := locks.ByName(ctx, parsed.VirtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
 
-		locks.ByName(parsed.SubnetName, network.SubnetResourceName)
+		if err := locks.ByName(ctx, parsed.SubnetName, network.SubnetResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(parsed.SubnetName, network.SubnetResourceName)
 	}
 
@@ -4,6 +4,7 @@
 package resource
 
 import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/sweep"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -11,6 +12,7 @@ import (
 var (
 	_ sdk.TypedServiceRegistration   = Registration{}
 	_ sdk.UntypedServiceRegistration = Registration{}
+	_ sweep.ServiceRegistration      = Registration{}
 )
 
 type Registration struct{}
@@ -68,3 +70,10 @@ func (r Registration) Resources() []sdk.Resource {
 		ResourceDeploymentScriptAzureCliResource{},
 	}
 }
+
+// Sweepers returns a list of Sweepers used to clean up the Resources left behind by the Acceptance Tests
+func (r Registration) Sweepers() []sweep.Sweeper {
+	return []sweep.Sweeper{
+		ResourceGroupSweeper{},
+	}
+}
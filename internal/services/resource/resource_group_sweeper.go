@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/sweep"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/parse"
+)
+
+var _ sweep.Sweeper = ResourceGroupSweeper{}
+
+// ResourceGroupSweeper sweeps the Resource Groups (and in turn, the Resources within them) left behind by the
+// Acceptance Tests, for example `acctestRG-230102150405001234`
+type ResourceGroupSweeper struct{}
+
+func (ResourceGroupSweeper) Name() string {
+	return "azurerm_resource_group"
+}
+
+func (ResourceGroupSweeper) List(ctx context.Context, client *clients.Client) ([]sweep.Resource, error) {
+	output := make([]sweep.Resource, 0)
+
+	iterator, err := client.Resource.GroupsClient.ListComplete(ctx, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing Resource Groups: %+v", err)
+	}
+	for iterator.NotDone() {
+		v := iterator.Value()
+		name := pointer.From(v.Name)
+		output = append(output, sweep.Resource{
+			ID:        pointer.From(v.ID),
+			Name:      name,
+			CreatedAt: sweep.CreatedAtFromName(name),
+		})
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing Resource Groups: %+v", err)
+		}
+	}
+
+	return output, nil
+}
+
+func (ResourceGroupSweeper) Delete(ctx context.Context, client *clients.Client, resource sweep.Resource) error {
+	groupsClient := client.Resource.GroupsClient
+
+	id, err := parse.ResourceGroupIDInsensitively(resource.ID)
+	if err != nil {
+		return err
+	}
+
+	future, err := groupsClient.Delete(ctx, id.ResourceGroup, "")
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, groupsClient.Client); err != nil {
+		return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
@@ -101,7 +101,9 @@ func (r CustomCertSignalrServiceResource) Create() sdk.ResourceFunc {
 
 			id := signalr.NewCustomCertificateID(signalRServiceId.SubscriptionId, signalRServiceId.ResourceGroupName, signalRServiceId.SignalRName, metadata.ResourceData.Get("name").(string))
 
-			locks.ByID(signalRServiceId.ID())
+			if err := locks.ByID(ctx, signalRServiceId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(signalRServiceId.ID())
 
 			existing, err := client.CustomCertificatesGet(ctx, id)
@@ -211,7 +213,9 @@ func (r CustomCertSignalrServiceResource) Delete() sdk.ResourceFunc {
 
 			signalrId := signalr.NewSignalRID(id.SubscriptionId, id.ResourceGroupName, id.SignalRName)
 
-			locks.ByID(signalrId.ID())
+			if err := locks.ByID(ctx, signalrId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(signalrId.ID())
 
 			if _, err := client.CustomCertificatesDelete(ctx, *id); err != nil {
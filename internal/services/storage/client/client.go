@@ -48,6 +48,7 @@ type Client struct {
 
 	resourceManagerAuthorizer autorest.Authorizer
 	storageAdAuth             *autorest.Authorizer
+	readOnlyPolicy            *common.ReadOnlyPolicy
 }
 
 func NewClient(options *common.ClientOptions) (*Client, error) {
@@ -116,6 +117,7 @@ func NewClient(options *common.ClientOptions) (*Client, error) {
 		SyncGroupsClient:            syncGroupsClient,
 
 		resourceManagerAuthorizer: options.ResourceManagerAuthorizer,
+		readOnlyPolicy:            options.ReadOnlyPolicy,
 	}
 
 	if options.StorageUseAzureAD {
@@ -129,6 +131,7 @@ func (client Client) AccountsDataPlaneClient(ctx context.Context, account accoun
 	if client.storageAdAuth != nil {
 		accountsClient := accounts.NewWithEnvironment(client.Environment)
 		accountsClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&accountsClient.Client)
 		return &accountsClient, nil
 	}
 
@@ -144,6 +147,7 @@ func (client Client) AccountsDataPlaneClient(ctx context.Context, account accoun
 
 	accountsClient := accounts.NewWithEnvironment(client.Environment)
 	accountsClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&accountsClient.Client)
 	return &accountsClient, nil
 }
 
@@ -151,6 +155,7 @@ func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*
 	if client.storageAdAuth != nil {
 		blobsClient := blobs.NewWithEnvironment(client.Environment)
 		blobsClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&blobsClient.Client)
 		return &blobsClient, nil
 	}
 
@@ -166,6 +171,7 @@ func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*
 
 	blobsClient := blobs.NewWithEnvironment(client.Environment)
 	blobsClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&blobsClient.Client)
 	return &blobsClient, nil
 }
 
@@ -173,6 +179,7 @@ func (client Client) ContainersClient(ctx context.Context, account accountDetail
 	if client.storageAdAuth != nil {
 		containersClient := containers.NewWithEnvironment(client.Environment)
 		containersClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&containersClient.Client)
 		shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
 		return shim, nil
 	}
@@ -189,6 +196,7 @@ func (client Client) ContainersClient(ctx context.Context, account accountDetail
 
 	containersClient := containers.NewWithEnvironment(client.Environment)
 	containersClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&containersClient.Client)
 
 	shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
 	return shim, nil
@@ -209,6 +217,7 @@ func (client Client) FileShareDirectoriesClient(ctx context.Context, account acc
 
 	directoriesClient := directories.NewWithEnvironment(client.Environment)
 	directoriesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&directoriesClient.Client)
 	return &directoriesClient, nil
 }
 
@@ -227,6 +236,7 @@ func (client Client) FileShareFilesClient(ctx context.Context, account accountDe
 
 	filesClient := files.NewWithEnvironment(client.Environment)
 	filesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&filesClient.Client)
 	return &filesClient, nil
 }
 
@@ -245,6 +255,7 @@ func (client Client) FileSharesClient(ctx context.Context, account accountDetail
 
 	sharesClient := shares.NewWithEnvironment(client.Environment)
 	sharesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&sharesClient.Client)
 	shim := shim.NewDataPlaneStorageShareWrapper(&sharesClient)
 	return shim, nil
 }
@@ -253,6 +264,7 @@ func (client Client) QueuesClient(ctx context.Context, account accountDetails) (
 	if client.storageAdAuth != nil {
 		queueClient := queues.NewWithEnvironment(client.Environment)
 		queueClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&queueClient.Client)
 		return shim.NewDataPlaneStorageQueueWrapper(&queueClient), nil
 	}
 
@@ -268,6 +280,7 @@ func (client Client) QueuesClient(ctx context.Context, account accountDetails) (
 
 	queuesClient := queues.NewWithEnvironment(client.Environment)
 	queuesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&queuesClient.Client)
 	return shim.NewDataPlaneStorageQueueWrapper(&queuesClient), nil
 }
 
@@ -286,6 +299,7 @@ func (client Client) TableEntityClient(ctx context.Context, account accountDetai
 
 	entitiesClient := entities.NewWithEnvironment(client.Environment)
 	entitiesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&entitiesClient.Client)
 	return &entitiesClient, nil
 }
 
@@ -304,6 +318,15 @@ func (client Client) TablesClient(ctx context.Context, account accountDetails) (
 
 	tablesClient := tables.NewWithEnvironment(client.Environment)
 	tablesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&tablesClient.Client)
 	shim := shim.NewDataPlaneStorageTableWrapper(&tablesClient)
 	return shim, nil
 }
+
+// configureDataPlaneClient applies the Provider-level policies to a Data Plane client, since these are built
+// on-demand rather than using the ClientOptions
+func (client Client) configureDataPlaneClient(c *autorest.Client) {
+	if client.readOnlyPolicy != nil {
+		c.Sender = autorest.DecorateSender(c.Sender, common.WithReadOnlyPolicy(*client.readOnlyPolicy))
+	}
+}
@@ -36,11 +36,14 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 		"azurerm_storage_account":                    dataSourceStorageAccount(),
 		"azurerm_storage_blob":                       dataSourceStorageBlob(),
 		"azurerm_storage_container":                  dataSourceStorageContainer(),
+		"azurerm_storage_containers":                 dataSourceStorageContainers(),
 		"azurerm_storage_encryption_scope":           dataSourceStorageEncryptionScope(),
 		"azurerm_storage_management_policy":          dataSourceStorageManagementPolicy(),
+		"azurerm_storage_queue":                      dataSourceStorageQueue(),
 		"azurerm_storage_share":                      dataSourceStorageShare(),
 		"azurerm_storage_sync":                       dataSourceStorageSync(),
 		"azurerm_storage_sync_group":                 dataSourceStorageSyncGroup(),
+		"azurerm_storage_table":                      dataSourceStorageTable(),
 		"azurerm_storage_table_entity":               dataSourceStorageTableEntity(),
 	}
 }
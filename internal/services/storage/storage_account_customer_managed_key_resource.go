@@ -95,7 +95,9 @@ func resourceStorageAccountCustomerManagedKeyCreateUpdate(d *pluginsdk.ResourceD
 		return err
 	}
 
-	locks.ByName(storageAccountID.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, storageAccountID.Name, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(storageAccountID.Name, storageAccountResourceName)
 
 	storageAccount, err := storageClient.GetProperties(ctx, storageAccountID.ResourceGroup, storageAccountID.Name, "")
@@ -268,7 +270,9 @@ func resourceStorageAccountCustomerManagedKeyDelete(d *pluginsdk.ResourceData, m
 		return err
 	}
 
-	locks.ByName(storageAccountID.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, storageAccountID.Name, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(storageAccountID.Name, storageAccountResourceName)
 
 	// confirm it still exists prior to trying to update it, else we'll get an error
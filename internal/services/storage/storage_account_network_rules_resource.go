@@ -148,7 +148,9 @@ func resourceStorageAccountNetworkRulesCreateUpdate(d *pluginsdk.ResourceData, m
 		resourceGroup = parsedStorageAccountId.ResourceGroup
 	}
 
-	locks.ByName(storageAccountName, storageAccountResourceName)
+	if err := locks.ByName(ctx, storageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(storageAccountName, storageAccountResourceName)
 
 	storageAccount, err := client.GetProperties(ctx, resourceGroup, storageAccountName, "")
@@ -248,7 +250,9 @@ func resourceStorageAccountNetworkRulesDelete(d *pluginsdk.ResourceData, meta in
 		return err
 	}
 
-	locks.ByName(parsedStorageAccountNetworkRuleId.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, parsedStorageAccountNetworkRuleId.Name, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(parsedStorageAccountNetworkRuleId.Name, storageAccountResourceName)
 
 	storageAccount, err := client.GetProperties(ctx, parsedStorageAccountNetworkRuleId.ResourceGroup, parsedStorageAccountNetworkRuleId.Name, "")
@@ -1082,7 +1082,9 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 
 	id := parse.NewStorageAccountID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, id.Name, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, storageAccountResourceName)
 
 	existing, err := client.GetProperties(ctx, id.ResourceGroup, id.Name, "")
@@ -1444,7 +1446,9 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, id.Name, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, storageAccountResourceName)
 
 	accountTier := d.Get("account_tier").(string)
@@ -2222,7 +2226,9 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.Name, storageAccountResourceName)
+	if err := locks.ByName(ctx, id.Name, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, storageAccountResourceName)
 
 	read, err := client.GetProperties(ctx, id.ResourceGroup, id.Name, "")
@@ -2261,7 +2267,9 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 	resp, err := client.Delete(ctx, id.ResourceGroup, id.Name)
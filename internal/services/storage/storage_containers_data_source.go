@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2022-05-01/blobcontainers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func dataSourceStorageContainers() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceStorageContainersRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: commonids.ValidateStorageAccountID,
+			},
+
+			"name_prefix": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"containers": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"data_plane_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"resource_manager_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStorageContainersRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	// NOTE: the Data Plane API for Containers doesn't expose a List operation, so this uses the Resource Manager API
+	client := storageClient.ResourceManager.BlobContainers
+
+	accountId, err := commonids.ParseStorageAccountID(d.Get("storage_account_id").(string))
+	if err != nil {
+		return err
+	}
+
+	options := blobcontainers.DefaultListOperationOptions()
+	if v := d.Get("name_prefix").(string); v != "" {
+		// the `$filter` parameter only supports filtering on a prefix of the Container name
+		options.Filter = &v
+	}
+
+	resp, err := client.ListComplete(ctx, *accountId, options)
+	if err != nil {
+		return fmt.Errorf("listing Containers within %s: %+v", *accountId, err)
+	}
+
+	containers := make([]interface{}, 0)
+	for _, item := range resp.Items {
+		if item.Name == nil {
+			continue
+		}
+		name := *item.Name
+
+		dataPlaneId := parse.NewStorageContainerDataPlaneId(accountId.StorageAccountName, storageClient.Environment.StorageEndpointSuffix, name)
+		resourceManagerId := parse.NewStorageContainerResourceManagerID(accountId.SubscriptionId, accountId.ResourceGroupName, accountId.StorageAccountName, "default", name)
+
+		containers = append(containers, map[string]interface{}{
+			"name":                name,
+			"data_plane_id":       dataPlaneId.ID(),
+			"resource_manager_id": resourceManagerId.ID(),
+		})
+	}
+
+	d.SetId(accountId.ID())
+
+	if err := d.Set("containers", containers); err != nil {
+		return fmt.Errorf("setting `containers`: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type StorageContainersDataSource struct{}
+
+func TestAccDataSourceStorageContainers_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_containers", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageContainersDataSource{}.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("containers.#").HasValue("3"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceStorageContainers_namePrefix(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_containers", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageContainersDataSource{}.namePrefix(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("containers.#").HasValue("2"),
+				check.That(data.ResourceName).Key("containers.0.data_plane_id").Exists(),
+				check.That(data.ResourceName).Key("containers.0.resource_manager_id").Exists(),
+			),
+		},
+	})
+}
+
+func (d StorageContainersDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_storage_containers" "test" {
+  storage_account_id = azurerm_storage_account.test.id
+
+  depends_on = [
+    azurerm_storage_container.first,
+    azurerm_storage_container.second,
+    azurerm_storage_container.other,
+  ]
+}
+`, d.template(data))
+}
+
+func (d StorageContainersDataSource) namePrefix(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_storage_containers" "test" {
+  storage_account_id = azurerm_storage_account.test.id
+  name_prefix        = "prefix-"
+
+  depends_on = [
+    azurerm_storage_container.first,
+    azurerm_storage_container.second,
+    azurerm_storage_container.other,
+  ]
+}
+`, d.template(data))
+}
+
+func (d StorageContainersDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "containersdstest-%[1]s"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsadscs%[1]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "first" {
+  name                 = "prefix-first"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_storage_container" "second" {
+  name                 = "prefix-second"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_storage_container" "other" {
+  name                 = "other"
+  storage_account_name = azurerm_storage_account.test.name
+}
+`, data.RandomString, data.Locations.Primary)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func dataSourceStorageQueue() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceStorageQueueRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+
+			"storage_account_name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+
+			"metadata": MetaDataComputedSchema(),
+
+			"resource_manager_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStorageQueueRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	queueName := d.Get("name").(string)
+	accountName := d.Get("storage_account_name").(string)
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Queue %q: %s", accountName, queueName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("unable to locate Account %q for Queue %q", accountName, queueName)
+	}
+
+	client, err := storageClient.QueuesClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Queues Client for Storage Account %q (Resource Group %q): %s", accountName, account.ResourceGroup, err)
+	}
+
+	id := parse.NewStorageQueueDataPlaneId(accountName, storageClient.Environment.StorageEndpointSuffix, queueName).ID()
+
+	props, err := client.Get(ctx, account.ResourceGroup, accountName, queueName)
+	if err != nil {
+		return fmt.Errorf("retrieving Queue %q (Account %q / Resource Group %q): %s", queueName, accountName, account.ResourceGroup, err)
+	}
+	if props == nil {
+		return fmt.Errorf("queue %q was not found in Account %q / Resource Group %q", queueName, accountName, account.ResourceGroup)
+	}
+	d.SetId(id)
+
+	d.Set("name", queueName)
+	d.Set("storage_account_name", accountName)
+
+	if err := d.Set("metadata", FlattenMetaData(props.MetaData)); err != nil {
+		return fmt.Errorf("setting `metadata`: %+v", err)
+	}
+
+	resourceManagerId := parse.NewStorageQueueResourceManagerID(storageClient.SubscriptionId, account.ResourceGroup, accountName, "default", queueName)
+	d.Set("resource_manager_id", resourceManagerId.ID())
+
+	return nil
+}
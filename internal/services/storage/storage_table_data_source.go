@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func dataSourceStorageTable() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceStorageTableRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+
+			"storage_account_name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+
+			"acl": {
+				Type:     pluginsdk.TypeSet,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"access_policy": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"start": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+									"expiry": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+									"permissions": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStorageTableRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	tableName := d.Get("name").(string)
+	accountName := d.Get("storage_account_name").(string)
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", accountName, tableName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("unable to locate Account %q for Table %q", accountName, tableName)
+	}
+
+	client, err := storageClient.TablesClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Tables Client for Storage Account %q (Resource Group %q): %s", accountName, account.ResourceGroup, err)
+	}
+
+	id := parse.NewStorageTableDataPlaneId(accountName, storageClient.Environment.StorageEndpointSuffix, tableName).ID()
+
+	exists, err := client.Exists(ctx, account.ResourceGroup, accountName, tableName)
+	if err != nil {
+		return fmt.Errorf("retrieving Table %q (Account %q / Resource Group %q): %s", tableName, accountName, account.ResourceGroup, err)
+	}
+	if exists == nil || !*exists {
+		return fmt.Errorf("table %q was not found in Account %q / Resource Group %q", tableName, accountName, account.ResourceGroup)
+	}
+
+	acls, err := client.GetACLs(ctx, account.ResourceGroup, accountName, tableName)
+	if err != nil {
+		return fmt.Errorf("retrieving ACL's for Table %q (Account %q / Resource Group %q): %s", tableName, accountName, account.ResourceGroup, err)
+	}
+	d.SetId(id)
+
+	d.Set("name", tableName)
+	d.Set("storage_account_name", accountName)
+
+	if err := d.Set("acl", flattenStorageTableACLs(acls)); err != nil {
+		return fmt.Errorf("setting `acl`: %+v", err)
+	}
+
+	return nil
+}
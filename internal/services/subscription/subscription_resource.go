@@ -148,7 +148,9 @@ func resourceSubscriptionCreate(d *pluginsdk.ResourceData, meta interface{}) err
 		return tf.ImportAsExistsError("azurerm_subscription", id.ID())
 	}
 
-	locks.ByName(aliasName, SubscriptionResourceName)
+	if err := locks.ByName(ctx, aliasName, SubscriptionResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(aliasName, SubscriptionResourceName)
 
 	workload := subscriptionAlias.WorkloadProduction
@@ -169,7 +171,9 @@ func resourceSubscriptionCreate(d *pluginsdk.ResourceData, meta interface{}) err
 	if subscriptionIdRaw, ok := d.GetOk("subscription_id"); ok {
 		subscriptionId = subscriptionIdRaw.(string)
 
-		locks.ByID(subscriptionId)
+		if err := locks.ByID(ctx, subscriptionId); err != nil {
+			return err
+		}
 		defer locks.UnlockByID(subscriptionId)
 
 		// Terraform assumes a 1:1 mapping between a Subscription and an Alias - first check if there's any existing aliases
@@ -257,7 +261,9 @@ func resourceSubscriptionUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(id.AliasName, SubscriptionResourceName)
+	if err := locks.ByName(ctx, id.AliasName, SubscriptionResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AliasName, SubscriptionResourceName)
 	resp, err := aliasClient.AliasGet(ctx, *id)
 	if err != nil || resp.Model == nil || resp.Model.Properties == nil || resp.Model.Properties.SubscriptionId == nil {
@@ -267,7 +273,9 @@ func resourceSubscriptionUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 	subscriptionId := commonids.NewSubscriptionID(*resp.Model.Properties.SubscriptionId)
 
 	if d.HasChange("subscription_name") {
-		locks.ByID(subscriptionId.ID())
+		if err := locks.ByID(ctx, subscriptionId.ID()); err != nil {
+			return err
+		}
 		defer locks.UnlockByID(subscriptionId.ID())
 
 		displayName := subscriptionAlias.SubscriptionName{
@@ -368,7 +376,9 @@ func resourceSubscriptionDelete(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(id.AliasName, SubscriptionResourceName)
+	if err := locks.ByName(ctx, id.AliasName, SubscriptionResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AliasName, SubscriptionResourceName)
 
 	// Get subscription details for later
@@ -380,7 +390,9 @@ func resourceSubscriptionDelete(d *pluginsdk.ResourceData, meta interface{}) err
 	if subscriptionIdRaw := alias.Model.Properties.SubscriptionId; subscriptionIdRaw != nil {
 		subscriptionId = *subscriptionIdRaw
 	}
-	locks.ByID(subscriptionId)
+	if err := locks.ByID(ctx, subscriptionId); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(subscriptionId)
 
 	sub, err := client.Get(ctx, subscriptionId)
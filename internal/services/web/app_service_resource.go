@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/web/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/web/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
@@ -226,6 +227,54 @@ func resourceAppService() *pluginsdk.Resource {
 	}
 }
 
+// appServiceReplacementMigration describes how the `azurerm_app_service` resource can be migrated to the
+// `azurerm_linux_web_app` and `azurerm_windows_web_app` resources
+func appServiceReplacementMigration() sdk.ReplacementMigration {
+	renamedProperties := func() map[string]string {
+		return map[string]string{
+			"app_service_plan_id": "service_plan_id",
+			"client_cert_enabled": "client_certificate_enabled",
+			"client_cert_mode":    "client_certificate_mode",
+			"site_config.acr_use_managed_identity_credentials": "container_registry_use_managed_identity",
+			"site_config.acr_user_managed_identity_client_id":  "container_registry_managed_identity_client_id",
+			"site_config.min_tls_version":                      "minimum_tls_version",
+			"site_config.number_of_workers":                    "worker_count",
+			"site_config.use_32_bit_worker_process":            "use_32_bit_worker",
+		}
+	}
+	removedProperties := func() map[string]string {
+		return map[string]string{
+			"source_control":                       "source control is configured using the `azurerm_app_service_source_control` resource",
+			"site_config.auto_swap_slot_name":      "`auto_swap_slot_name` is only available within the `site_config` block of the Web App Slot resources",
+			"site_config.dotnet_framework_version": "the runtime is configured using the `application_stack` block within the `site_config` block",
+			"site_config.java_container":           "the runtime is configured using the `application_stack` block within the `site_config` block",
+			"site_config.java_container_version":   "the runtime is configured using the `application_stack` block within the `site_config` block",
+			"site_config.java_version":             "the runtime is configured using the `application_stack` block within the `site_config` block",
+			"site_config.linux_fx_version":         "the runtime is configured using the `application_stack` block within the `site_config` block",
+			"site_config.php_version":              "the runtime is configured using the `application_stack` block within the `site_config` block",
+			"site_config.python_version":           "the runtime is configured using the `application_stack` block within the `site_config` block",
+			"site_config.scm_type":                 "source control is configured using the `azurerm_app_service_source_control` resource",
+			"site_config.windows_fx_version":       "the runtime is configured using the `application_stack` block within the `site_config` block",
+		}
+	}
+
+	return sdk.ReplacementMigration{
+		Replacements: []sdk.ResourceReplacement{
+			{
+				ResourceType:      "azurerm_linux_web_app",
+				WhenPropertiesSet: []string{"site_config.linux_fx_version"},
+				RenamedProperties: renamedProperties(),
+				RemovedProperties: removedProperties(),
+			},
+			{
+				ResourceType:      "azurerm_windows_web_app",
+				RenamedProperties: renamedProperties(),
+				RemovedProperties: removedProperties(),
+			},
+		},
+	}
+}
+
 func resourceAppServiceCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Web.AppServicesClient
 	aspClient := meta.(*clients.Client).Web.AppServicePlansClient
@@ -97,7 +97,9 @@ func resourceAppServiceSlotCustomHostnameBindingCreate(d *pluginsdk.ResourceData
 
 	id := parse.NewAppServiceSlotCustomHostnameBindingID(slotId.SubscriptionId, slotId.ResourceGroup, slotId.SiteName, slotId.SlotName, hostname)
 
-	locks.ByName(hostname, appServiceSlotCustomHostnameBindingResourceName)
+	if err := locks.ByName(ctx, hostname, appServiceSlotCustomHostnameBindingResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(hostname, appServiceSlotCustomHostnameBindingResourceName)
 
 	existing, err := client.GetHostNameBindingSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName, id.HostNameBindingName)
@@ -177,7 +179,9 @@ func resourceAppServiceSlotCustomHostnameBindingDelete(d *pluginsdk.ResourceData
 		return err
 	}
 
-	locks.ByName(id.HostNameBindingName, appServiceSlotCustomHostnameBindingResourceName)
+	if err := locks.ByName(ctx, id.HostNameBindingName, appServiceSlotCustomHostnameBindingResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.HostNameBindingName, appServiceSlotCustomHostnameBindingResourceName)
 
 	log.Printf("[DEBUG] deleting %s", id)
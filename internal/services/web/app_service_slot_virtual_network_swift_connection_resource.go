@@ -97,10 +97,14 @@ func resourceAppServiceSlotVirtualNetworkSwiftConnectionCreateUpdate(d *pluginsd
 		}
 	}
 
-	locks.ByName(virtualNetworkName, network.VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, virtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualNetworkName, network.VirtualNetworkResourceName)
 
-	locks.ByName(subnetName, network.SubnetResourceName)
+	if err := locks.ByName(ctx, subnetName, network.SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(subnetName, network.SubnetResourceName)
 
 	appServiceExists, err := client.Get(ctx, resourceGroup, name)
@@ -229,10 +233,14 @@ func resourceAppServiceSlotVirtualNetworkSwiftConnectionDelete(d *pluginsdk.Reso
 	subnetName := subnetID.SubnetName
 	virtualNetworkName := subnetID.VirtualNetworkName
 
-	locks.ByName(virtualNetworkName, network.VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, virtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualNetworkName, network.VirtualNetworkResourceName)
 
-	locks.ByName(subnetName, network.SubnetResourceName)
+	if err := locks.ByName(ctx, subnetName, network.SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(subnetName, network.SubnetResourceName)
 
 	read, err := client.GetSwiftVirtualNetworkConnectionSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
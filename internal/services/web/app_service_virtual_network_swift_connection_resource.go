@@ -91,10 +91,14 @@ func resourceAppServiceVirtualNetworkSwiftConnectionCreateUpdate(d *pluginsdk.Re
 		}
 	}
 
-	locks.ByName(virtualNetworkName, network.VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, virtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualNetworkName, network.VirtualNetworkResourceName)
 
-	locks.ByName(subnetName, network.SubnetResourceName)
+	if err := locks.ByName(ctx, subnetName, network.SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(subnetName, network.SubnetResourceName)
 
 	exists, err := client.Get(ctx, resourceGroup, name)
@@ -206,10 +210,14 @@ func resourceAppServiceVirtualNetworkSwiftConnectionDelete(d *pluginsdk.Resource
 	subnetName := subnetID.SubnetName
 	virtualNetworkName := subnetID.VirtualNetworkName
 
-	locks.ByName(virtualNetworkName, network.VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, virtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualNetworkName, network.VirtualNetworkResourceName)
 
-	locks.ByName(subnetName, network.SubnetResourceName)
+	if err := locks.ByName(ctx, subnetName, network.SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(subnetName, network.SubnetResourceName)
 
 	read, err := client.GetSwiftVirtualNetworkConnection(ctx, id.ResourceGroup, id.SiteName)
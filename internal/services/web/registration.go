@@ -10,6 +10,8 @@ import (
 
 type Registration struct{}
 
+var _ sdk.UntypedServiceRegistrationWithReplacementMigrations = Registration{}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Web"
@@ -72,3 +74,10 @@ func (r Registration) Resources() []sdk.Resource {
 		AppServiceEnvironmentV3Resource{},
 	}
 }
+
+// ReplacementMigrations returns the ReplacementMigration for each deprecated Resource within this Service
+func (r Registration) ReplacementMigrations() map[string]sdk.ReplacementMigration {
+	return map[string]sdk.ReplacementMigration{
+		"azurerm_app_service": appServiceReplacementMigration(),
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweepers
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/sweep"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+)
+
+// Registry returns a Registry containing the Sweepers for each Service Package which implements
+// the `sweep.ServiceRegistration` interface
+func Registry() (*sweep.Registry, error) {
+	registry := sweep.NewRegistry()
+
+	services := make([]interface{}, 0)
+	for _, service := range provider.SupportedTypedServices() {
+		services = append(services, service)
+	}
+	for _, service := range provider.SupportedUntypedServices() {
+		services = append(services, service)
+	}
+
+	// a Service Registration can be both Typed and Untyped, so each is only registered once
+	seen := make(map[string]struct{})
+	for _, service := range services {
+		v, ok := service.(sweep.ServiceRegistration)
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%T", service)
+		if _, exists := seen[key]; exists {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		if err := registry.Register(v.Sweepers()...); err != nil {
+			return nil, fmt.Errorf("registering the Sweepers for %T: %+v", service, err)
+		}
+	}
+
+	return registry, nil
+}
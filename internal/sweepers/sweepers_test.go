@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweepers
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/sweep"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
+)
+
+var (
+	flagSweep       = flag.Bool("sweep", false, "delete the Resources left behind by the Acceptance Tests, rather than running the tests")
+	flagSweepRun    = flag.String("sweep-run", "", "a comma-separated list of the Sweepers to run, defaults to all of the Sweepers")
+	flagSweepPrefix = flag.String("sweep-prefix", strings.Join(sweep.DefaultPrefixes, ","), "a comma-separated list of the name prefixes which Resources must match to be swept")
+	flagSweepMinAge = flag.Duration("sweep-min-age", sweep.DefaultMinimumAge, "how long ago a Resource must have been created to be swept, so that in-progress test runs are unaffected")
+	flagSweepLimit  = flag.Int("sweep-parallelism", sweep.DefaultParallelism, "the maximum number of Resources to delete at once")
+	flagSweepDryRun = flag.Bool("sweep-dry-run", false, "only output the Resources which would be swept, rather than deleting them")
+)
+
+// TestMain runs the Sweepers when `-sweep` is specified, for example:
+//
+//	go test ./internal/sweepers -v -sweep -sweep-dry-run
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !*flagSweep {
+		os.Exit(m.Run())
+	}
+
+	if err := runSweepers(context.Background()); err != nil {
+		log.Printf("[ERROR] %+v", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func runSweepers(ctx context.Context) error {
+	registry, err := Registry()
+	if err != nil {
+		return fmt.Errorf("building the Sweeper Registry: %+v", err)
+	}
+
+	sweepers, err := registry.Sweepers(splitFlag(*flagSweepRun)...)
+	if err != nil {
+		return err
+	}
+
+	client, err := testclient.Build()
+	if err != nil {
+		return fmt.Errorf("building client: %+v", err)
+	}
+
+	options := sweep.Options{
+		Prefixes:    splitFlag(*flagSweepPrefix),
+		MinimumAge:  *flagSweepMinAge,
+		Parallelism: *flagSweepLimit,
+		DryRun:      *flagSweepDryRun,
+	}
+	result, err := sweep.Run(ctx, client, sweepers, options)
+	if result != nil {
+		action := "Deleted"
+		if options.DryRun {
+			action = "Would delete"
+		}
+		for _, v := range result.Swept {
+			fmt.Printf("%s %q (Sweeper %q)\n", action, v.Resource.ID, v.Sweeper)
+		}
+		fmt.Printf("%s %d Resources, skipped %d Resources\n", action, len(result.Swept), result.Skipped)
+	}
+	return err
+}
+
+func TestRegistry(t *testing.T) {
+	if _, err := Registry(); err != nil {
+		t.Fatalf("building the Sweeper Registry: %+v", err)
+	}
+}
+
+func splitFlag(input string) []string {
+	output := make([]string, 0)
+	for _, v := range strings.Split(input, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			output = append(output, v)
+		}
+	}
+	return output
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"strings"
+)
+
+// IgnoreConfig defines the Tags which should be ignored when reading Tags from the API, for example
+// Tags which are applied outside of Terraform by Azure Policy
+type IgnoreConfig struct {
+	// Keys is a list of Tag keys which should be ignored, these are matched case-insensitively
+	Keys []string
+
+	// KeyPrefixes is a list of Tag key prefixes which should be ignored, these are matched case-insensitively
+	KeyPrefixes []string
+}
+
+// Config is the provider-level Tags configuration for a single (potentially aliased) provider block, which is
+// held on the Provider's meta rather than globally, so that each provider block applies only its own Tags
+type Config struct {
+	// DefaultTags are merged into the Tags planned (and sent to the API) for each Resource
+	DefaultTags map[string]string
+
+	// IgnoreTags are removed from the Tags returned from the API for each Resource and Data Source
+	IgnoreTags IgnoreConfig
+}
+
+// IsEmpty returns whether neither Default Tags nor Ignore Tags are configured
+func (c Config) IsEmpty() bool {
+	return len(c.DefaultTags) == 0 && len(c.IgnoreTags.Keys) == 0 && len(c.IgnoreTags.KeyPrefixes) == 0
+}
+
+// MergeDefaultTags returns a copy of the Default Tags merged with the specified Tags, where a value
+// specified in input takes precedence over a Default Tag with the same (case-insensitive) key
+func (c Config) MergeDefaultTags(input map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(c.DefaultTags)+len(input))
+	for k, v := range c.DefaultTags {
+		if existingKey(input, k) == "" {
+			output[k] = v
+		}
+	}
+	for k, v := range input {
+		output[k] = v
+	}
+
+	return output
+}
+
+// EffectiveTags returns the Tags which should be assigned to a Resource, being the configured Tags merged with
+// the Default Tags - omitting any ignored Tags, since these are omitted when the Tags are read from the API
+func (c Config) EffectiveTags(configured map[string]interface{}) map[string]interface{} {
+	return c.FilterIgnored(c.MergeDefaultTags(configured))
+}
+
+// FilterIgnored returns the Tags read from the API, omitting any ignored Tags
+func (c Config) FilterIgnored(input map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(input))
+	for k, v := range input {
+		if !c.IsIgnored(k) {
+			output[k] = v
+		}
+	}
+
+	return output
+}
+
+// IsIgnored returns whether the Tag with the specified key should be omitted when read from the API
+func (c Config) IsIgnored(key string) bool {
+	lowered := strings.ToLower(key)
+	for _, k := range c.IgnoreTags.Keys {
+		if strings.ToLower(k) == lowered {
+			return true
+		}
+	}
+	for _, prefix := range c.IgnoreTags.KeyPrefixes {
+		if prefix != "" && strings.HasPrefix(lowered, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// existingKey returns the key within input which matches key case-insensitively, if any
+func existingKey[T any](input map[string]T, key string) string {
+	for k := range input {
+		if strings.EqualFold(k, key) {
+			return k
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigMergeDefaultTags(t *testing.T) {
+	config := Config{
+		DefaultTags: map[string]string{
+			"cost-center": "1234",
+			"owner":       "platform",
+		},
+	}
+
+	testData := []struct {
+		Name     string
+		Input    map[string]interface{}
+		Expected map[string]interface{}
+	}{
+		{
+			Name:  "Empty",
+			Input: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"cost-center": "1234",
+				"owner":       "platform",
+			},
+		},
+		{
+			Name: "Additional Tag",
+			Input: map[string]interface{}{
+				"hello": "there",
+			},
+			Expected: map[string]interface{}{
+				"cost-center": "1234",
+				"hello":       "there",
+				"owner":       "platform",
+			},
+		},
+		{
+			Name: "Overridden Default Tag",
+			Input: map[string]interface{}{
+				"Owner": "networking",
+			},
+			Expected: map[string]interface{}{
+				"cost-center": "1234",
+				"Owner":       "networking",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := config.MergeDefaultTags(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestConfigEffectiveTags(t *testing.T) {
+	config := Config{
+		DefaultTags: map[string]string{
+			"owner": "platform",
+		},
+		IgnoreTags: IgnoreConfig{
+			Keys:        []string{"CreatedBy"},
+			KeyPrefixes: []string{"policy-"},
+		},
+	}
+
+	testData := []struct {
+		Name       string
+		Configured map[string]interface{}
+		Expected   map[string]interface{}
+	}{
+		{
+			Name:       "Nothing Configured",
+			Configured: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"owner": "platform",
+			},
+		},
+		{
+			Name: "Ignored Key",
+			Configured: map[string]interface{}{
+				"createdby": "someone",
+				"hello":     "there",
+			},
+			Expected: map[string]interface{}{
+				"hello": "there",
+				"owner": "platform",
+			},
+		},
+		{
+			Name: "Ignored Key Prefix",
+			Configured: map[string]interface{}{
+				"Policy-Assignment": "abc123",
+			},
+			Expected: map[string]interface{}{
+				"owner": "platform",
+			},
+		},
+		{
+			Name: "Default Tag Overridden",
+			Configured: map[string]interface{}{
+				"Owner": "networking",
+			},
+			Expected: map[string]interface{}{
+				"Owner": "networking",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := config.EffectiveTags(v.Configured)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestConfigFilterIgnored(t *testing.T) {
+	config := Config{
+		DefaultTags: map[string]string{
+			"owner": "platform",
+		},
+		IgnoreTags: IgnoreConfig{
+			Keys: []string{"CreatedBy"},
+		},
+	}
+
+	input := map[string]interface{}{
+		"createdBy": "someone",
+		"owner":     "platform",
+	}
+	expected := map[string]interface{}{
+		"owner": "platform",
+	}
+
+	// Default Tags are real Tags on the resource, so they're returned as-is
+	if actual := config.FilterIgnored(input); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package timeouts
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// ResourceTimeouts defines the default timeouts for a Resource Type, configured using the `default_timeouts`
+// block within the Provider. Any operation without a value uses the built-in default for that Resource.
+type ResourceTimeouts struct {
+	Create *time.Duration
+	Read   *time.Duration
+	Update *time.Duration
+	Delete *time.Duration
+}
+
+// ApplyDefaults returns a copy of resources where the built-in default timeouts for each Resource Type are
+// replaced with those configured in the Provider. Each Resource with configured defaults is copied rather
+// than modified, so that the Resources (and their Timeouts) registered for the Provider are left unchanged.
+//
+// Since the defaults are resolved when the Resource is planned, the values within a `timeouts` block in the
+// Resource continue to take precedence - and `ForCreate`, `ForRead`, `ForUpdate` and `ForDelete` (alongside the
+// Typed SDK, which uses the same timeouts) use these defaults when no `timeouts` block is specified.
+func ApplyDefaults(resources map[string]*pluginsdk.Resource, defaults map[string]ResourceTimeouts) (map[string]*pluginsdk.Resource, error) {
+	output := make(map[string]*pluginsdk.Resource, len(resources))
+	for resourceType, resource := range resources {
+		output[resourceType] = resource
+	}
+
+	resourceTypes := make([]string, 0)
+	for resourceType := range defaults {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	for _, resourceType := range resourceTypes {
+		resource, ok := resources[resourceType]
+		if !ok {
+			return nil, fmt.Errorf("the Resource Type %q is not supported by this Provider", resourceType)
+		}
+		if resource.Timeouts == nil {
+			return nil, fmt.Errorf("the Resource Type %q doesn't support configuring timeouts", resourceType)
+		}
+
+		updated := *resource.Timeouts
+		input := defaults[resourceType]
+
+		if input.Create != nil {
+			if updated.Create == nil {
+				return nil, fmt.Errorf("the Resource Type %q doesn't support a `create` timeout", resourceType)
+			}
+			updated.Create = input.Create
+		}
+		if input.Read != nil {
+			if updated.Read == nil {
+				return nil, fmt.Errorf("the Resource Type %q doesn't support a `read` timeout", resourceType)
+			}
+			updated.Read = input.Read
+		}
+		if input.Update != nil {
+			if updated.Update == nil {
+				return nil, fmt.Errorf("the Resource Type %q doesn't support a `update` timeout", resourceType)
+			}
+			updated.Update = input.Update
+		}
+		if input.Delete != nil {
+			if updated.Delete == nil {
+				return nil, fmt.Errorf("the Resource Type %q doesn't support a `delete` timeout", resourceType)
+			}
+			updated.Delete = input.Delete
+		}
+
+		copied := *resource
+		copied.Timeouts = &updated
+		output[resourceType] = &copied
+	}
+
+	return output, nil
+}
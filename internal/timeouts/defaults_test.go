@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package timeouts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	duration := func(input time.Duration) *time.Duration {
+		return &input
+	}
+	resources := func() map[string]*pluginsdk.Resource {
+		return map[string]*pluginsdk.Resource{
+			"azurerm_example": {
+				Timeouts: &pluginsdk.ResourceTimeout{
+					Create: duration(30 * time.Minute),
+					Read:   duration(5 * time.Minute),
+					Update: duration(30 * time.Minute),
+					Delete: duration(30 * time.Minute),
+				},
+			},
+			"azurerm_no_update": {
+				Timeouts: &pluginsdk.ResourceTimeout{
+					Create: duration(30 * time.Minute),
+					Read:   duration(5 * time.Minute),
+					Delete: duration(30 * time.Minute),
+				},
+			},
+		}
+	}
+
+	testData := []struct {
+		Name     string
+		Input    map[string]ResourceTimeouts
+		Expected *pluginsdk.ResourceTimeout
+		Error    bool
+	}{
+		{
+			Name:  "No Defaults",
+			Input: map[string]ResourceTimeouts{},
+			Expected: &pluginsdk.ResourceTimeout{
+				Create: duration(30 * time.Minute),
+				Read:   duration(5 * time.Minute),
+				Update: duration(30 * time.Minute),
+				Delete: duration(30 * time.Minute),
+			},
+		},
+		{
+			Name: "Some Defaults",
+			Input: map[string]ResourceTimeouts{
+				"azurerm_example": {
+					Create: duration(2 * time.Hour),
+					Delete: duration(90 * time.Minute),
+				},
+			},
+			Expected: &pluginsdk.ResourceTimeout{
+				Create: duration(2 * time.Hour),
+				Read:   duration(5 * time.Minute),
+				Update: duration(30 * time.Minute),
+				Delete: duration(90 * time.Minute),
+			},
+		},
+		{
+			Name: "Unknown Resource Type",
+			Input: map[string]ResourceTimeouts{
+				"azurerm_unknown": {
+					Create: duration(2 * time.Hour),
+				},
+			},
+			Error: true,
+		},
+		{
+			Name: "Unsupported Operation",
+			Input: map[string]ResourceTimeouts{
+				"azurerm_no_update": {
+					Update: duration(2 * time.Hour),
+				},
+			},
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		input := resources()
+		builtIn := input["azurerm_example"]
+		output, err := ApplyDefaults(input, v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		actual := output["azurerm_example"].Timeouts
+		if *actual.Create != *v.Expected.Create || *actual.Read != *v.Expected.Read || *actual.Update != *v.Expected.Update || *actual.Delete != *v.Expected.Delete {
+			t.Fatalf("expected %+v but got %+v", *v.Expected, *actual)
+		}
+		if input["azurerm_example"] != builtIn || *builtIn.Timeouts.Create != 30*time.Minute {
+			t.Fatalf("expected the registered Resource not to be modified but got %s", *builtIn.Timeouts.Create)
+		}
+	}
+}
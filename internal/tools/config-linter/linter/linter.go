@@ -0,0 +1,335 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package linter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type FindingType string
+
+const (
+	// FindingTypeDeprecatedResource is a Resource or Data Source which has been deprecated
+	FindingTypeDeprecatedResource FindingType = "deprecatedResource"
+
+	// FindingTypeDeprecatedProperty is a property which has been deprecated
+	FindingTypeDeprecatedProperty FindingType = "deprecatedProperty"
+
+	// FindingTypeRemovedInFourPointOh is a Resource, Data Source or property which is removed in 4.0
+	FindingTypeRemovedInFourPointOh FindingType = "removedInFourPointOh"
+)
+
+// Finding is a use of a deprecated or removed Resource, Data Source or property within the configuration
+type Finding struct {
+	Type     FindingType `json:"type"`
+	FileName string      `json:"fileName"`
+	Line     int         `json:"line"`
+	Column   int         `json:"column"`
+
+	// Address is the address of the Resource or Data Source within the configuration, e.g. `azurerm_example.test`
+	// or `data.azurerm_example.test`
+	Address string `json:"address"`
+
+	// Property is the path to the property within the Resource or Data Source, e.g. `network_rules.bypass`, which
+	// is empty when the Finding applies to the Resource or Data Source itself
+	Property string `json:"property,omitempty"`
+
+	// ReplacedBy is the Resource which should be used instead, when known
+	ReplacedBy string `json:"replacedBy,omitempty"`
+
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	address := f.Address
+	if f.Property != "" {
+		address += "." + f.Property
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s", f.FileName, f.Line, f.Column, address, f.Message)
+}
+
+// Schema is the schema of the Provider used to lint the configuration
+type Schema struct {
+	Resources   map[string]*pluginsdk.Resource
+	DataSources map[string]*pluginsdk.Resource
+
+	// ReplacedBy is the Resource replacing each deprecated Resource, for those implementing `ResourceWithDeprecationReplacedBy`
+	ReplacedBy map[string]string
+
+	// FourPointOhResources and FourPointOhDataSources are the Resources and Data Sources available when the Provider is
+	// running in 4.0 mode - which are used to report uses of Resources, Data Sources and properties which are removed
+	// or deprecated in 4.0. When nil, these aren't reported.
+	FourPointOhResources   map[string]*pluginsdk.Resource
+	FourPointOhDataSources map[string]*pluginsdk.Resource
+}
+
+type Linter struct {
+	schema Schema
+}
+
+func NewLinter(schema Schema) Linter {
+	return Linter{
+		schema: schema,
+	}
+}
+
+// LintDirectory lints each Terraform Configuration file (`*.tf`) within the directory and any nested directories,
+// other than hidden directories (e.g. `.terraform`)
+func (l Linter) LintDirectory(directory string) ([]Finding, error) {
+	findings := make([]Finding, 0)
+	err := filepath.WalkDir(directory, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != directory && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(path) != ".tf" {
+			return nil
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %q: %+v", path, err)
+		}
+
+		fileFindings, err := l.LintFile(path, contents)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, fileFindings...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortFindings(findings)
+	return findings, nil
+}
+
+// LintFile lints the contents of a single Terraform Configuration file
+func (l Linter) LintFile(fileName string, contents []byte) ([]Finding, error) {
+	file, diags := hclsyntax.ParseConfig(contents, fileName, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %q: %s", fileName, diags.Error())
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("parsing %q: unexpected body type %T", fileName, file.Body)
+	}
+
+	findings := make([]Finding, 0)
+	for _, block := range body.Blocks {
+		if len(block.Labels) != 2 {
+			continue
+		}
+
+		var resource *pluginsdk.Resource
+		var fourPointOh map[string]*pluginsdk.Resource
+		address := fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+		kind := "Resource"
+		switch block.Type {
+		case "resource":
+			resource = l.schema.Resources[block.Labels[0]]
+			fourPointOh = l.schema.FourPointOhResources
+		case "data":
+			resource = l.schema.DataSources[block.Labels[0]]
+			fourPointOh = l.schema.FourPointOhDataSources
+			address = "data." + address
+			kind = "Data Source"
+		default:
+			continue
+		}
+		if resource == nil {
+			// either not a resource within this Provider, or this resource is unknown - either way there's nothing to lint
+			continue
+		}
+
+		c := blockChecker{
+			fileName: fileName,
+			address:  address,
+		}
+
+		replacedBy := l.schema.ReplacedBy[block.Labels[0]]
+		replacement := ""
+		if replacedBy != "" {
+			replacement = fmt.Sprintf(" - the %q resource should be used instead", replacedBy)
+		}
+
+		var fourPointOhSchema map[string]*pluginsdk.Schema
+		fourPointOhDeprecationMessage := ""
+		if fourPointOh != nil {
+			fourPointOhResource, ok := fourPointOh[block.Labels[0]]
+			if !ok {
+				finding := c.finding(block.TypeRange, FindingTypeRemovedInFourPointOh, "", fmt.Sprintf("the %s %q will be removed in version 4.0 of the AzureRM Provider%s", kind, block.Labels[0], replacement))
+				finding.ReplacedBy = replacedBy
+				findings = append(findings, finding)
+				continue
+			}
+			c.fourPointOh = true
+			fourPointOhSchema = fourPointOhResource.Schema
+			fourPointOhDeprecationMessage = fourPointOhResource.DeprecationMessage
+		}
+
+		if resource.DeprecationMessage != "" {
+			message := fmt.Sprintf("the %s %q has been deprecated%s", kind, block.Labels[0], replacement)
+			if replacedBy == "" {
+				message = fmt.Sprintf("%s: %s", message, strings.TrimSpace(resource.DeprecationMessage))
+			}
+			finding := c.finding(block.TypeRange, FindingTypeDeprecatedResource, "", message)
+			finding.ReplacedBy = replacedBy
+			findings = append(findings, finding)
+		} else if fourPointOhDeprecationMessage != "" {
+			message := fmt.Sprintf("the %s %q is deprecated in version 4.0 of the AzureRM Provider%s", kind, block.Labels[0], replacement)
+			if replacedBy == "" {
+				message = fmt.Sprintf("%s: %s", message, strings.TrimSpace(fourPointOhDeprecationMessage))
+			}
+			finding := c.finding(block.TypeRange, FindingTypeDeprecatedResource, "", message)
+			finding.ReplacedBy = replacedBy
+			findings = append(findings, finding)
+		}
+
+		findings = append(findings, c.checkBody("", block.Body, resource.Schema, fourPointOhSchema)...)
+	}
+
+	sortFindings(findings)
+	return findings, nil
+}
+
+type blockChecker struct {
+	fileName string
+	address  string
+
+	// fourPointOh specifies whether the schema of the Provider running in 4.0 mode is available
+	fourPointOh bool
+}
+
+// checkBody checks the attributes and blocks within the body against the schema, and the equivalent schema when the
+// Provider is running in 4.0 mode (which is nil if the block is removed in 4.0, or the 4.0 schema isn't available)
+func (c blockChecker) checkBody(parentPath string, body *hclsyntax.Body, schema map[string]*pluginsdk.Schema, fourPointOhSchema map[string]*pluginsdk.Schema) []Finding {
+	findings := make([]Finding, 0)
+
+	for name, attribute := range body.Attributes {
+		if v, ok := schema[name]; ok {
+			findings = append(findings, c.checkProperty(joinPath(parentPath, name), attribute.NameRange, v, fourPointOhSchema[name])...)
+		}
+	}
+
+	for _, block := range body.Blocks {
+		name := block.Type
+		nestedBody := block.Body
+		if block.Type == "dynamic" {
+			// the content of a dynamic block is nested within the `content` block
+			if len(block.Labels) != 1 {
+				continue
+			}
+			name = block.Labels[0]
+			nestedBody = nil
+			for _, b := range block.Body.Blocks {
+				if b.Type == "content" {
+					nestedBody = b.Body
+				}
+			}
+		}
+
+		v, ok := schema[name]
+		if !ok {
+			// e.g. `lifecycle` or `timeouts`
+			continue
+		}
+
+		path := joinPath(parentPath, name)
+		fourPointOhV := fourPointOhSchema[name]
+		propertyFindings := c.checkProperty(path, block.TypeRange, v, fourPointOhV)
+		findings = append(findings, propertyFindings...)
+
+		nested, ok := v.Elem.(*pluginsdk.Resource)
+		if !ok || nestedBody == nil {
+			continue
+		}
+		if c.fourPointOh && fourPointOhV == nil {
+			// the removal of the block has been reported, which covers the properties within it
+			continue
+		}
+
+		var fourPointOhNested map[string]*pluginsdk.Schema
+		if fourPointOhV != nil {
+			if elem, ok := fourPointOhV.Elem.(*pluginsdk.Resource); ok {
+				fourPointOhNested = elem.Schema
+			}
+		}
+		findings = append(findings, c.checkBody(path, nestedBody, nested.Schema, fourPointOhNested)...)
+	}
+
+	return findings
+}
+
+// checkProperty checks the property against its schema, and the equivalent schema when the Provider is running in
+// 4.0 mode - which is nil if the property is removed in 4.0, or the 4.0 schema isn't available
+func (c blockChecker) checkProperty(path string, r hcl.Range, v *pluginsdk.Schema, fourPointOh *pluginsdk.Schema) []Finding {
+	if c.fourPointOh && fourPointOh == nil {
+		message := fmt.Sprintf("the property %q will be removed in version 4.0 of the AzureRM Provider", path)
+		if v.Deprecated != "" {
+			message = fmt.Sprintf("%s: %s", message, v.Deprecated)
+		}
+		return []Finding{c.finding(r, FindingTypeRemovedInFourPointOh, path, message)}
+	}
+
+	if v.Deprecated != "" {
+		return []Finding{c.finding(r, FindingTypeDeprecatedProperty, path, fmt.Sprintf("the property %q has been deprecated: %s", path, v.Deprecated))}
+	}
+
+	// properties deprecated using `features.DeprecatedInFourPointOh` are only deprecated in the 4.0 schema
+	if fourPointOh != nil && fourPointOh.Deprecated != "" {
+		return []Finding{c.finding(r, FindingTypeDeprecatedProperty, path, fmt.Sprintf("the property %q is deprecated in version 4.0 of the AzureRM Provider: %s", path, fourPointOh.Deprecated))}
+	}
+
+	return nil
+}
+
+func (c blockChecker) finding(r hcl.Range, findingType FindingType, path string, message string) Finding {
+	return Finding{
+		Type:     findingType,
+		FileName: c.fileName,
+		Line:     r.Start.Line,
+		Column:   r.Start.Column,
+		Address:  c.address,
+		Property: path,
+		Message:  message,
+	}
+}
+
+func joinPath(parentPath string, name string) string {
+	if parentPath == "" {
+		return name
+	}
+	return parentPath + "." + name
+}
+
+func sortFindings(input []Finding) {
+	sort.SliceStable(input, func(i, j int) bool {
+		if input[i].FileName != input[j].FileName {
+			return input[i].FileName < input[j].FileName
+		}
+		if input[i].Line != input[j].Line {
+			return input[i].Line < input[j].Line
+		}
+		return input[i].Column < input[j].Column
+	})
+}
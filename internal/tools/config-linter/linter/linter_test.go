@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package linter
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func testSchema() Schema {
+	return Schema{
+		Resources: map[string]*pluginsdk.Resource{
+			"azurerm_example": {
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+					},
+					"sku": {
+						Type:       pluginsdk.TypeString,
+						Optional:   true,
+						Deprecated: "`sku` has been deprecated in favour of `sku_name`",
+					},
+					"network_rules": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"default_action": {
+									Type:     pluginsdk.TypeString,
+									Optional: true,
+								},
+								"bypass": {
+									Type:     pluginsdk.TypeString,
+									Optional: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"azurerm_legacy_example": {
+				DeprecationMessage: "the `azurerm_legacy_example` resource has been deprecated",
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+					},
+				},
+			},
+		},
+		DataSources: map[string]*pluginsdk.Resource{
+			"azurerm_example": {
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+					},
+					"sku": {
+						Type:       pluginsdk.TypeString,
+						Computed:   true,
+						Deprecated: "`sku` has been deprecated in favour of `sku_name`",
+					},
+				},
+			},
+		},
+		ReplacedBy: map[string]string{
+			"azurerm_legacy_example": "azurerm_example",
+		},
+	}
+}
+
+func TestLintFile(t *testing.T) {
+	// in 4.0 `network_rules.bypass` and `azurerm_legacy_example` are removed, and `network_rules.default_action`
+	// is deprecated (using `features.DeprecatedInFourPointOh`)
+	fourPointOhSchema := testSchema()
+	delete(fourPointOhSchema.Resources, "azurerm_legacy_example")
+	networkRules := fourPointOhSchema.Resources["azurerm_example"].Schema["network_rules"].Elem.(*pluginsdk.Resource)
+	delete(networkRules.Schema, "bypass")
+	networkRules.Schema["default_action"].Deprecated = "`default_action` will be removed in favour of `action`"
+
+	fourPointOh := testSchema()
+	fourPointOh.FourPointOhResources = fourPointOhSchema.Resources
+	fourPointOh.FourPointOhDataSources = fourPointOhSchema.DataSources
+
+	config := `
+resource "azurerm_example" "test" {
+  name = "example"
+  sku  = "Standard"
+
+  network_rules {
+    default_action = "Deny"
+    bypass         = "AzureServices"
+  }
+
+  lifecycle {
+    ignore_changes = [name]
+  }
+}
+
+resource "azurerm_legacy_example" "test" {
+  name = "example"
+}
+
+data "azurerm_example" "test" {
+  name = "example"
+}
+
+resource "other_example" "test" {
+  sku = "Standard"
+}
+`
+
+	testData := []struct {
+		Name     string
+		Schema   Schema
+		Expected []Finding
+	}{
+		{
+			Name:   "Deprecations",
+			Schema: testSchema(),
+			Expected: []Finding{
+				{
+					Type:     FindingTypeDeprecatedProperty,
+					Line:     4,
+					Address:  "azurerm_example.test",
+					Property: "sku",
+				},
+				{
+					Type:       FindingTypeDeprecatedResource,
+					Line:       16,
+					Address:    "azurerm_legacy_example.test",
+					ReplacedBy: "azurerm_example",
+				},
+			},
+		},
+		{
+			Name:   "Removed or Deprecated in 4.0",
+			Schema: fourPointOh,
+			Expected: []Finding{
+				{
+					Type:     FindingTypeDeprecatedProperty,
+					Line:     4,
+					Address:  "azurerm_example.test",
+					Property: "sku",
+				},
+				{
+					Type:     FindingTypeDeprecatedProperty,
+					Line:     7,
+					Address:  "azurerm_example.test",
+					Property: "network_rules.default_action",
+				},
+				{
+					Type:     FindingTypeRemovedInFourPointOh,
+					Line:     8,
+					Address:  "azurerm_example.test",
+					Property: "network_rules.bypass",
+				},
+				{
+					Type:       FindingTypeRemovedInFourPointOh,
+					Line:       16,
+					Address:    "azurerm_legacy_example.test",
+					ReplacedBy: "azurerm_example",
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual, err := NewLinter(v.Schema).LintFile("main.tf", []byte(config))
+		if err != nil {
+			t.Fatalf("linting: %+v", err)
+		}
+
+		if len(actual) != len(v.Expected) {
+			t.Fatalf("expected %d findings but got %d: %+v", len(v.Expected), len(actual), actual)
+		}
+		for i, expected := range v.Expected {
+			if actual[i].Type != expected.Type || actual[i].Line != expected.Line || actual[i].Address != expected.Address || actual[i].Property != expected.Property || actual[i].ReplacedBy != expected.ReplacedBy {
+				t.Fatalf("expected finding %d to be %+v but got %+v", i, expected, actual[i])
+			}
+		}
+	}
+}
+
+func TestLintFileDynamicBlock(t *testing.T) {
+	config := `
+resource "azurerm_example" "test" {
+  name = "example"
+
+  dynamic "network_rules" {
+    for_each = var.network_rules
+    content {
+      bypass = network_rules.value
+    }
+  }
+}
+`
+
+	schema := testSchema()
+	schema.Resources["azurerm_example"].Schema["network_rules"].Elem.(*pluginsdk.Resource).Schema["bypass"].Deprecated = "`bypass` has been deprecated"
+
+	actual, err := NewLinter(schema).LintFile("main.tf", []byte(config))
+	if err != nil {
+		t.Fatalf("linting: %+v", err)
+	}
+
+	if len(actual) != 1 || actual[0].Property != "network_rules.bypass" || actual[0].Line != 8 {
+		t.Fatalf("expected a single finding for `network_rules.bypass` on line 8 but got %+v", actual)
+	}
+}
+
+func TestLintFileInvalid(t *testing.T) {
+	if _, err := NewLinter(testSchema()).LintFile("main.tf", []byte(`resource "azurerm_example" {`)); err == nil {
+		t.Fatalf("expected an error for invalid configuration")
+	}
+}
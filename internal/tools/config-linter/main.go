@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/config-linter/linter"
+)
+
+func main() {
+	f := flag.NewFlagSet("config-linter", flag.ExitOnError)
+
+	path := f.String("path", ".", "the path to the directory containing the Terraform Configuration to lint")
+	outputFormat := f.String("format", "text", "the format of the output, either `text` or `json`")
+	errorOnFindings := f.Bool("error-on-findings", false, "should the linter exit with a non-zero error code when any findings are reported. Defaults to `false`")
+
+	if err := f.Parse(os.Args[1:]); err != nil {
+		log.Fatalf("error parsing args: %+v", err)
+	}
+
+	findings, err := linter.NewLinter(buildSchema()).LintDirectory(*path)
+	if err != nil {
+		log.Fatalf("error linting %q: %+v", *path, err)
+	}
+
+	switch *outputFormat {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(findings); err != nil {
+			log.Fatalf("error writing findings: %+v", err)
+		}
+	case "text":
+		for _, finding := range findings {
+			fmt.Println(finding.String())
+		}
+	default:
+		log.Fatalf("unsupported output format %q", *outputFormat)
+	}
+
+	if len(findings) > 0 && *errorOnFindings {
+		os.Exit(1)
+	}
+}
+
+func buildSchema() linter.Schema {
+	azureProvider := provider.AzureProvider()
+	schema := linter.Schema{
+		Resources:   azureProvider.ResourcesMap,
+		DataSources: azureProvider.DataSourcesMap,
+		ReplacedBy:  make(map[string]string),
+	}
+
+	for _, service := range provider.SupportedTypedServices() {
+		for _, resource := range service.Resources() {
+			if v, ok := resource.(sdk.ResourceWithDeprecationReplacedBy); ok {
+				schema.ReplacedBy[resource.ResourceType()] = v.DeprecatedInFavourOfResource()
+			}
+		}
+	}
+
+	// properties deprecated using `features.DeprecatedInFourPointOh` are only visible when the Provider is running
+	// in 4.0 mode, so the Resources and properties which are removed or deprecated in 4.0 are found by building the
+	// Provider again with 4.0 mode enabled
+	restore := features.OverrideFourPointOh(true)
+	defer restore()
+
+	fourPointOhProvider := provider.AzureProvider()
+	schema.FourPointOhResources = fourPointOhProvider.ResourcesMap
+	schema.FourPointOhDataSources = fourPointOhProvider.DataSourcesMap
+
+	return schema
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/config-migrator/migrator"
+)
+
+func main() {
+	f := flag.NewFlagSet("config-migrator", flag.ExitOnError)
+
+	path := f.String("path", ".", "the path to the directory containing the Terraform Module to migrate")
+	outputPath := f.String("output", "", "the path to the directory where the migrated Terraform Configuration should be written, which can be the same as `-path` to migrate the Terraform Module in-place")
+	statePath := f.String("state", "", "the path to the JSON representation of the Terraform State (from `terraform show -json`), used to populate the `import` blocks")
+	migrationFileName := f.String("migration-file-name", "migration.tf", "the name of the file within the output directory where the `import` and `removed` blocks should be written")
+
+	if err := f.Parse(os.Args[1:]); err != nil {
+		log.Fatalf("error parsing args: %+v", err)
+	}
+
+	if *outputPath == "" {
+		log.Fatalf("the `-output` argument must be specified")
+	}
+
+	migrations, err := buildMigrations()
+	if err != nil {
+		log.Fatalf("error building the migrations: %+v", err)
+	}
+
+	if err := run(migrations, *path, *outputPath, *statePath, *migrationFileName); err != nil {
+		log.Fatalf("error migrating %q: %+v", *path, err)
+	}
+}
+
+func run(migrations map[string]sdk.ReplacementMigration, path, outputPath, statePath, migrationFileName string) error {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return fmt.Errorf("reading %q: %+v", path, err)
+	}
+
+	files := make(map[string][]byte)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tf") {
+			continue
+		}
+
+		contents, err := os.ReadFile(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("reading %q: %+v", entry.Name(), err)
+		}
+		files[entry.Name()] = contents
+	}
+
+	if _, ok := files[migrationFileName]; ok {
+		return fmt.Errorf("the migration file %q already exists within %q", migrationFileName, path)
+	}
+
+	result, err := migrator.NewMigrator(migrations).Migrate(files)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Moves) == 0 {
+		log.Printf("[DEBUG] No Resources to migrate within %q", path)
+		return nil
+	}
+
+	state := make(migrator.State)
+	if statePath != "" {
+		contents, err := os.ReadFile(statePath)
+		if err != nil {
+			return fmt.Errorf("reading %q: %+v", statePath, err)
+		}
+
+		state, err = migrator.ParseState(contents)
+		if err != nil {
+			return err
+		}
+	}
+
+	blocks, notes := result.MigrationBlocks(state)
+	result.Files[migrationFileName] = blocks
+
+	if err := os.MkdirAll(outputPath, 0o755); err != nil {
+		return fmt.Errorf("creating %q: %+v", outputPath, err)
+	}
+	for fileName, contents := range result.Files {
+		if err := os.WriteFile(filepath.Join(outputPath, fileName), contents, 0o644); err != nil {
+			return fmt.Errorf("writing %q: %+v", fileName, err)
+		}
+	}
+
+	for _, move := range result.Moves {
+		fmt.Printf("Migrated %q to %q\n", move.From, move.To)
+	}
+	for _, note := range append(result.Notes, notes...) {
+		fmt.Printf("NOTE: %s\n", note.String())
+	}
+
+	return nil
+}
+
+func buildMigrations() (map[string]sdk.ReplacementMigration, error) {
+	migrations := make(map[string]sdk.ReplacementMigration)
+
+	for _, service := range provider.SupportedTypedServices() {
+		for _, resource := range service.Resources() {
+			if v, ok := resource.(sdk.ResourceWithReplacementMigration); ok {
+				migration := v.ReplacementMigration()
+				if err := migration.Validate(v.DeprecatedInFavourOfResource()); err != nil {
+					return nil, fmt.Errorf("validating the ReplacementMigration for %q: %+v", resource.ResourceType(), err)
+				}
+				migrations[resource.ResourceType()] = migration
+			}
+		}
+	}
+
+	for _, service := range provider.SupportedUntypedServices() {
+		v, ok := service.(sdk.UntypedServiceRegistrationWithReplacementMigrations)
+		if !ok {
+			continue
+		}
+
+		for resourceType, migration := range v.ReplacementMigrations() {
+			if err := migration.Validate(); err != nil {
+				return nil, fmt.Errorf("validating the ReplacementMigration for %q: %+v", resourceType, err)
+			}
+			migrations[resourceType] = migration
+		}
+	}
+
+	return migrations, nil
+}
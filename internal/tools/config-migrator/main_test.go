@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestReplacementMigrationsMatchProviderSchema(t *testing.T) {
+	migrations, err := buildMigrations()
+	if err != nil {
+		t.Fatalf("building the migrations: %+v", err)
+	}
+
+	resources := provider.AzureProvider().ResourcesMap
+	for resourceType, migration := range migrations {
+		deprecated, ok := resources[resourceType]
+		if !ok {
+			t.Fatalf("the deprecated Resource %q has a ReplacementMigration but wasn't found in the Provider", resourceType)
+		}
+
+		for _, replacement := range migration.Replacements {
+			t.Logf("[DEBUG] Testing the migration from %q to %q", resourceType, replacement.ResourceType)
+
+			replacementResource, ok := resources[replacement.ResourceType]
+			if !ok {
+				t.Fatalf("the replacement Resource %q for %q wasn't found in the Provider", replacement.ResourceType, resourceType)
+			}
+
+			for _, path := range replacement.WhenPropertiesSet {
+				if !schemaContainsPath(deprecated.Schema, path) {
+					t.Errorf("the property %q within `WhenPropertiesSet` for %q doesn't exist within %q", path, replacement.ResourceType, resourceType)
+				}
+			}
+
+			for path := range replacement.RemovedProperties {
+				if !schemaContainsPath(deprecated.Schema, path) {
+					t.Errorf("the removed property %q for %q doesn't exist within %q", path, replacement.ResourceType, resourceType)
+				}
+			}
+
+			for path := range replacement.RenamedProperties {
+				if !schemaContainsPath(deprecated.Schema, path) {
+					t.Errorf("the renamed property %q for %q doesn't exist within %q", path, replacement.ResourceType, resourceType)
+				}
+
+				renamedPath := replacementPath(path, replacement.RenamedProperties)
+				if !schemaContainsPath(replacementResource.Schema, renamedPath) {
+					t.Errorf("the property %q within %q is renamed to %q which doesn't exist within %q", path, resourceType, renamedPath, replacement.ResourceType)
+				}
+			}
+		}
+	}
+}
+
+// replacementPath returns the path to the property within the replacement Resource, accounting for any of the
+// blocks containing the property being renamed too
+func replacementPath(path string, renamedProperties map[string]string) string {
+	segments := strings.Split(path, ".")
+	output := make([]string, 0, len(segments))
+	for i, segment := range segments {
+		if renamed, ok := renamedProperties[strings.Join(segments[:i+1], ".")]; ok {
+			segment = renamed
+		}
+		output = append(output, segment)
+	}
+	return strings.Join(output, ".")
+}
+
+func schemaContainsPath(input map[string]*pluginsdk.Schema, path string) bool {
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		v, ok := input[segment]
+		if !ok {
+			return false
+		}
+		if i == len(segments)-1 {
+			return true
+		}
+
+		elem, ok := v.Elem.(*pluginsdk.Resource)
+		if !ok {
+			return false
+		}
+		input = elem.Schema
+	}
+
+	return false
+}
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package migrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// StateInstance is an instance of a Resource within the Terraform State
+type StateInstance struct {
+	// Address is the address of this instance, e.g. `azurerm_app_service.example[0]`
+	Address string
+
+	// ID is the Resource ID of this instance
+	ID string
+}
+
+// State is the instances of each Resource within the root module of the Terraform State, keyed by the
+// address of the Resource (e.g. `azurerm_app_service.example`)
+type State map[string][]StateInstance
+
+// ParseState parses the JSON representation of the Terraform State (output from `terraform show -json`)
+func ParseState(contents []byte) (State, error) {
+	var state struct {
+		Values *struct {
+			RootModule struct {
+				Resources []struct {
+					Address string `json:"address"`
+					Mode    string `json:"mode"`
+					Type    string `json:"type"`
+					Name    string `json:"name"`
+					Values  struct {
+						ID string `json:"id"`
+					} `json:"values"`
+				} `json:"resources"`
+			} `json:"root_module"`
+		} `json:"values"`
+	}
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return nil, fmt.Errorf("parsing the Terraform State: %+v", err)
+	}
+
+	output := make(State)
+	if state.Values == nil {
+		return output, nil
+	}
+
+	for _, resource := range state.Values.RootModule.Resources {
+		if resource.Mode != "managed" {
+			continue
+		}
+
+		address := fmt.Sprintf("%s.%s", resource.Type, resource.Name)
+		output[address] = append(output[address], StateInstance{
+			Address: resource.Address,
+			ID:      resource.Values.ID,
+		})
+	}
+
+	for address := range output {
+		sort.Slice(output[address], func(i, j int) bool {
+			return output[address][i].Address < output[address][j].Address
+		})
+	}
+
+	return output, nil
+}
+
+// MigrationBlocks returns the `import` blocks used to import the existing Resources into the replacement
+// Resources, and the `removed` blocks used to remove the deprecated Resources from the Terraform State
+// without destroying them.
+//
+// Since the Plugin SDK doesn't support moving Resources between Resource Types, `moved` blocks can't be used.
+func (r Result) MigrationBlocks(state State) ([]byte, []Note) {
+	notes := make([]Note, 0)
+	blocks := make([]string, 0)
+
+	for _, move := range r.Moves {
+		instances, ok := state[move.From]
+		switch {
+		case ok:
+			for _, instance := range instances {
+				to := move.To + strings.TrimPrefix(instance.Address, move.From)
+				blocks = append(blocks, importBlock(to, instance.ID, ""))
+			}
+
+		case move.Repeated:
+			blocks = append(blocks, fmt.Sprintf("# TODO: add an `import` block into `%s` for each instance of `%s`\n", move.To, move.From))
+			notes = append(notes, Note{
+				Address: move.From,
+				Message: "an `import` block needs to be added for each instance of this Resource, since the Terraform State wasn't specified",
+			})
+
+		default:
+			blocks = append(blocks, importBlock(move.To, "", fmt.Sprintf("TODO: set `id` to the ID of `%s`, which is available using `terraform state show %s`", move.From, move.From)))
+			notes = append(notes, Note{
+				Address: move.From,
+				Message: "the `id` within the `import` block needs to be set, since the Terraform State wasn't specified",
+			})
+		}
+
+		blocks = append(blocks, fmt.Sprintf(`removed {
+from = %s
+
+lifecycle {
+destroy = false
+}
+}
+`, move.From))
+	}
+
+	return hclwrite.Format([]byte(strings.Join(blocks, "\n"))), notes
+}
+
+func importBlock(to string, id string, comment string) string {
+	output := ""
+	if comment != "" {
+		output = fmt.Sprintf("# %s\n", comment)
+	}
+	return output + fmt.Sprintf(`import {
+to = %s
+id = %q
+}
+`, to, id)
+}
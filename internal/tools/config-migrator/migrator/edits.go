@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package migrator
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// edit replaces the bytes between start and end with text - which is used rather than rewriting the
+// whole file so that any comments and formatting are retained
+type edit struct {
+	start int
+	end   int
+	text  string
+}
+
+// applyEdits applies the edits to the contents, where any edit within a range which has already been
+// replaced (e.g. a property within a block which has been removed) is ignored
+func applyEdits(contents []byte, edits []edit) []byte {
+	sorted := make([]edit, len(edits))
+	copy(sorted, edits)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].start != sorted[j].start {
+			return sorted[i].start < sorted[j].start
+		}
+		// the larger of the two edits takes precedence
+		return sorted[i].end > sorted[j].end
+	})
+
+	output := bytes.Buffer{}
+	position := 0
+	for _, e := range sorted {
+		if e.start < position {
+			continue
+		}
+		output.Write(contents[position:e.start])
+		output.WriteString(e.text)
+		position = e.end
+	}
+	output.Write(contents[position:])
+
+	return output.Bytes()
+}
+
+// expandToLines expands the range to include the whole line(s) when there's nothing else on those lines,
+// so that removing a property or block doesn't leave an empty line behind
+func expandToLines(contents []byte, start int, end int) (int, int) {
+	lineStart := start
+	for lineStart > 0 && (contents[lineStart-1] == ' ' || contents[lineStart-1] == '\t') {
+		lineStart--
+	}
+	if lineStart > 0 && contents[lineStart-1] != '\n' {
+		return start, end
+	}
+
+	lineEnd := end
+	for lineEnd < len(contents) && (contents[lineEnd] == ' ' || contents[lineEnd] == '\t' || contents[lineEnd] == '\r') {
+		lineEnd++
+	}
+	if lineEnd < len(contents) && contents[lineEnd] != '\n' {
+		return start, end
+	}
+	if lineEnd < len(contents) {
+		lineEnd++
+	}
+
+	// when the removed lines are surrounded by blank lines, remove the preceding blank line too
+	if isBlankLineBefore(contents, lineStart) && isBlankLineAt(contents, lineEnd) {
+		lineStart--
+		for lineStart > 0 && contents[lineStart-1] != '\n' {
+			lineStart--
+		}
+	}
+
+	return lineStart, lineEnd
+}
+
+func isBlankLineBefore(contents []byte, lineStart int) bool {
+	if lineStart == 0 {
+		return false
+	}
+	i := lineStart - 1 // the newline ending the previous line
+	for i > 0 && (contents[i-1] == ' ' || contents[i-1] == '\t' || contents[i-1] == '\r') {
+		i--
+	}
+	return i > 0 && contents[i-1] == '\n'
+}
+
+func isBlankLineAt(contents []byte, lineStart int) bool {
+	i := lineStart
+	for i < len(contents) && (contents[i] == ' ' || contents[i] == '\t' || contents[i] == '\r') {
+		i++
+	}
+	return i < len(contents) && contents[i] == '\n'
+}
+
+func joinPath(parentPath string, name string) string {
+	if parentPath == "" {
+		return name
+	}
+	return parentPath + "." + name
+}
+
+func splitPath(path string) []string {
+	return strings.Split(path, ".")
+}
@@ -0,0 +1,386 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package migrator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+// Move is a Resource within the Terraform Configuration which has been migrated to a replacement Resource
+type Move struct {
+	// From is the address of the deprecated Resource, e.g. `azurerm_app_service.example`
+	From string
+
+	// To is the address of the replacement Resource, e.g. `azurerm_linux_web_app.example`
+	To string
+
+	// Repeated is whether the Resource uses `count` or `for_each`, meaning there's an instance for each key
+	Repeated bool
+}
+
+// Note is a change to the Terraform Configuration which needs to be reviewed or completed manually
+type Note struct {
+	FileName string
+	Line     int
+	Address  string
+	Message  string
+}
+
+func (n Note) String() string {
+	if n.FileName == "" {
+		return fmt.Sprintf("%s: %s", n.Address, n.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", n.FileName, n.Line, n.Address, n.Message)
+}
+
+// Result is the output of migrating the Terraform Configuration
+type Result struct {
+	// Files are the migrated contents of each file, keyed by the file name
+	Files map[string][]byte
+
+	// Moves are the Resources which have been migrated to a replacement Resource
+	Moves []Move
+
+	// Notes are changes which need to be reviewed or completed manually
+	Notes []Note
+}
+
+type Migrator struct {
+	migrations map[string]sdk.ReplacementMigration
+}
+
+// NewMigrator returns a Migrator using the specified ReplacementMigrations, keyed by the deprecated Resource Type
+func NewMigrator(migrations map[string]sdk.ReplacementMigration) Migrator {
+	return Migrator{
+		migrations: migrations,
+	}
+}
+
+type parsedFile struct {
+	fileName string
+	contents []byte
+	body     *hclsyntax.Body
+	edits    []edit
+}
+
+// migratedResource is a Resource within the Terraform Configuration which is being migrated
+type migratedResource struct {
+	replacement sdk.ResourceReplacement
+}
+
+// Migrate migrates the Terraform Configuration within the specified files (keyed by file name), which should be
+// all of the files within a single Terraform Module so that references to the migrated Resources are also updated
+func (m Migrator) Migrate(files map[string][]byte) (*Result, error) {
+	fileNames := make([]string, 0)
+	for fileName := range files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	parsed := make([]*parsedFile, 0)
+	for _, fileName := range fileNames {
+		file, diags := hclsyntax.ParseConfig(files[fileName], fileName, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("parsing %q: %s", fileName, diags.Error())
+		}
+
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			return nil, fmt.Errorf("parsing %q: unexpected body type %T", fileName, file.Body)
+		}
+
+		parsed = append(parsed, &parsedFile{
+			fileName: fileName,
+			contents: files[fileName],
+			body:     body,
+		})
+	}
+
+	result := &Result{
+		Files: make(map[string][]byte),
+		Moves: make([]Move, 0),
+		Notes: make([]Note, 0),
+	}
+
+	// first migrate the Resource blocks, then any references to them (which can be in any file)
+	migrated := make(map[string]migratedResource)
+	for _, file := range parsed {
+		for _, block := range file.body.Blocks {
+			if block.Type != "resource" || len(block.Labels) != 2 {
+				continue
+			}
+
+			migration, ok := m.migrations[block.Labels[0]]
+			if !ok {
+				continue
+			}
+
+			address := fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+			replacement := matchingReplacement(migration, block.Body)
+			if replacement == nil {
+				result.Notes = append(result.Notes, Note{
+					FileName: file.fileName,
+					Line:     block.TypeRange.Start.Line,
+					Address:  address,
+					Message:  "unable to determine the replacement for this Resource, so it needs to be migrated manually",
+				})
+				continue
+			}
+
+			migrated[address] = migratedResource{
+				replacement: *replacement,
+			}
+
+			file.edits = append(file.edits, edit{
+				start: block.LabelRanges[0].Start.Byte,
+				end:   block.LabelRanges[0].End.Byte,
+				text:  fmt.Sprintf("%q", replacement.ResourceType),
+			})
+
+			w := bodyMigrator{
+				file:        file,
+				address:     address,
+				replacement: *replacement,
+			}
+			w.migrateBody("", block.Body)
+			result.Notes = append(result.Notes, w.notes...)
+
+			_, hasCount := block.Body.Attributes["count"]
+			_, hasForEach := block.Body.Attributes["for_each"]
+			result.Moves = append(result.Moves, Move{
+				From:     address,
+				To:       fmt.Sprintf("%s.%s", replacement.ResourceType, block.Labels[1]),
+				Repeated: hasCount || hasForEach,
+			})
+		}
+	}
+
+	for _, file := range parsed {
+		file.edits = append(file.edits, migrateReferences(file.body, migrated)...)
+
+		contents := file.contents
+		if len(file.edits) > 0 {
+			contents = hclwrite.Format(applyEdits(file.contents, file.edits))
+		}
+		result.Files[file.fileName] = contents
+	}
+
+	sort.Slice(result.Moves, func(i, j int) bool {
+		return result.Moves[i].From < result.Moves[j].From
+	})
+	sort.SliceStable(result.Notes, func(i, j int) bool {
+		if result.Notes[i].FileName != result.Notes[j].FileName {
+			return result.Notes[i].FileName < result.Notes[j].FileName
+		}
+		return result.Notes[i].Line < result.Notes[j].Line
+	})
+
+	return result, nil
+}
+
+// matchingReplacement returns the first Replacement whose required properties are all set within the body
+func matchingReplacement(migration sdk.ReplacementMigration, body *hclsyntax.Body) *sdk.ResourceReplacement {
+	for _, replacement := range migration.Replacements {
+		matches := true
+		for _, path := range replacement.WhenPropertiesSet {
+			if !hasProperty(body, splitPath(path)) {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return &replacement
+		}
+	}
+
+	return nil
+}
+
+func hasProperty(body *hclsyntax.Body, path []string) bool {
+	if len(path) == 0 {
+		return true
+	}
+
+	if _, ok := body.Attributes[path[0]]; ok && len(path) == 1 {
+		return true
+	}
+
+	for _, block := range body.Blocks {
+		if name, nestedBody := blockNameAndBody(block); name == path[0] && nestedBody != nil && hasProperty(nestedBody, path[1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type bodyMigrator struct {
+	file        *parsedFile
+	address     string
+	replacement sdk.ResourceReplacement
+	notes       []Note
+}
+
+func (w *bodyMigrator) migrateBody(parentPath string, body *hclsyntax.Body) {
+	for name, attribute := range body.Attributes {
+		path := joinPath(parentPath, name)
+		if note, ok := w.replacement.RemovedProperties[path]; ok {
+			w.remove(path, attribute.SrcRange, note)
+			continue
+		}
+
+		if renamed, ok := w.replacement.RenamedProperties[path]; ok {
+			w.file.edits = append(w.file.edits, edit{
+				start: attribute.NameRange.Start.Byte,
+				end:   attribute.NameRange.End.Byte,
+				text:  renamed,
+			})
+		}
+	}
+
+	for _, block := range body.Blocks {
+		name, nestedBody := blockNameAndBody(block)
+		if name == "" {
+			// e.g. a `lifecycle` block
+			continue
+		}
+		path := joinPath(parentPath, name)
+
+		if note, ok := w.replacement.RemovedProperties[path]; ok {
+			w.remove(path, block.Range(), note)
+			continue
+		}
+
+		if renamed, ok := w.replacement.RenamedProperties[path]; ok {
+			if block.Type == "dynamic" {
+				w.file.edits = append(w.file.edits, edit{
+					start: block.LabelRanges[0].Start.Byte,
+					end:   block.LabelRanges[0].End.Byte,
+					text:  fmt.Sprintf("%q", renamed),
+				})
+
+				// the iterator defaults to the name of the block, so this needs to be retained for existing references
+				if _, ok := block.Body.Attributes["iterator"]; !ok {
+					w.file.edits = append(w.file.edits, edit{
+						start: block.OpenBraceRange.End.Byte,
+						end:   block.OpenBraceRange.End.Byte,
+						text:  fmt.Sprintf("\niterator = %s\n", name),
+					})
+				}
+			} else {
+				w.file.edits = append(w.file.edits, edit{
+					start: block.TypeRange.Start.Byte,
+					end:   block.TypeRange.End.Byte,
+					text:  renamed,
+				})
+			}
+		}
+
+		if nestedBody != nil {
+			w.migrateBody(path, nestedBody)
+		}
+	}
+}
+
+func (w *bodyMigrator) remove(path string, r hcl.Range, note string) {
+	start, end := expandToLines(w.file.contents, r.Start.Byte, r.End.Byte)
+	w.file.edits = append(w.file.edits, edit{
+		start: start,
+		end:   end,
+	})
+
+	message := fmt.Sprintf("`%s` isn't supported by %q and has been removed", path, w.replacement.ResourceType)
+	if note != "" {
+		message = fmt.Sprintf("%s - %s", message, note)
+	}
+	w.notes = append(w.notes, Note{
+		FileName: w.file.fileName,
+		Line:     r.Start.Line,
+		Address:  w.address,
+		Message:  message,
+	})
+}
+
+// blockNameAndBody returns the name of the property which the block configures and the body containing its
+// properties, which for a dynamic block is the `content` block
+func blockNameAndBody(block *hclsyntax.Block) (string, *hclsyntax.Body) {
+	switch block.Type {
+	case "dynamic":
+		if len(block.Labels) != 1 {
+			return "", nil
+		}
+		for _, b := range block.Body.Blocks {
+			if b.Type == "content" {
+				return block.Labels[0], b.Body
+			}
+		}
+		return block.Labels[0], nil
+
+	case "lifecycle", "provisioner", "connection":
+		return "", nil
+	}
+
+	return block.Type, block.Body
+}
+
+// migrateReferences updates any references to the migrated Resources (e.g. `azurerm_app_service.example.id`) to
+// reference the replacement Resource, including any top-level properties which have been renamed
+func migrateReferences(body *hclsyntax.Body, migrated map[string]migratedResource) []edit {
+	edits := make([]edit, 0)
+	hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok || len(expr.Traversal) < 2 {
+			return nil
+		}
+
+		root, ok := expr.Traversal[0].(hcl.TraverseRoot)
+		if !ok {
+			return nil
+		}
+		name, ok := expr.Traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			return nil
+		}
+
+		resource, ok := migrated[fmt.Sprintf("%s.%s", root.Name, name.Name)]
+		if !ok {
+			return nil
+		}
+
+		edits = append(edits, edit{
+			start: root.SrcRange.Start.Byte,
+			end:   root.SrcRange.End.Byte,
+			text:  resource.replacement.ResourceType,
+		})
+
+		// the property may follow an index when using `count` or `for_each`
+		remaining := expr.Traversal[2:]
+		if len(remaining) > 0 {
+			if _, ok := remaining[0].(hcl.TraverseIndex); ok {
+				remaining = remaining[1:]
+			}
+		}
+		if len(remaining) > 0 {
+			if property, ok := remaining[0].(hcl.TraverseAttr); ok {
+				if renamed, ok := resource.replacement.RenamedProperties[property.Name]; ok {
+					edits = append(edits, edit{
+						start: property.SrcRange.Start.Byte + 1, // skip the `.`
+						end:   property.SrcRange.End.Byte,
+						text:  renamed,
+					})
+				}
+			}
+		}
+
+		return nil
+	})
+	return edits
+}
@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package migrator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+func testMigrations() map[string]sdk.ReplacementMigration {
+	return map[string]sdk.ReplacementMigration{
+		"azurerm_legacy_app": {
+			Replacements: []sdk.ResourceReplacement{
+				{
+					ResourceType:      "azurerm_linux_app",
+					WhenPropertiesSet: []string{"site_config.linux_version"},
+					RenamedProperties: map[string]string{
+						"plan_id":                   "service_plan_id",
+						"site_config.min_tls":       "minimum_tls_version",
+						"site_config.linux_version": "linux_fx_version",
+						"storage":                   "storage_account",
+					},
+					RemovedProperties: map[string]string{
+						"source_control":   "use the `azurerm_source_control` resource",
+						"site_config.java": "",
+					},
+				},
+				{
+					ResourceType: "azurerm_windows_app",
+					RenamedProperties: map[string]string{
+						"plan_id": "service_plan_id",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	testData := []struct {
+		Name          string
+		Input         map[string]string
+		Expected      map[string]string
+		ExpectedMoves []Move
+		ExpectedNotes int
+	}{
+		{
+			Name: "Nothing to Migrate",
+			Input: map[string]string{
+				"main.tf": `resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "West Europe"
+}
+`,
+			},
+			Expected: map[string]string{
+				"main.tf": `resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "West Europe"
+}
+`,
+			},
+			ExpectedMoves: []Move{},
+		},
+		{
+			Name: "Linux",
+			Input: map[string]string{
+				"main.tf": `resource "azurerm_legacy_app" "example" {
+  name    = "example"
+  plan_id = azurerm_plan.example.id # the plan
+
+  site_config {
+    linux_version = "NODE|18-lts"
+    min_tls       = "1.2"
+    java          = "11"
+  }
+
+  source_control {
+    repo_url = "https://github.com/example/example"
+  }
+
+  dynamic "storage" {
+    for_each = var.storage
+    content {
+      name = storage.value.name
+    }
+  }
+}
+`,
+				"outputs.tf": `output "plan_id" {
+  value = azurerm_legacy_app.example.plan_id
+}
+
+output "name" {
+  value = "${azurerm_legacy_app.example.name}-suffix"
+}
+`,
+			},
+			Expected: map[string]string{
+				"main.tf": `resource "azurerm_linux_app" "example" {
+  name            = "example"
+  service_plan_id = azurerm_plan.example.id # the plan
+
+  site_config {
+    linux_fx_version    = "NODE|18-lts"
+    minimum_tls_version = "1.2"
+  }
+
+  dynamic "storage_account" {
+    iterator = storage
+
+    for_each = var.storage
+    content {
+      name = storage.value.name
+    }
+  }
+}
+`,
+				"outputs.tf": `output "plan_id" {
+  value = azurerm_linux_app.example.service_plan_id
+}
+
+output "name" {
+  value = "${azurerm_linux_app.example.name}-suffix"
+}
+`,
+			},
+			ExpectedMoves: []Move{
+				{
+					From: "azurerm_legacy_app.example",
+					To:   "azurerm_linux_app.example",
+				},
+			},
+			ExpectedNotes: 2,
+		},
+		{
+			Name: "Windows with Count",
+			Input: map[string]string{
+				"main.tf": `resource "azurerm_legacy_app" "example" {
+  count   = 2
+  name    = "example-${count.index}"
+  plan_id = azurerm_plan.example.id
+}
+
+output "plan_id" {
+  value = azurerm_legacy_app.example[0].plan_id
+}
+`,
+			},
+			Expected: map[string]string{
+				"main.tf": `resource "azurerm_windows_app" "example" {
+  count           = 2
+  name            = "example-${count.index}"
+  service_plan_id = azurerm_plan.example.id
+}
+
+output "plan_id" {
+  value = azurerm_windows_app.example[0].service_plan_id
+}
+`,
+			},
+			ExpectedMoves: []Move{
+				{
+					From:     "azurerm_legacy_app.example",
+					To:       "azurerm_windows_app.example",
+					Repeated: true,
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		input := make(map[string][]byte)
+		for fileName, contents := range v.Input {
+			input[fileName] = []byte(contents)
+		}
+
+		result, err := NewMigrator(testMigrations()).Migrate(input)
+		if err != nil {
+			t.Fatalf("migrating: %+v", err)
+		}
+
+		for fileName, expected := range v.Expected {
+			if actual := string(result.Files[fileName]); actual != expected {
+				t.Fatalf("expected %q to be:\n%s\n\nbut got:\n%s", fileName, expected, actual)
+			}
+		}
+
+		if len(result.Moves) != len(v.ExpectedMoves) {
+			t.Fatalf("expected %d moves but got %d: %+v", len(v.ExpectedMoves), len(result.Moves), result.Moves)
+		}
+		for i, expected := range v.ExpectedMoves {
+			if result.Moves[i] != expected {
+				t.Fatalf("expected move %d to be %+v but got %+v", i, expected, result.Moves[i])
+			}
+		}
+
+		if len(result.Notes) != v.ExpectedNotes {
+			t.Fatalf("expected %d notes but got %d: %+v", v.ExpectedNotes, len(result.Notes), result.Notes)
+		}
+	}
+}
+
+func TestMigrationBlocks(t *testing.T) {
+	result := Result{
+		Moves: []Move{
+			{
+				From:     "azurerm_legacy_app.example",
+				To:       "azurerm_linux_app.example",
+				Repeated: true,
+			},
+			{
+				From: "azurerm_legacy_app.other",
+				To:   "azurerm_linux_app.other",
+			},
+		},
+	}
+
+	state, err := ParseState([]byte(`{
+  "values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "azurerm_legacy_app.example[1]",
+          "mode": "managed",
+          "type": "azurerm_legacy_app",
+          "name": "example",
+          "index": 1,
+          "values": {
+            "id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites/example1"
+          }
+        },
+        {
+          "address": "azurerm_legacy_app.example[0]",
+          "mode": "managed",
+          "type": "azurerm_legacy_app",
+          "name": "example",
+          "index": 0,
+          "values": {
+            "id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites/example0"
+          }
+        }
+      ]
+    }
+  }
+}`))
+	if err != nil {
+		t.Fatalf("parsing state: %+v", err)
+	}
+
+	blocks, notes := result.MigrationBlocks(state)
+	actual := string(blocks)
+
+	for _, expected := range []string{
+		"to = azurerm_linux_app.example[0]\n  id = \"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites/example0\"",
+		"to = azurerm_linux_app.example[1]\n  id = \"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites/example1\"",
+		"from = azurerm_legacy_app.example\n",
+		"to = azurerm_linux_app.other\n  id = \"\"",
+		"from = azurerm_legacy_app.other\n",
+		"destroy = false",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Fatalf("expected the migration blocks to contain %q but got:\n%s", expected, actual)
+		}
+	}
+
+	if len(notes) != 1 || notes[0].Address != "azurerm_legacy_app.other" {
+		t.Fatalf("expected a single note for `azurerm_legacy_app.other` but got %+v", notes)
+	}
+}
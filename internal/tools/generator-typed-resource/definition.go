@@ -0,0 +1,587 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type propertyKind string
+
+const (
+	kindBlock      propertyKind = "block"
+	kindBlockList  propertyKind = "blockList"
+	kindBool       propertyKind = "bool"
+	kindEnum       propertyKind = "enum"
+	kindFloat      propertyKind = "float"
+	kindInt        propertyKind = "int"
+	kindString     propertyKind = "string"
+	kindStringList propertyKind = "stringList"
+	kindStringMap  propertyKind = "stringMap"
+)
+
+// maxBlockDepth is the maximum depth of nested blocks, to avoid recursing into (potentially) recursive models
+const maxBlockDepth = 5
+
+// property is a property within the Terraform Schema which maps to a field within the SDK model
+type property struct {
+	SchemaName string
+
+	// FieldName is the name of the field within the Terraform model, which matches the field within the SDK model
+	FieldName string
+
+	SdkType  typeRef
+	Kind     propertyKind
+	Required bool
+	ForceNew bool
+	Computed bool
+
+	// Block is the nested block, for the `block` and `blockList` kinds
+	Block *block
+
+	// UpdateSupported is whether this (top-level) property can be set within the update payload
+	UpdateSupported bool
+}
+
+// modelType returns the type of the field within the Terraform model
+func (p property) modelType() string {
+	switch p.Kind {
+	case kindBlock, kindBlockList:
+		return "[]" + p.Block.ModelName()
+	case kindBool:
+		return "bool"
+	case kindFloat:
+		return "float64"
+	case kindInt:
+		return "int64"
+	case kindStringList:
+		return "[]string"
+	case kindStringMap:
+		return "map[string]string"
+	}
+	return "string"
+}
+
+// block is a nested block within the Terraform Schema which maps to a model within the SDK
+type block struct {
+	// Name is used for the Terraform model and expand/flatten functions, e.g. `LoadTestEncryption`
+	Name string
+
+	// SdkType is the name of the SDK model, e.g. `EncryptionProperties`
+	SdkType string
+
+	// List is whether this block maps to a list of SDK models (rather than a single model)
+	List bool
+
+	Properties []property
+}
+
+func (b block) ModelName() string {
+	return b.Name + "Model"
+}
+
+// identityType describes how an Identity type from the `identity` package is exposed in the Terraform Schema
+type identityType struct {
+	Schema                string
+	Model                 string
+	Expand                string
+	Flatten               string
+	FlattenReturnsError   bool
+	FlattenReturnsPointer bool
+	SystemAssigned        bool
+	UserAssigned          bool
+}
+
+var identityTypes = map[string]identityType{
+	"LegacySystemAndUserAssignedMap": {
+		Schema:              "SystemAssignedUserAssignedIdentityOptional",
+		Model:               "ModelSystemAssignedUserAssigned",
+		Expand:              "ExpandLegacySystemAndUserAssignedMapFromModel",
+		Flatten:             "FlattenLegacySystemAndUserAssignedMapToModel",
+		FlattenReturnsError: true,
+		SystemAssigned:      true,
+		UserAssigned:        true,
+	},
+	"SystemAndUserAssignedList": {
+		Schema:                "SystemAssignedUserAssignedIdentityOptional",
+		Model:                 "ModelSystemAssignedUserAssigned",
+		Expand:                "ExpandSystemAndUserAssignedListFromModel",
+		Flatten:               "FlattenSystemAndUserAssignedListToModel",
+		FlattenReturnsError:   true,
+		FlattenReturnsPointer: true,
+		SystemAssigned:        true,
+		UserAssigned:          true,
+	},
+	"SystemAndUserAssignedMap": {
+		Schema:                "SystemAssignedUserAssignedIdentityOptional",
+		Model:                 "ModelSystemAssignedUserAssigned",
+		Expand:                "ExpandSystemAndUserAssignedMapFromModel",
+		Flatten:               "FlattenSystemAndUserAssignedMapToModel",
+		FlattenReturnsError:   true,
+		FlattenReturnsPointer: true,
+		SystemAssigned:        true,
+		UserAssigned:          true,
+	},
+	"SystemAssigned": {
+		Schema:         "SystemAssignedIdentityOptional",
+		Model:          "ModelSystemAssigned",
+		Expand:         "ExpandSystemAssignedFromModel",
+		Flatten:        "FlattenSystemAssignedToModel",
+		SystemAssigned: true,
+	},
+	"SystemOrUserAssignedList": {
+		Schema:                "SystemOrUserAssignedIdentityOptional",
+		Model:                 "ModelSystemAssignedUserAssigned",
+		Expand:                "ExpandSystemOrUserAssignedListFromModel",
+		Flatten:               "FlattenSystemAssignedOrUserAssignedListToModel",
+		FlattenReturnsError:   true,
+		FlattenReturnsPointer: true,
+		SystemAssigned:        true,
+	},
+	"SystemOrUserAssignedMap": {
+		Schema:                "SystemOrUserAssignedIdentityOptional",
+		Model:                 "ModelSystemAssignedUserAssigned",
+		Expand:                "ExpandSystemOrUserAssignedMapFromModel",
+		Flatten:               "FlattenSystemOrUserAssignedMapToModel",
+		FlattenReturnsError:   true,
+		FlattenReturnsPointer: true,
+		SystemAssigned:        true,
+	},
+	"UserAssignedList": {
+		Schema:                "UserAssignedIdentityOptional",
+		Model:                 "ModelUserAssigned",
+		Expand:                "ExpandUserAssignedListFromModel",
+		Flatten:               "FlattenUserAssignedListToModel",
+		FlattenReturnsError:   true,
+		FlattenReturnsPointer: true,
+		UserAssigned:          true,
+	},
+	"UserAssignedMap": {
+		Schema:                "UserAssignedIdentityOptional",
+		Model:                 "ModelUserAssigned",
+		Expand:                "ExpandUserAssignedMapFromModel",
+		Flatten:               "FlattenUserAssignedMapToModel",
+		FlattenReturnsError:   true,
+		FlattenReturnsPointer: true,
+		UserAssigned:          true,
+	},
+}
+
+type idArgumentKind string
+
+const (
+	idArgumentName          idArgumentKind = "name"
+	idArgumentParentId      idArgumentKind = "parentId"
+	idArgumentResourceGroup idArgumentKind = "resourceGroup"
+	idArgumentSegment       idArgumentKind = "segment"
+)
+
+// idArgument is a property within the Terraform Schema used to build the Resource ID
+type idArgument struct {
+	Kind       idArgumentKind
+	SchemaName string
+	FieldName  string
+
+	// Segment is the name of the segment within the Resource ID, for the `name`, `resourceGroup` and `segment` kinds
+	Segment string
+}
+
+// topLevelField is a field within the SDK model which is exposed using a common schema (e.g. `location` or `tags`)
+type topLevelField struct {
+	Field    sdkField
+	ForceNew bool
+}
+
+// resourceDefinition describes the Resource being generated
+type resourceDefinition struct {
+	ResourceType   string
+	Name           string
+	ServicePackage string
+	Client         string
+	Package        *sdkPackage
+	Operations     operations
+
+	// IdSegments are the names of the segments within the Resource ID
+	IdSegments []string
+
+	// ParentId is the name of the Resource ID type for the parent Resource, if any (e.g. `FleetId`)
+	ParentId string
+
+	IdArguments []idArgument
+
+	Location *topLevelField
+	Tags     *topLevelField
+	Identity *topLevelField
+
+	// Properties is the `properties` field within the SDK model, whose fields are exposed at the top-level
+	Properties *sdkField
+
+	Arguments  []property
+	Attributes []property
+	Blocks     []*block
+
+	// Unsupported are the fields within the SDK model which couldn't be mapped to the Terraform Schema
+	Unsupported []string
+
+	// UpdateModel is the name of the model used for updates, when this differs from the model used for creation
+	UpdateModel string
+
+	// UpdateProperties is the `properties` field within the UpdateModel, if any
+	UpdateProperties *sdkField
+}
+
+func (d resourceDefinition) ResourceStruct() string {
+	return d.Name + "Resource"
+}
+
+func (d resourceDefinition) ModelStruct() string {
+	return d.Name + "ResourceModel"
+}
+
+func (d resourceDefinition) IdentityType() identityType {
+	return identityTypes[d.Identity.Field.Type.Name]
+}
+
+// ignoredFields are fields within the SDK models which are either exposed via the Resource ID or are read-only
+var ignoredFields = map[string]struct{}{
+	"etag":              {},
+	"id":                {},
+	"name":              {},
+	"provisioningState": {},
+	"systemData":        {},
+	"type":              {},
+}
+
+type definitionBuilder struct {
+	pkg         *sdkPackage
+	computed    map[string]struct{}
+	updatePaths map[string]typeRef
+	definition  *resourceDefinition
+}
+
+func buildResourceDefinition(pkg *sdkPackage, ops operations, resourceType, servicePackage, client string, computed []string) (*resourceDefinition, error) {
+	if !strings.HasPrefix(resourceType, "azurerm_") {
+		return nil, fmt.Errorf("the Resource Type %q must start with `azurerm_`", resourceType)
+	}
+
+	b := definitionBuilder{
+		pkg:      pkg,
+		computed: make(map[string]struct{}),
+		definition: &resourceDefinition{
+			ResourceType:   resourceType,
+			Name:           toPascalCase(strings.TrimPrefix(resourceType, "azurerm_")),
+			ServicePackage: servicePackage,
+			Client:         client,
+			Package:        pkg,
+			Operations:     ops,
+			IdSegments:     pkg.ResourceIds[ops.ResourceId],
+		},
+	}
+	for _, v := range computed {
+		if v = strings.TrimSpace(v); v != "" {
+			b.computed[v] = struct{}{}
+		}
+	}
+
+	b.buildIdArguments()
+
+	if update := ops.Update; update != nil && update.Input != ops.Model {
+		b.definition.UpdateModel = update.Input
+		b.updatePaths = make(map[string]typeRef)
+		for _, field := range pkg.Models[update.Input] {
+			b.updatePaths[field.JsonName] = field.Type
+			if field.JsonName == "properties" && isModel(pkg, field.Type) && !field.Type.Slice {
+				f := field
+				b.definition.UpdateProperties = &f
+				for _, nested := range pkg.Models[field.Type.Name] {
+					b.updatePaths["properties."+nested.JsonName] = nested.Type
+				}
+			}
+		}
+	}
+
+	usedNames := make(map[string]struct{})
+	for _, arg := range b.definition.IdArguments {
+		usedNames[arg.SchemaName] = struct{}{}
+	}
+
+	for _, field := range pkg.Models[ops.Model] {
+		switch {
+		case field.JsonName == "location" && field.Type.Package == "" && field.Type.Name == "string" && !field.Type.Slice && !field.Type.Map:
+			b.definition.Location = &topLevelField{
+				Field:    field,
+				ForceNew: true,
+			}
+			usedNames["location"] = struct{}{}
+
+		case field.JsonName == "tags" && field.Type.Map && field.Type.Name == "string" && field.Type.Package == "":
+			b.definition.Tags = &topLevelField{
+				Field:    field,
+				ForceNew: !b.updatable("tags", field.Type),
+			}
+			usedNames["tags"] = struct{}{}
+
+		case field.JsonName == "identity" && field.Type.Package == "identity" && !field.Type.Slice && !field.Type.Map:
+			if _, ok := identityTypes[field.Type.Name]; !ok {
+				b.definition.Unsupported = append(b.definition.Unsupported, fmt.Sprintf("`identity` (%s)", field.Type))
+				continue
+			}
+			b.definition.Identity = &topLevelField{
+				Field:    field,
+				ForceNew: !b.updatable("identity", field.Type),
+			}
+			usedNames["identity"] = struct{}{}
+
+		case field.JsonName == "properties" && isModel(pkg, field.Type) && !field.Type.Slice && !field.Type.Map:
+			f := field
+			b.definition.Properties = &f
+		}
+	}
+
+	topLevelFields := make([]struct {
+		path  string
+		field sdkField
+	}, 0)
+	for _, field := range pkg.Models[ops.Model] {
+		if field.JsonName == "location" && b.definition.Location != nil || field.JsonName == "tags" && b.definition.Tags != nil || field.JsonName == "identity" {
+			continue
+		}
+		if field.JsonName == "properties" && b.definition.Properties != nil {
+			for _, nested := range pkg.Models[field.Type.Name] {
+				topLevelFields = append(topLevelFields, struct {
+					path  string
+					field sdkField
+				}{path: "properties." + nested.JsonName, field: nested})
+			}
+			continue
+		}
+		topLevelFields = append(topLevelFields, struct {
+			path  string
+			field sdkField
+		}{path: field.JsonName, field: field})
+	}
+
+	for _, v := range topLevelFields {
+		if _, ok := ignoredFields[v.field.JsonName]; ok {
+			continue
+		}
+
+		_, isComputed := b.computed[toSnakeCase(v.field.JsonName)]
+		prop, reason := b.property(v.field, v.path, b.definition.Name, 0, isComputed)
+		if prop == nil {
+			b.definition.Unsupported = append(b.definition.Unsupported, fmt.Sprintf("`%s` (%s)", v.path, reason))
+			continue
+		}
+		if _, ok := usedNames[prop.SchemaName]; ok {
+			b.definition.Unsupported = append(b.definition.Unsupported, fmt.Sprintf("`%s` (conflicts with the existing property %q)", v.path, prop.SchemaName))
+			continue
+		}
+		usedNames[prop.SchemaName] = struct{}{}
+
+		if isComputed {
+			b.definition.Attributes = append(b.definition.Attributes, *prop)
+			continue
+		}
+
+		prop.UpdateSupported = b.updatable(v.path, v.field.Type)
+		if b.updatePaths != nil {
+			// when the Update operation uses a separate model, properties which aren't present in it can't be updated
+			_, present := b.updatePaths[v.path]
+			prop.ForceNew = !present
+		}
+		b.definition.Arguments = append(b.definition.Arguments, *prop)
+	}
+
+	for name := range b.computed {
+		if _, ok := usedNames[name]; !ok {
+			return nil, fmt.Errorf("the computed property %q wasn't found within the model %q", name, ops.Model)
+		}
+	}
+
+	sort.Strings(b.definition.Unsupported)
+	return b.definition, nil
+}
+
+func (b *definitionBuilder) buildIdArguments() {
+	d := b.definition
+	segments := d.IdSegments
+	if len(segments) == 0 {
+		return
+	}
+	parentSegments := segments[:len(segments)-1]
+
+	d.IdArguments = append(d.IdArguments, idArgument{
+		Kind:       idArgumentName,
+		SchemaName: "name",
+		FieldName:  "Name",
+		Segment:    segments[len(segments)-1],
+	})
+
+	isStandardScope := len(parentSegments) <= 2
+	for i, segment := range parentSegments {
+		if (i == 0 && segment != "SubscriptionId") || (i == 1 && segment != "ResourceGroupName") {
+			isStandardScope = false
+		}
+	}
+	if !isStandardScope {
+		// look for the Resource ID of the parent Resource within the SDK package
+		parentIds := make([]string, 0)
+		for name, parentIdSegments := range b.pkg.ResourceIds {
+			if strings.Join(parentIdSegments, ",") == strings.Join(parentSegments, ",") {
+				parentIds = append(parentIds, name)
+			}
+		}
+		sort.Strings(parentIds)
+
+		if len(parentIds) > 0 {
+			d.ParentId = parentIds[0]
+			parentName := toSnakeCase(strings.TrimSuffix(d.ParentId, "Id"))
+			d.IdArguments = append(d.IdArguments, idArgument{
+				Kind:       idArgumentParentId,
+				SchemaName: parentName + "_id",
+				FieldName:  toPascalCase(parentName) + "Id",
+			})
+			return
+		}
+	}
+
+	for _, segment := range parentSegments {
+		switch segment {
+		case "SubscriptionId":
+			continue
+
+		case "ResourceGroupName":
+			d.IdArguments = append(d.IdArguments, idArgument{
+				Kind:       idArgumentResourceGroup,
+				SchemaName: "resource_group_name",
+				FieldName:  "ResourceGroupName",
+				Segment:    segment,
+			})
+
+		default:
+			d.IdArguments = append(d.IdArguments, idArgument{
+				Kind:       idArgumentSegment,
+				SchemaName: toSnakeCase(segment),
+				FieldName:  segment,
+				Segment:    segment,
+			})
+		}
+	}
+}
+
+// updatable returns whether the field at the specified path can be set within the update payload
+func (b *definitionBuilder) updatable(path string, sdkType typeRef) bool {
+	if b.updatePaths == nil {
+		// the Resource is updated using the same model as it's created with
+		return true
+	}
+	updateType, ok := b.updatePaths[path]
+	return ok && updateType == sdkType
+}
+
+// property maps the field within the SDK model to a property within the Terraform Schema, returning the reason
+// when this isn't possible
+func (b *definitionBuilder) property(field sdkField, path string, blockPrefix string, depth int, computed bool) (*property, string) {
+	if field.JsonName == "" || field.JsonName == "-" {
+		return nil, "no JSON name"
+	}
+	sdkType := field.Type
+	if sdkType.Name == "" {
+		return nil, "unsupported type"
+	}
+
+	output := property{
+		SchemaName: toSnakeCase(field.JsonName),
+		FieldName:  field.Name,
+		SdkType:    sdkType,
+		Required:   !computed && !sdkType.Pointer && !field.OmitEmpty,
+		Computed:   computed,
+	}
+
+	if sdkType.Package != "" {
+		return nil, sdkType.String()
+	}
+
+	_, isEnum := b.pkg.Constants[sdkType.Name]
+	switch {
+	case sdkType.Map:
+		if sdkType.Name != "string" {
+			return nil, sdkType.String()
+		}
+		output.Kind = kindStringMap
+
+	case sdkType.Slice && sdkType.Name == "string":
+		output.Kind = kindStringList
+
+	case sdkType.Slice && !isModel(b.pkg, sdkType):
+		return nil, sdkType.String()
+
+	case sdkType.Name == "bool":
+		output.Kind = kindBool
+
+	case sdkType.Name == "float64":
+		output.Kind = kindFloat
+
+	case sdkType.Name == "int" || sdkType.Name == "int64":
+		output.Kind = kindInt
+
+	case sdkType.Name == "string":
+		output.Kind = kindString
+
+	case isEnum:
+		output.Kind = kindEnum
+
+	case isModel(b.pkg, sdkType):
+		if depth >= maxBlockDepth {
+			return nil, fmt.Sprintf("%s is nested too deeply", sdkType)
+		}
+
+		nested := &block{
+			Name:    blockPrefix + field.Name,
+			SdkType: sdkType.Name,
+			List:    sdkType.Slice,
+		}
+		// the block is added prior to any nested blocks, so that these are generated in the same order as the schema
+		index := len(b.definition.Blocks)
+		b.definition.Blocks = append(b.definition.Blocks, nested)
+
+		for _, nestedField := range b.pkg.Models[sdkType.Name] {
+			if nestedField.JsonName == "provisioningState" {
+				continue
+			}
+			nestedPath := path + "." + nestedField.JsonName
+			nestedProperty, reason := b.property(nestedField, nestedPath, nested.Name, depth+1, computed)
+			if nestedProperty == nil {
+				b.definition.Unsupported = append(b.definition.Unsupported, fmt.Sprintf("`%s` (%s)", nestedPath, reason))
+				continue
+			}
+			nested.Properties = append(nested.Properties, *nestedProperty)
+		}
+		if len(nested.Properties) == 0 {
+			b.definition.Blocks = b.definition.Blocks[:index]
+			return nil, fmt.Sprintf("%s contains no supported fields", sdkType)
+		}
+
+		output.Kind = kindBlock
+		if sdkType.Slice {
+			output.Kind = kindBlockList
+		}
+		output.Block = nested
+
+	default:
+		return nil, sdkType.String()
+	}
+
+	return &output, ""
+}
+
+func isModel(pkg *sdkPackage, sdkType typeRef) bool {
+	if sdkType.Package != "" || sdkType.Map {
+		return false
+	}
+	_, ok := pkg.Models[sdkType.Name]
+	return ok
+}
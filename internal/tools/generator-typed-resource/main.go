@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	f := flag.NewFlagSet("generator-typed-resource", flag.ExitOnError)
+
+	name := f.String("name", "", "the name of the Resource Type, e.g. `azurerm_load_test`")
+	sdkPackage := f.String("sdk-package", "", "the path to the go-azure-sdk package, e.g. `resource-manager/loadtestservice/2022-12-01/loadtests`")
+	servicePackagePath := f.String("service-package-path", "", "the path to the Service Package where the Resource should be generated, e.g. `./internal/services/loadtestservice`")
+	client := f.String("client", "", "the path to the SDK Client within `metadata.Client`, e.g. `LoadTestService.V20221201.LoadTests`")
+	resourceId := f.String("resource-id", "", "the name of the Resource ID type within the SDK package, required when the SDK package contains multiple Resources, e.g. `LoadTestId`")
+	computed := f.String("computed", "", "a comma-separated list of the properties which should be exposed as Attributes (rather than Arguments), e.g. `data_plane_uri`")
+	sdkPath := f.String("sdk-path", "", "the path to the directory containing the go-azure-sdk package, which defaults to the vendor directory or the Go module cache")
+	rootDirectory := f.String("root-dir", "../../..", "the path to the root of this repository")
+	serviceName := f.String("service-name", "", "the name of the Service, used when generating the Service Registration for a new Service Package")
+
+	if err := f.Parse(os.Args[1:]); err != nil {
+		log.Fatalf("error parsing args: %+v", err)
+	}
+
+	if *name == "" || *sdkPackage == "" || *servicePackagePath == "" || *client == "" {
+		f.Usage()
+		os.Exit(1)
+	}
+
+	input := generatorInput{
+		ResourceType:       *name,
+		SdkPackage:         strings.Trim(*sdkPackage, "/"),
+		SdkPath:            *sdkPath,
+		ServicePackagePath: *servicePackagePath,
+		Client:             *client,
+		ResourceId:         *resourceId,
+		Computed:           strings.Split(*computed, ","),
+		RootDirectory:      *rootDirectory,
+		ServiceName:        *serviceName,
+	}
+	if err := run(input); err != nil {
+		log.Fatalf("error generating %q: %+v", *name, err)
+	}
+}
+
+type generatorInput struct {
+	ResourceType       string
+	SdkPackage         string
+	SdkPath            string
+	ServicePackagePath string
+	Client             string
+	ResourceId         string
+	Computed           []string
+	RootDirectory      string
+	ServiceName        string
+}
+
+func run(input generatorInput) error {
+	sdkPath := input.SdkPath
+	if sdkPath == "" {
+		path, err := findSdkPackage(input.RootDirectory, input.SdkPackage)
+		if err != nil {
+			return err
+		}
+		sdkPath = path
+	}
+
+	pkg, err := parseSdkPackage(sdkPath, fmt.Sprintf("github.com/hashicorp/go-azure-sdk/%s", input.SdkPackage))
+	if err != nil {
+		return err
+	}
+
+	ops, err := pkg.findOperations(input.ResourceId)
+	if err != nil {
+		return err
+	}
+
+	servicePackage := filepath.Base(filepath.Clean(input.ServicePackagePath))
+	definition, err := buildResourceDefinition(pkg, *ops, input.ResourceType, servicePackage, input.Client, input.Computed)
+	if err != nil {
+		return err
+	}
+
+	resource, err := renderResource(definition)
+	if err != nil {
+		return fmt.Errorf("generating the Resource: %+v", err)
+	}
+	tests, err := renderTests(definition)
+	if err != nil {
+		return fmt.Errorf("generating the Tests: %+v", err)
+	}
+
+	fileName := strings.TrimPrefix(input.ResourceType, "azurerm_") + "_resource"
+	files := map[string][]byte{
+		fileName + ".go":      resource,
+		fileName + "_test.go": tests,
+	}
+	for name := range files {
+		if _, err := os.Stat(filepath.Join(input.ServicePackagePath, name)); err == nil {
+			return fmt.Errorf("%q already exists within %q", name, input.ServicePackagePath)
+		}
+	}
+	if err := os.MkdirAll(input.ServicePackagePath, 0o755); err != nil {
+		return fmt.Errorf("creating %q: %+v", input.ServicePackagePath, err)
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(input.ServicePackagePath, name), contents, 0o644); err != nil {
+			return fmt.Errorf("writing %q: %+v", name, err)
+		}
+		log.Printf("[DEBUG] Generated %q", filepath.Join(input.ServicePackagePath, name))
+	}
+
+	if err := registerResource(input.ServicePackagePath, servicePackage, input.ServiceName, definition.ResourceStruct()); err != nil {
+		return fmt.Errorf("registering the Resource: %+v", err)
+	}
+
+	for _, v := range definition.Unsupported {
+		log.Printf("[WARN] The SDK field %s couldn't be mapped to the Terraform Schema and needs to be added manually", v)
+	}
+	log.Printf("[DEBUG] The generated Resource is intended as a starting point and needs to be reviewed - in particular the validation, the properties which are Computed/ForceNew and the values used in the Acceptance Tests")
+
+	return nil
+}
+
+// registerResource adds the Resource to the Service Registration, which is created when the Service Package doesn't exist
+func registerResource(servicePackagePath, servicePackage, serviceName, resourceStruct string) error {
+	registrationPath := filepath.Join(servicePackagePath, "registration.go")
+	existing, err := os.ReadFile(registrationPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("reading %q: %+v", registrationPath, err)
+		}
+
+		if serviceName == "" {
+			serviceName = toPascalCase(servicePackage)
+		}
+		contents, err := format.Source([]byte(newRegistration(servicePackage, serviceName, resourceStruct)))
+		if err != nil {
+			return fmt.Errorf("formatting the Service Registration: %+v", err)
+		}
+		if err := os.WriteFile(registrationPath, contents, 0o644); err != nil {
+			return fmt.Errorf("writing %q: %+v", registrationPath, err)
+		}
+		log.Printf("[DEBUG] Generated %q - the Service Registration also needs to be added to `SupportedTypedServices` within `./internal/provider/services.go` and a Client configured", registrationPath)
+		return nil
+	}
+
+	updated, err := addToRegistration(string(existing), resourceStruct)
+	if err != nil {
+		return fmt.Errorf("updating %q: %+v", registrationPath, err)
+	}
+	if err := os.WriteFile(registrationPath, updated, 0o644); err != nil {
+		return fmt.Errorf("writing %q: %+v", registrationPath, err)
+	}
+	log.Printf("[DEBUG] Registered %q within %q", resourceStruct, registrationPath)
+	return nil
+}
+
+// addToRegistration adds the Resource to the list of Resources returned from the (Typed) Service Registration
+func addToRegistration(contents string, resourceStruct string) ([]byte, error) {
+	method := strings.Index(contents, "Resources() []sdk.Resource {")
+	if method == -1 {
+		return nil, fmt.Errorf("the Service Registration doesn't contain a `Resources()` method, so the Resource needs to be registered manually")
+	}
+
+	const literal = "[]sdk.Resource{"
+	start := strings.Index(contents[method+len("Resources() []sdk.Resource {"):], literal)
+	if start == -1 {
+		return nil, fmt.Errorf("the `Resources()` method doesn't return a list of Resources, so the Resource needs to be registered manually")
+	}
+	start += method + len("Resources() []sdk.Resource {") + len(literal)
+
+	// insert the Resource prior to the closing brace of the list
+	depth := 1
+	end := start
+	for ; end < len(contents) && depth > 0; end++ {
+		switch contents[end] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("the list of Resources within the `Resources()` method isn't terminated")
+	}
+	closing := end - 1
+
+	existing := strings.TrimRight(contents[start:closing], " \t\n")
+	updated := fmt.Sprintf("%s%s\n%s{},\n%s", contents[:start], existing, resourceStruct, contents[closing:])
+
+	formatted, err := format.Source([]byte(updated))
+	if err != nil {
+		return nil, fmt.Errorf("formatting: %+v", err)
+	}
+	return formatted, nil
+}
+
+func newRegistration(servicePackage, serviceName, resourceStruct string) string {
+	return fmt.Sprintf(`%[1]spackage %[2]s
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+type Registration struct{}
+
+var _ sdk.TypedServiceRegistration = Registration{}
+
+// Name is the name of this Service
+func (r Registration) Name() string {
+	return %[3]q
+}
+
+// WebsiteCategories returns a list of categories which can be used for the sidebar
+func (r Registration) WebsiteCategories() []string {
+	return []string{
+		%[3]q,
+	}
+}
+
+// DataSources returns a list of Data Sources supported by this Service
+func (r Registration) DataSources() []sdk.DataSource {
+	return []sdk.DataSource{}
+}
+
+// Resources returns a list of Resources supported by this Service
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		%[4]s{},
+	}
+}
+`, fileHeader, servicePackage, serviceName, resourceStruct)
+}
@@ -0,0 +1,258 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestToSnakeCase(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "name",
+			Expected: "name",
+		},
+		{
+			Input:    "keyUrl",
+			Expected: "key_url",
+		},
+		{
+			Input:    "dataPlaneURI",
+			Expected: "data_plane_uri",
+		},
+		{
+			Input:    "URIPrefix",
+			Expected: "uri_prefix",
+		},
+		{
+			Input:    "ipv4Address",
+			Expected: "ipv4_address",
+		},
+		{
+			Input:    "ResourceId",
+			Expected: "resource_id",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Input)
+
+		if actual := toSnakeCase(v.Input); actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestAddToRegistration(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    string
+		Expected string
+		Error    bool
+	}{
+		{
+			Name: "empty list",
+			Input: `package example
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{}
+}
+`,
+			Expected: `package example
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		ExampleResource{},
+	}
+}
+`,
+		},
+		{
+			Name: "existing Resources",
+			Input: `package example
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		FirstResource{},
+		SecondResource{},
+	}
+}
+`,
+			Expected: `package example
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		FirstResource{},
+		SecondResource{},
+		ExampleResource{},
+	}
+}
+`,
+		},
+		{
+			Name: "Resources built up dynamically",
+			Input: `package example
+
+func (r Registration) Resources() []sdk.Resource {
+	resources := make([]sdk.Resource, 0)
+	return resources
+}
+`,
+			Error: true,
+		},
+		{
+			Name: "untyped Service Registration",
+			Input: `package example
+
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{}
+}
+`,
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual, err := addToRegistration(v.Input, "ExampleResource")
+		if err != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if string(actual) != v.Expected {
+			t.Fatalf("expected:\n%s\n\nbut got:\n%s", v.Expected, string(actual))
+		}
+	}
+}
+
+func TestGenerateLoadTest(t *testing.T) {
+	const sdkPackage = "resource-manager/loadtestservice/2022-12-01/loadtests"
+	path, err := findSdkPackage("../../..", sdkPackage)
+	if err != nil {
+		t.Skipf("skipping since the SDK package isn't available: %+v", err)
+	}
+
+	pkg, err := parseSdkPackage(path, "github.com/hashicorp/go-azure-sdk/"+sdkPackage)
+	if err != nil {
+		t.Fatalf("parsing the SDK package: %+v", err)
+	}
+
+	ops, err := pkg.findOperations("")
+	if err != nil {
+		t.Fatalf("finding the operations: %+v", err)
+	}
+	if ops.ResourceId != "LoadTestId" {
+		t.Fatalf("expected the Resource ID to be `LoadTestId` but got %q", ops.ResourceId)
+	}
+	if !ops.Create.LongRunning {
+		t.Fatalf("expected a long-running Create operation")
+	}
+	if ops.Update == nil || ops.Update.Input != "LoadTestResourcePatchRequestBody" {
+		t.Fatalf("expected the Update operation to use `LoadTestResourcePatchRequestBody`")
+	}
+
+	definition, err := buildResourceDefinition(pkg, *ops, "azurerm_load_test", "loadtestservice", "LoadTestService.V20221201.LoadTests", []string{"data_plane_uri"})
+	if err != nil {
+		t.Fatalf("building the Resource Definition: %+v", err)
+	}
+	if definition.Name != "LoadTest" {
+		t.Fatalf("expected the name to be `LoadTest` but got %q", definition.Name)
+	}
+	if definition.Location == nil || definition.Tags == nil || definition.Identity == nil {
+		t.Fatalf("expected the Resource to contain `location`, `tags` and `identity`")
+	}
+
+	resource, err := renderResource(definition)
+	if err != nil {
+		t.Fatalf("rendering the Resource: %+v", err)
+	}
+	for _, expected := range []string{
+		"type LoadTestResourceModel struct {",
+		"`tfschema:\"data_plane_uri\"`",
+		"var _ sdk.ResourceWithUpdate = LoadTestResource{}",
+		"func expandLoadTestEncryption(input []LoadTestEncryptionModel) *loadtests.EncryptionProperties {",
+		"client.CreateOrUpdateThenPoll(ctx, id, payload)",
+		"payload := loadtests.LoadTestResourcePatchRequestBody{",
+	} {
+		if !strings.Contains(string(resource), expected) {
+			t.Fatalf("expected the Resource to contain %q:\n\n%s", expected, string(resource))
+		}
+	}
+
+	tests, err := renderTests(definition)
+	if err != nil {
+		t.Fatalf("rendering the Tests: %+v", err)
+	}
+	for _, expected := range []string{
+		"package loadtestservice_test",
+		"func TestAccLoadTest_requiresImport(t *testing.T) {",
+		`name                = "acctest-lt-%d"`,
+		`resource "azurerm_user_assigned_identity" "test" {`,
+	} {
+		if !strings.Contains(string(tests), expected) {
+			t.Fatalf("expected the Tests to contain %q:\n\n%s", expected, string(tests))
+		}
+	}
+}
+
+func TestRunNewServicePackage(t *testing.T) {
+	const sdkPackage = "resource-manager/loadtestservice/2022-12-01/loadtests"
+	if _, err := findSdkPackage("../../..", sdkPackage); err != nil {
+		t.Skipf("skipping since the SDK package isn't available: %+v", err)
+	}
+
+	// the Service Package doesn't exist yet, so should be created
+	servicePackagePath := filepath.Join(t.TempDir(), "services", "loadtestservice")
+	input := generatorInput{
+		ResourceType:       "azurerm_load_test",
+		SdkPackage:         sdkPackage,
+		ServicePackagePath: servicePackagePath,
+		Client:             "LoadTestService.V20221201.LoadTests",
+		Computed:           []string{"data_plane_uri"},
+		RootDirectory:      "../../..",
+	}
+	if err := run(input); err != nil {
+		t.Fatalf("generating the Resource: %+v", err)
+	}
+
+	for _, name := range []string{"load_test_resource.go", "load_test_resource_test.go", "registration.go"} {
+		path := filepath.Join(servicePackagePath, name)
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading %q: %+v", name, err)
+		}
+
+		if _, err := parser.ParseFile(token.NewFileSet(), path, contents, parser.AllErrors); err != nil {
+			t.Fatalf("parsing %q: %+v\n\n%s", name, err, string(contents))
+		}
+		formatted, err := format.Source(contents)
+		if err != nil {
+			t.Fatalf("formatting %q: %+v", name, err)
+		}
+		if !bytes.Equal(formatted, contents) {
+			t.Fatalf("expected %q to be formatted:\n\n%s", name, string(contents))
+		}
+	}
+
+	if err := run(input); err == nil {
+		t.Fatalf("expected an error when the Resource already exists but didn't get one")
+	}
+}
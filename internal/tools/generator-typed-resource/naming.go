@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// toSnakeCase converts a camelCased/PascalCased name (e.g. `dataPlaneURI`) into snake_case (e.g. `data_plane_uri`)
+func toSnakeCase(input string) string {
+	runes := []rune(input)
+	output := strings.Builder{}
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				output.WriteRune('_')
+			}
+		}
+		output.WriteRune(unicode.ToLower(r))
+	}
+	return output.String()
+}
+
+// toPascalCase converts a snake_cased name (e.g. `load_test`) into PascalCase (e.g. `LoadTest`)
+func toPascalCase(input string) string {
+	output := ""
+	for _, segment := range strings.Split(input, "_") {
+		if segment == "" {
+			continue
+		}
+		output += strings.ToUpper(segment[:1]) + segment[1:]
+	}
+	return output
+}
+
+// toCamelCase converts a PascalCased name (e.g. `LoadTest`) into camelCase (e.g. `loadTest`)
+func toCamelCase(input string) string {
+	if input == "" {
+		return input
+	}
+	return strings.ToLower(input[:1]) + input[1:]
+}
@@ -0,0 +1,776 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"regexp"
+	"strings"
+)
+
+const fileHeader = `// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+`
+
+// candidateImports are the imports which may be used within the generated code, keyed by the package name
+var candidateImports = []struct {
+	name string
+	path string
+}{
+	{"context", "context"},
+	{"fmt", "fmt"},
+	{"testing", "testing"},
+	{"time", "time"},
+	{"pointer", "github.com/hashicorp/go-azure-helpers/lang/pointer"},
+	{"response", "github.com/hashicorp/go-azure-helpers/lang/response"},
+	{"commonschema", "github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"},
+	{"identity", "github.com/hashicorp/go-azure-helpers/resourcemanager/identity"},
+	{"location", "github.com/hashicorp/go-azure-helpers/resourcemanager/location"},
+	{"tags", "github.com/hashicorp/go-azure-helpers/resourcemanager/tags"},
+	{"acceptance", "github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"},
+	{"check", "github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"},
+	{"clients", "github.com/hashicorp/terraform-provider-azurerm/internal/clients"},
+	{"sdk", "github.com/hashicorp/terraform-provider-azurerm/internal/sdk"},
+	{"pluginsdk", "github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"},
+	{"validation", "github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"},
+	{"utils", "github.com/hashicorp/terraform-provider-azurerm/utils"},
+}
+
+// withImports prepends the package declaration and the imports used within the body, then formats the code
+func withImports(packageName string, sdkPackageName string, sdkImportPath string, body string) ([]byte, error) {
+	standard := make([]string, 0)
+	external := make([]string, 0)
+	for _, v := range candidateImports {
+		if !usesPackage(body, v.name) {
+			continue
+		}
+		if strings.Contains(v.path, ".") {
+			external = append(external, fmt.Sprintf("%q", v.path))
+		} else {
+			standard = append(standard, fmt.Sprintf("%q", v.path))
+		}
+	}
+	if usesPackage(body, sdkPackageName) {
+		external = append(external, fmt.Sprintf("%q", sdkImportPath))
+	}
+
+	imports := strings.Join(standard, "\n")
+	if len(external) > 0 {
+		imports += "\n\n" + strings.Join(external, "\n")
+	}
+
+	code := fmt.Sprintf("%spackage %s\n\nimport (\n%s\n)\n\n%s", fileHeader, packageName, imports, body)
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %+v\n\n%s", err, code)
+	}
+	return formatted, nil
+}
+
+func usesPackage(body string, packageName string) bool {
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(packageName) + `\.`).MatchString(body)
+}
+
+type resourceRenderer struct {
+	d   *resourceDefinition
+	pkg string
+}
+
+func renderResource(d *resourceDefinition) ([]byte, error) {
+	r := resourceRenderer{
+		d:   d,
+		pkg: d.Package.Name,
+	}
+
+	body := strings.Join([]string{
+		r.models(),
+		r.resource(),
+		r.arguments(),
+		r.attributes(),
+		r.create(),
+		r.read(),
+		r.update(),
+		r.delete(),
+		r.expandsAndFlattens(),
+	}, "\n\n")
+
+	return withImports(d.ServicePackage, d.Package.Name, d.Package.ImportPath, body)
+}
+
+func (r resourceRenderer) models() string {
+	lines := make([]string, 0)
+	for _, arg := range r.d.IdArguments {
+		lines = append(lines, fmt.Sprintf("%s string `tfschema:%q`", arg.FieldName, arg.SchemaName))
+	}
+	if r.d.Location != nil {
+		lines = append(lines, "Location string `tfschema:\"location\"`")
+	}
+	if r.d.Identity != nil {
+		lines = append(lines, fmt.Sprintf("Identity []identity.%s `tfschema:\"identity\"`", r.d.IdentityType().Model))
+	}
+	for _, prop := range append(append([]property{}, r.d.Arguments...), r.d.Attributes...) {
+		lines = append(lines, fmt.Sprintf("%s %s `tfschema:%q`", prop.FieldName, prop.modelType(), prop.SchemaName))
+	}
+	if r.d.Tags != nil {
+		lines = append(lines, "Tags map[string]interface{} `tfschema:\"tags\"`")
+	}
+
+	output := fmt.Sprintf("type %s struct {\n%s\n}", r.d.ModelStruct(), strings.Join(lines, "\n"))
+
+	for _, b := range r.d.Blocks {
+		fields := make([]string, 0)
+		for _, prop := range b.Properties {
+			fields = append(fields, fmt.Sprintf("%s %s `tfschema:%q`", prop.FieldName, prop.modelType(), prop.SchemaName))
+		}
+		output += fmt.Sprintf("\n\ntype %s struct {\n%s\n}", b.ModelName(), strings.Join(fields, "\n"))
+	}
+
+	return output
+}
+
+func (r resourceRenderer) resource() string {
+	d := r.d
+	return fmt.Sprintf(`type %[1]s struct{}
+
+var _ sdk.ResourceWithUpdate = %[1]s{}
+
+func (r %[1]s) ResourceType() string {
+	return %[2]q
+}
+
+func (r %[1]s) ModelObject() interface{} {
+	return &%[3]s{}
+}
+
+func (r %[1]s) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return %[4]s.Validate%[5]sID
+}`, d.ResourceStruct(), d.ResourceType, d.ModelStruct(), r.pkg, strings.TrimSuffix(d.Operations.ResourceId, "Id"))
+}
+
+func (r resourceRenderer) arguments() string {
+	items := make([]string, 0)
+	for _, arg := range r.d.IdArguments {
+		switch arg.Kind {
+		case idArgumentResourceGroup:
+			items = append(items, fmt.Sprintf("%q: commonschema.ResourceGroupName(),", arg.SchemaName))
+
+		case idArgumentParentId:
+			items = append(items, fmt.Sprintf(`%q: {
+	Type:         pluginsdk.TypeString,
+	Required:     true,
+	ForceNew:     true,
+	ValidateFunc: %s.Validate%sID,
+},`, arg.SchemaName, r.pkg, strings.TrimSuffix(r.d.ParentId, "Id")))
+
+		default:
+			items = append(items, fmt.Sprintf(`%q: {
+	Type:         pluginsdk.TypeString,
+	Required:     true,
+	ForceNew:     true,
+	ValidateFunc: validation.StringIsNotEmpty,
+},`, arg.SchemaName))
+		}
+	}
+
+	if r.d.Location != nil {
+		items = append(items, `"location": commonschema.Location(),`)
+	}
+	if r.d.Identity != nil {
+		schema := r.d.IdentityType().Schema
+		if r.d.Identity.ForceNew {
+			schema += "ForceNew"
+		}
+		items = append(items, fmt.Sprintf(`"identity": commonschema.%s(),`, schema))
+	}
+
+	for _, prop := range r.d.Arguments {
+		items = append(items, fmt.Sprintf("%q: %s,", prop.SchemaName, r.schema(prop)))
+	}
+
+	if r.d.Tags != nil {
+		tagsSchema := "Tags"
+		if r.d.Tags.ForceNew {
+			tagsSchema = "TagsForceNew"
+		}
+		items = append(items, fmt.Sprintf(`"tags": commonschema.%s(),`, tagsSchema))
+	}
+
+	return fmt.Sprintf(`func (r %s) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+%s
+	}
+}`, r.d.ResourceStruct(), strings.Join(items, "\n\n"))
+}
+
+func (r resourceRenderer) attributes() string {
+	items := make([]string, 0)
+	for _, prop := range r.d.Attributes {
+		items = append(items, fmt.Sprintf("%q: %s,", prop.SchemaName, r.schema(prop)))
+	}
+
+	body := "return map[string]*pluginsdk.Schema{}"
+	if len(items) > 0 {
+		body = fmt.Sprintf("return map[string]*pluginsdk.Schema{\n%s\n}", strings.Join(items, "\n\n"))
+	}
+	return fmt.Sprintf("func (r %s) Attributes() map[string]*pluginsdk.Schema {\n%s\n}", r.d.ResourceStruct(), body)
+}
+
+// schema returns the Terraform Schema for the specified property
+func (r resourceRenderer) schema(prop property) string {
+	lines := make([]string, 0)
+	switch prop.Kind {
+	case kindBool:
+		lines = append(lines, "Type: pluginsdk.TypeBool,")
+	case kindFloat:
+		lines = append(lines, "Type: pluginsdk.TypeFloat,")
+	case kindInt:
+		lines = append(lines, "Type: pluginsdk.TypeInt,")
+	case kindStringMap:
+		lines = append(lines, "Type: pluginsdk.TypeMap,")
+	case kindBlock, kindBlockList, kindStringList:
+		lines = append(lines, "Type: pluginsdk.TypeList,")
+	default:
+		lines = append(lines, "Type: pluginsdk.TypeString,")
+	}
+
+	switch {
+	case prop.Computed:
+		lines = append(lines, "Computed: true,")
+	case prop.Required:
+		lines = append(lines, "Required: true,")
+	default:
+		lines = append(lines, "Optional: true,")
+	}
+	if prop.ForceNew {
+		lines = append(lines, "ForceNew: true,")
+	}
+	if prop.Kind == kindBlock && !prop.Computed {
+		lines = append(lines, "MaxItems: 1,")
+	}
+
+	if !prop.Computed {
+		switch prop.Kind {
+		case kindString:
+			lines = append(lines, "ValidateFunc: validation.StringIsNotEmpty,")
+		case kindEnum:
+			lines = append(lines, fmt.Sprintf("ValidateFunc: validation.StringInSlice(%s.PossibleValuesFor%s(), false),", r.pkg, prop.SdkType.Name))
+		}
+	}
+
+	switch prop.Kind {
+	case kindStringList:
+		validate := ""
+		if !prop.Computed {
+			validate = "\nValidateFunc: validation.StringIsNotEmpty,"
+		}
+		lines = append(lines, fmt.Sprintf("Elem: &pluginsdk.Schema{\nType: pluginsdk.TypeString,%s\n},", validate))
+
+	case kindStringMap:
+		lines = append(lines, "Elem: &pluginsdk.Schema{\nType: pluginsdk.TypeString,\n},")
+
+	case kindBlock, kindBlockList:
+		items := make([]string, 0)
+		for _, nested := range prop.Block.Properties {
+			items = append(items, fmt.Sprintf("%q: %s,", nested.SchemaName, r.schema(nested)))
+		}
+		lines = append(lines, fmt.Sprintf("Elem: &pluginsdk.Resource{\nSchema: map[string]*pluginsdk.Schema{\n%s\n},\n},", strings.Join(items, "\n\n")))
+	}
+
+	return fmt.Sprintf("{\n%s\n}", strings.Join(lines, "\n"))
+}
+
+// createId returns the code used to build the Resource ID from the Terraform model named `config`
+func (r resourceRenderer) createId() string {
+	d := r.d
+	constructor := fmt.Sprintf("%s.New%sID", r.pkg, strings.TrimSuffix(d.Operations.ResourceId, "Id"))
+
+	if d.ParentId != "" {
+		parent := d.IdArguments[1]
+		variable := toCamelCase(d.ParentId)
+		args := make([]string, 0)
+		for _, segment := range d.IdSegments[:len(d.IdSegments)-1] {
+			args = append(args, fmt.Sprintf("%s.%s", variable, segment))
+		}
+		args = append(args, "config.Name")
+
+		return fmt.Sprintf(`%[1]s, err := %[2]s.Parse%[3]sID(config.%[4]s)
+if err != nil {
+	return err
+}
+
+id := %[5]s(%[6]s)`, variable, r.pkg, strings.TrimSuffix(d.ParentId, "Id"), parent.FieldName, constructor, strings.Join(args, ", "))
+	}
+
+	output := ""
+	args := make([]string, 0)
+	for _, segment := range d.IdSegments {
+		if segment == "SubscriptionId" {
+			output = "subscriptionId := metadata.Client.Account.SubscriptionId\n"
+			args = append(args, "subscriptionId")
+			continue
+		}
+		for _, arg := range d.IdArguments {
+			if arg.Segment == segment {
+				args = append(args, "config."+arg.FieldName)
+			}
+		}
+	}
+
+	return output + fmt.Sprintf("id := %s(%s)", constructor, strings.Join(args, ", "))
+}
+
+func (r resourceRenderer) create() string {
+	d := r.d
+	ops := d.Operations
+
+	expandIdentity := ""
+	if d.Identity != nil {
+		expandIdentity = fmt.Sprintf(`expandedIdentity, err := identity.%s(config.Identity)
+if err != nil {
+	return fmt.Errorf("expanding `+"`identity`"+`: %%+v", err)
+}
+
+`, d.IdentityType().Expand)
+	}
+
+	fields := make([]string, 0)
+	if d.Location != nil {
+		fields = append(fields, fmt.Sprintf("%s: %s,", d.Location.Field.Name, wrapPointer("location.Normalize(config.Location)", d.Location.Field.Type.Pointer)))
+	}
+	if d.Identity != nil {
+		fields = append(fields, fmt.Sprintf("%s: %s,", d.Identity.Field.Name, unwrapPointer("expandedIdentity", !d.Identity.Field.Type.Pointer)))
+	}
+
+	properties := make([]string, 0)
+	for _, prop := range d.Arguments {
+		line := fmt.Sprintf("%s: %s,", prop.FieldName, r.expandExpression(prop, "config"))
+		if r.inProperties(prop) {
+			properties = append(properties, line)
+		} else {
+			fields = append(fields, line)
+		}
+	}
+	if d.Properties != nil {
+		value := fmt.Sprintf("%s%s.%s{}", addressOf(d.Properties.Type.Pointer), r.pkg, d.Properties.Type.Name)
+		if len(properties) > 0 {
+			value = fmt.Sprintf("%s%s.%s{\n%s\n}", addressOf(d.Properties.Type.Pointer), r.pkg, d.Properties.Type.Name, strings.Join(properties, "\n"))
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s,", d.Properties.Name, value))
+	}
+	if d.Tags != nil {
+		fields = append(fields, fmt.Sprintf("%s: %s,", d.Tags.Field.Name, unwrapPointer("tags.Expand(config.Tags)", !d.Tags.Field.Type.Pointer)))
+	}
+
+	return fmt.Sprintf(`func (r %[1]s) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s
+
+			var config %[3]s
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %%+v", err)
+			}
+
+			%[4]s
+
+			existing, err := %[5]s
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for presence of existing %%s: %%+v", id, err)
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			%[6]spayload := %[7]s.%[8]s{
+				%[9]s
+			}
+
+			%[10]s
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}`, d.ResourceStruct(), d.Client, d.ModelStruct(), r.createId(), ops.Read.call("id"), expandIdentity, r.pkg, ops.Model, strings.Join(fields, "\n"), r.checkedCall(ops.Create, "creating %s", "id", "payload"))
+}
+
+// checkedCall returns the code used to call the operation and return any error
+func (r resourceRenderer) checkedCall(op operation, message string, args ...string) string {
+	id := args[0]
+	if op.LongRunning {
+		return fmt.Sprintf("if err := %s; err != nil {\n\treturn fmt.Errorf(%q, %s, err)\n}", op.call(args...), message+": %+v", id)
+	}
+	return fmt.Sprintf("if _, err := %s; err != nil {\n\treturn fmt.Errorf(%q, %s, err)\n}", op.call(args...), message+": %+v", id)
+}
+
+func (r resourceRenderer) parseId() string {
+	return fmt.Sprintf(`id, err := %s.Parse%sID(metadata.ResourceData.Id())
+if err != nil {
+	return err
+}`, r.pkg, strings.TrimSuffix(r.d.Operations.ResourceId, "Id"))
+}
+
+func (r resourceRenderer) read() string {
+	d := r.d
+	ops := d.Operations
+
+	idFields := make([]string, 0)
+	for _, arg := range d.IdArguments {
+		if arg.Kind == idArgumentParentId {
+			args := make([]string, 0)
+			for _, segment := range d.IdSegments[:len(d.IdSegments)-1] {
+				args = append(args, "id."+segment)
+			}
+			idFields = append(idFields, fmt.Sprintf("%s: %s.New%sID(%s).ID(),", arg.FieldName, r.pkg, strings.TrimSuffix(d.ParentId, "Id"), strings.Join(args, ", ")))
+			continue
+		}
+		idFields = append(idFields, fmt.Sprintf("%s: id.%s,", arg.FieldName, arg.Segment))
+	}
+
+	lines := make([]string, 0)
+	if d.Location != nil {
+		if d.Location.Field.Type.Pointer {
+			lines = append(lines, fmt.Sprintf("state.Location = location.NormalizeNilable(model.%s)", d.Location.Field.Name))
+		} else {
+			lines = append(lines, fmt.Sprintf("state.Location = location.Normalize(model.%s)", d.Location.Field.Name))
+		}
+	}
+	if d.Identity != nil {
+		identityType := d.IdentityType()
+		flatten := fmt.Sprintf("identity.%s(%smodel.%s)", identityType.Flatten, addressOf(!d.Identity.Field.Type.Pointer), d.Identity.Field.Name)
+		if identityType.FlattenReturnsError {
+			lines = append(lines, fmt.Sprintf(`
+flattenedIdentity, err := %s
+if err != nil {
+	return fmt.Errorf("flattening `+"`identity`"+`: %%+v", err)
+}
+state.Identity = %s
+`, flatten, unwrapPointer("flattenedIdentity", identityType.FlattenReturnsPointer)))
+		} else {
+			lines = append(lines, fmt.Sprintf("state.Identity = %s", flatten))
+		}
+	}
+	if d.Tags != nil {
+		lines = append(lines, fmt.Sprintf("state.Tags = tags.Flatten(%smodel.%s)", addressOf(!d.Tags.Field.Type.Pointer), d.Tags.Field.Name))
+	}
+
+	properties := make([]string, 0)
+	for _, prop := range append(append([]property{}, d.Arguments...), d.Attributes...) {
+		if r.inProperties(prop) {
+			properties = append(properties, fmt.Sprintf("state.%s = %s", prop.FieldName, r.flattenExpression(prop, "props")))
+		} else {
+			lines = append(lines, fmt.Sprintf("state.%s = %s", prop.FieldName, r.flattenExpression(prop, "model")))
+		}
+	}
+	if d.Properties != nil && len(properties) > 0 {
+		if d.Properties.Type.Pointer {
+			lines = append(lines, fmt.Sprintf("\nif props := model.%s; props != nil {\n%s\n}", d.Properties.Name, strings.Join(properties, "\n")))
+		} else {
+			lines = append(lines, fmt.Sprintf("\nprops := model.%s\n%s", d.Properties.Name, strings.Join(properties, "\n")))
+		}
+	}
+
+	modelBlock := ""
+	if len(lines) > 0 {
+		modelBlock = fmt.Sprintf("\n\nif model := resp.Model; model != nil {\n%s\n}", strings.Join(lines, "\n"))
+	}
+
+	return fmt.Sprintf(`func (r %[1]s) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s
+
+			%[3]s
+
+			resp, err := %[4]s
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %%s: %%+v", *id, err)
+			}
+
+			state := %[5]s{
+				%[6]s
+			}%[7]s
+
+			return metadata.Encode(&state)
+		},
+	}
+}`, d.ResourceStruct(), d.Client, r.parseId(), ops.Read.call("*id"), d.ModelStruct(), strings.Join(idFields, "\n"), modelBlock)
+}
+
+func (r resourceRenderer) update() string {
+	d := r.d
+	ops := d.Operations
+
+	updateOp := ops.Create
+	payload := ""
+	propertiesField := d.Properties
+	if ops.Update != nil {
+		updateOp = *ops.Update
+	}
+
+	if d.UpdateModel != "" {
+		propertiesField = d.UpdateProperties
+	} else {
+		payload = fmt.Sprintf(`existing, err := %s
+if err != nil {
+	return fmt.Errorf("retrieving %%s: %%+v", *id, err)
+}
+if existing.Model == nil {
+	return fmt.Errorf("retrieving %%s: `+"`model`"+` was nil", *id)
+}
+payload := *existing.Model`, ops.Read.call("*id"))
+	}
+
+	changes := make([]string, 0)
+	if d.Identity != nil && !d.Identity.ForceNew {
+		changes = append(changes, fmt.Sprintf(`if metadata.ResourceData.HasChange("identity") {
+	expandedIdentity, err := identity.%s(config.Identity)
+	if err != nil {
+		return fmt.Errorf("expanding `+"`identity`"+`: %%+v", err)
+	}
+	payload.%s = %s
+}`, d.IdentityType().Expand, d.Identity.Field.Name, unwrapPointer("expandedIdentity", !d.Identity.Field.Type.Pointer)))
+	}
+
+	propertiesChanged := false
+	for _, prop := range d.Arguments {
+		if prop.ForceNew {
+			continue
+		}
+
+		target := "payload." + prop.FieldName
+		if r.inProperties(prop) {
+			target = fmt.Sprintf("payload.%s.%s", propertiesField.Name, prop.FieldName)
+			propertiesChanged = true
+		}
+
+		if !prop.UpdateSupported {
+			changes = append(changes, fmt.Sprintf("if metadata.ResourceData.HasChange(%[1]q) {\n// TODO: `%[1]s` uses a different type within the `%[2]s` model, so needs to be mapped manually\n}", prop.SchemaName, d.UpdateModel))
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("if metadata.ResourceData.HasChange(%q) {\n%s = %s\n}", prop.SchemaName, target, r.expandExpression(prop, "config")))
+	}
+
+	if d.Tags != nil && !d.Tags.ForceNew {
+		changes = append(changes, fmt.Sprintf("if metadata.ResourceData.HasChange(\"tags\") {\npayload.%s = %s\n}", d.Tags.Field.Name, unwrapPointer("tags.Expand(config.Tags)", !d.Tags.Field.Type.Pointer)))
+	}
+
+	switch {
+	case d.UpdateModel != "" && propertiesChanged && propertiesField.Type.Pointer:
+		payload = fmt.Sprintf("payload := %[1]s.%[2]s{\n%[3]s: &%[1]s.%[4]s{},\n}", r.pkg, d.UpdateModel, propertiesField.Name, propertiesField.Type.Name)
+	case d.UpdateModel != "":
+		payload = fmt.Sprintf("payload := %s.%s{}", r.pkg, d.UpdateModel)
+	case propertiesChanged && propertiesField.Type.Pointer:
+		payload += fmt.Sprintf("\n\nif payload.%[1]s == nil {\n\tpayload.%[1]s = &%[2]s.%[3]s{}\n}", propertiesField.Name, r.pkg, propertiesField.Type.Name)
+	}
+
+	return fmt.Sprintf(`func (r %[1]s) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s
+
+			%[3]s
+
+			var config %[4]s
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %%+v", err)
+			}
+
+			%[5]s
+
+			%[6]s
+
+			%[7]s
+
+			return nil
+		},
+	}
+}`, d.ResourceStruct(), d.Client, r.parseId(), d.ModelStruct(), payload, strings.Join(changes, "\n\n"), r.checkedCall(updateOp, "updating %s", "*id", "payload"))
+}
+
+func (r resourceRenderer) delete() string {
+	d := r.d
+	return fmt.Sprintf(`func (r %[1]s) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s
+
+			%[3]s
+
+			%[4]s
+
+			return nil
+		},
+	}
+}`, d.ResourceStruct(), d.Client, r.parseId(), r.checkedCall(d.Operations.Delete, "deleting %s", "*id"))
+}
+
+// inProperties returns whether the top-level property is a field within the `properties` model
+func (r resourceRenderer) inProperties(prop property) bool {
+	if r.d.Properties == nil {
+		return false
+	}
+	for _, field := range r.d.Package.Models[r.d.Properties.Type.Name] {
+		if field.Name == prop.FieldName {
+			return true
+		}
+	}
+	return false
+}
+
+// expandExpression returns the expression used to map the property within the Terraform model `variable` to the SDK field
+func (r resourceRenderer) expandExpression(prop property, variable string) string {
+	value := fmt.Sprintf("%s.%s", variable, prop.FieldName)
+	switch prop.Kind {
+	case kindBlock, kindBlockList:
+		return unwrapPointer(fmt.Sprintf("expand%s(%s)", prop.Block.Name, value), !prop.SdkType.Pointer)
+	case kindEnum:
+		value = fmt.Sprintf("%s.%s(%s)", r.pkg, prop.SdkType.Name, value)
+	case kindInt:
+		if prop.SdkType.Name == "int" {
+			value = fmt.Sprintf("int(%s)", value)
+		}
+	}
+	return wrapPointer(value, prop.SdkType.Pointer)
+}
+
+// flattenExpression returns the expression used to map the SDK field within `variable` to the property within the Terraform model
+func (r resourceRenderer) flattenExpression(prop property, variable string) string {
+	value := fmt.Sprintf("%s.%s", variable, prop.FieldName)
+	switch prop.Kind {
+	case kindBlock, kindBlockList:
+		return fmt.Sprintf("flatten%s(%s%s)", prop.Block.Name, addressOf(!prop.SdkType.Pointer), value)
+	}
+
+	value = unwrapPointer(value, prop.SdkType.Pointer)
+	switch prop.Kind {
+	case kindEnum:
+		value = fmt.Sprintf("string(%s)", value)
+	case kindInt:
+		if prop.SdkType.Name == "int" {
+			value = fmt.Sprintf("int64(%s)", value)
+		}
+	}
+	return value
+}
+
+func (r resourceRenderer) expandsAndFlattens() string {
+	output := make([]string, 0)
+	for _, b := range r.d.Blocks {
+		if !b.hasArguments() {
+			// blocks which are Computed only need to be flattened
+			output = append(output, r.flatten(*b))
+			continue
+		}
+		output = append(output, r.expand(*b), r.flatten(*b))
+	}
+	return strings.Join(output, "\n\n")
+}
+
+func (b block) hasArguments() bool {
+	for _, prop := range b.Properties {
+		if !prop.Computed {
+			return true
+		}
+	}
+	return false
+}
+
+func (r resourceRenderer) expand(b block) string {
+	fields := make([]string, 0)
+	for _, prop := range b.Properties {
+		if prop.Computed {
+			continue
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s,", prop.FieldName, r.expandExpression(prop, "v")))
+	}
+
+	if b.List {
+		return fmt.Sprintf(`func expand%[1]s(input []%[2]s) *[]%[3]s.%[4]s {
+	output := make([]%[3]s.%[4]s, 0)
+	for _, v := range input {
+		output = append(output, %[3]s.%[4]s{
+			%[5]s
+		})
+	}
+	return &output
+}`, b.Name, b.ModelName(), r.pkg, b.SdkType, strings.Join(fields, "\n"))
+	}
+
+	return fmt.Sprintf(`func expand%[1]s(input []%[2]s) *%[3]s.%[4]s {
+	if len(input) == 0 {
+		return nil
+	}
+
+	v := input[0]
+	return &%[3]s.%[4]s{
+		%[5]s
+	}
+}`, b.Name, b.ModelName(), r.pkg, b.SdkType, strings.Join(fields, "\n"))
+}
+
+func (r resourceRenderer) flatten(b block) string {
+	fields := make([]string, 0)
+	for _, prop := range b.Properties {
+		fields = append(fields, fmt.Sprintf("%s: %s,", prop.FieldName, r.flattenExpression(prop, "v")))
+	}
+
+	if b.List {
+		return fmt.Sprintf(`func flatten%[1]s(input *[]%[3]s.%[4]s) []%[2]s {
+	output := make([]%[2]s, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		output = append(output, %[2]s{
+			%[5]s
+		})
+	}
+	return output
+}`, b.Name, b.ModelName(), r.pkg, b.SdkType, strings.Join(fields, "\n"))
+	}
+
+	return fmt.Sprintf(`func flatten%[1]s(input *%[3]s.%[4]s) []%[2]s {
+	output := make([]%[2]s, 0)
+	if input == nil {
+		return output
+	}
+
+	v := *input
+	return append(output, %[2]s{
+		%[5]s
+	})
+}`, b.Name, b.ModelName(), r.pkg, b.SdkType, strings.Join(fields, "\n"))
+}
+
+func wrapPointer(value string, pointer bool) string {
+	if pointer {
+		return fmt.Sprintf("pointer.To(%s)", value)
+	}
+	return value
+}
+
+func unwrapPointer(value string, pointer bool) string {
+	if pointer {
+		return fmt.Sprintf("pointer.From(%s)", value)
+	}
+	return value
+}
+
+func addressOf(pointer bool) string {
+	if pointer {
+		return "&"
+	}
+	return ""
+}
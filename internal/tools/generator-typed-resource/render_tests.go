@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+type testRenderer struct {
+	d   *resourceDefinition
+	pkg string
+}
+
+func renderTests(d *resourceDefinition) ([]byte, error) {
+	r := testRenderer{
+		d:   d,
+		pkg: d.Package.Name,
+	}
+
+	body := strings.Join([]string{
+		fmt.Sprintf("type %s struct{}", d.ResourceStruct()),
+		r.testFunc("basic", "r.basic(data)", "data.ImportStep()"),
+		r.testFunc("requiresImport", "r.basic(data)", "data.RequiresImportErrorStep(r.requiresImport)"),
+		r.testFunc("complete", "r.complete(data)", "data.ImportStep()"),
+		r.updateTestFunc(),
+		r.exists(),
+		r.config("basic", false),
+		r.requiresImport(),
+		r.config("complete", true),
+		r.template(),
+	}, "\n\n")
+
+	return withImports(d.ServicePackage+"_test", d.Package.Name, d.Package.ImportPath, body)
+}
+
+func (r testRenderer) testFunc(name string, config string, finalStep string) string {
+	return fmt.Sprintf(`func TestAcc%[1]s_%[2]s(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[3]q, "test")
+	r := %[4]s{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: %[5]s,
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		%[6]s,
+	})
+}`, r.d.Name, name, r.d.ResourceType, r.d.ResourceStruct(), config, finalStep)
+}
+
+func (r testRenderer) updateTestFunc() string {
+	steps := make([]string, 0)
+	for _, config := range []string{"basic", "complete", "basic"} {
+		steps = append(steps, fmt.Sprintf(`{
+			Config: r.%s(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),`, config))
+	}
+
+	return fmt.Sprintf(`func TestAcc%[1]s_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[2]q, "test")
+	r := %[3]s{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		%[4]s
+	})
+}`, r.d.Name, r.d.ResourceType, r.d.ResourceStruct(), strings.Join(steps, "\n"))
+}
+
+func (r testRenderer) exists() string {
+	return fmt.Sprintf(`func (r %[1]s) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := %[2]s.Parse%[3]sID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := clients.%[4]s
+	resp, err := %[5]s
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %%s: %%+v", *id, err)
+	}
+	return utils.Bool(resp.Model != nil), nil
+}`, r.d.ResourceStruct(), r.pkg, strings.TrimSuffix(r.d.Operations.ResourceId, "Id"), r.d.Client, r.d.Operations.Read.call("*id"))
+}
+
+// resourceNamePrefix returns the prefix used for the name of the Resource within the tests, e.g. `acctest-lt` for `LoadTest`
+func (r testRenderer) resourceNamePrefix() string {
+	initials := ""
+	for _, segment := range strings.Split(strings.TrimPrefix(r.d.ResourceType, "azurerm_"), "_") {
+		if segment != "" {
+			initials += segment[:1]
+		}
+	}
+	return "acctest-" + initials
+}
+
+// configBody returns the HCL for the Resource, where complete is whether all of the Arguments should be set
+// (rather than only those which are Required)
+func (r testRenderer) configBody(complete bool) []string {
+	lines := make([]string, 0)
+	for _, arg := range r.d.IdArguments {
+		switch arg.Kind {
+		case idArgumentName:
+			lines = append(lines, fmt.Sprintf(`name = "%s-%%d"`, r.resourceNamePrefix()))
+		case idArgumentResourceGroup:
+			lines = append(lines, "resource_group_name = azurerm_resource_group.test.name")
+		default:
+			lines = append(lines, fmt.Sprintf("# TODO: replace with a reference to the parent Resource\n%s = %q", arg.SchemaName, "TODO"))
+		}
+	}
+	if r.d.Location != nil {
+		lines = append(lines, "location = azurerm_resource_group.test.location")
+	}
+
+	lines = append(lines, r.propertiesConfig(r.d.Arguments, complete)...)
+
+	if complete && r.d.Identity != nil {
+		identityType := r.d.IdentityType()
+		switch {
+		case identityType.SystemAssigned && identityType.UserAssigned:
+			lines = append(lines, "\nidentity {\ntype = \"SystemAssigned, UserAssigned\"\nidentity_ids = [azurerm_user_assigned_identity.test.id]\n}")
+		case identityType.UserAssigned:
+			lines = append(lines, "\nidentity {\ntype = \"UserAssigned\"\nidentity_ids = [azurerm_user_assigned_identity.test.id]\n}")
+		default:
+			lines = append(lines, "\nidentity {\ntype = \"SystemAssigned\"\n}")
+		}
+	}
+
+	if complete && r.d.Tags != nil {
+		lines = append(lines, "\ntags = {\nenvironment = \"terraform-acctests\"\n}")
+	}
+
+	return lines
+}
+
+// propertiesConfig returns the HCL for the properties, where any attributes are followed by the blocks
+func (r testRenderer) propertiesConfig(properties []property, complete bool) []string {
+	attributes := make([]string, 0)
+	blocks := make([]string, 0)
+	for _, prop := range properties {
+		if prop.Computed || (!complete && !prop.Required) {
+			continue
+		}
+
+		switch prop.Kind {
+		case kindBlock, kindBlockList:
+			nested := r.propertiesConfig(prop.Block.Properties, complete)
+			blocks = append(blocks, fmt.Sprintf("\n%s {\n%s\n}", prop.SchemaName, strings.TrimPrefix(strings.Join(nested, "\n"), "\n")))
+		default:
+			attributes = append(attributes, fmt.Sprintf("%s = %s", prop.SchemaName, r.exampleValue(prop)))
+		}
+	}
+	return append(attributes, blocks...)
+}
+
+func (r testRenderer) exampleValue(prop property) string {
+	switch prop.Kind {
+	case kindBool:
+		return "true"
+	case kindFloat, kindInt:
+		return "1"
+	case kindEnum:
+		if values := r.d.Package.Constants[prop.SdkType.Name]; len(values) > 0 {
+			return escapeFormat(fmt.Sprintf("%q", values[0]))
+		}
+	case kindStringList:
+		return `["example"]`
+	case kindStringMap:
+		return "{\nexample = \"value\"\n}"
+	}
+	return `"example"`
+}
+
+func (r testRenderer) config(name string, complete bool) string {
+	return fmt.Sprintf(`func (r %[1]s) %[2]s(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+%%s
+
+resource %[3]q "test" {
+%[4]s}
+`+"`"+`, r.template(data), data.RandomInteger)
+}`, r.d.ResourceStruct(), name, r.d.ResourceType, formatHcl(r.configBody(complete)))
+}
+
+func (r testRenderer) requiresImport() string {
+	lines := make([]string, 0)
+	for _, arg := range r.d.IdArguments {
+		lines = append(lines, fmt.Sprintf("%[1]s = %[2]s.test.%[1]s", arg.SchemaName, r.d.ResourceType))
+	}
+	if r.d.Location != nil {
+		lines = append(lines, fmt.Sprintf("location = %s.test.location", r.d.ResourceType))
+	}
+	for _, prop := range r.d.Arguments {
+		if !prop.Required {
+			continue
+		}
+		switch prop.Kind {
+		case kindBlock, kindBlockList:
+			// blocks can't be referenced, so are duplicated
+			lines = append(lines, r.propertiesConfig([]property{prop}, false)...)
+		default:
+			lines = append(lines, fmt.Sprintf("%[1]s = %[2]s.test.%[1]s", prop.SchemaName, r.d.ResourceType))
+		}
+	}
+
+	return fmt.Sprintf(`func (r %[1]s) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+%%s
+
+resource %[2]q "import" {
+%[3]s}
+`+"`"+`, r.basic(data))
+}`, r.d.ResourceStruct(), r.d.ResourceType, formatHcl(lines))
+}
+
+func (r testRenderer) template() string {
+	needsResourceGroup := r.d.Location != nil
+	for _, arg := range r.d.IdArguments {
+		if arg.Kind == idArgumentResourceGroup {
+			needsResourceGroup = true
+		}
+	}
+	needsUserAssignedIdentity := r.d.Identity != nil && r.d.IdentityType().UserAssigned
+
+	if !needsResourceGroup && !needsUserAssignedIdentity {
+		return fmt.Sprintf(`func (r %s) template(data acceptance.TestData) string {
+	return `+"`"+`
+provider "azurerm" {
+  features {}
+}
+`+"`"+`
+}`, r.d.ResourceStruct())
+	}
+
+	userAssignedIdentity := ""
+	args := "data.RandomInteger, data.Locations.Primary"
+	if needsUserAssignedIdentity {
+		userAssignedIdentity = `
+resource "azurerm_user_assigned_identity" "test" {
+  name                = "acctest-uai-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+`
+		args += ", data.RandomInteger"
+	}
+
+	return fmt.Sprintf(`func (r %s) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%%d"
+  location = %%q
+}
+%s`+"`"+`, %s)
+}`, r.d.ResourceStruct(), userAssignedIdentity, args)
+}
+
+// formatHcl formats the lines within a block of HCL, returning them indented by a single level
+func formatHcl(lines []string) string {
+	formatted := string(hclwrite.Format([]byte(fmt.Sprintf("block {\n%s\n}\n", strings.Join(lines, "\n")))))
+	formatted = strings.TrimPrefix(formatted, "block {\n")
+	formatted = strings.TrimSuffix(formatted, "}\n")
+	return formatted
+}
+
+// escapeFormat escapes any formatting directives, since the HCL is used within `fmt.Sprintf`
+func escapeFormat(input string) string {
+	return strings.ReplaceAll(input, "%", "%%")
+}
@@ -0,0 +1,546 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// typeRef is a reference to a Go type used within the SDK package, e.g. `*[]EncryptionProperties`
+type typeRef struct {
+	Pointer bool
+	Slice   bool
+	Map     bool
+
+	// Package is the name of the package containing the type, when the type isn't defined within the SDK package
+	// or a builtin type - e.g. `identity`
+	Package string
+
+	// Name is the name of the type (or the type of the elements for a Slice/Map) - e.g. `string`
+	Name string
+}
+
+func (t typeRef) String() string {
+	output := ""
+	if t.Pointer {
+		output += "*"
+	}
+	if t.Slice {
+		output += "[]"
+	}
+	if t.Map {
+		output += "map[string]"
+	}
+	if t.Package != "" {
+		output += t.Package + "."
+	}
+	return output + t.Name
+}
+
+type sdkField struct {
+	Name      string
+	Type      typeRef
+	JsonName  string
+	OmitEmpty bool
+}
+
+type sdkMethod struct {
+	Name string
+
+	// Params are the types of the parameters for this method, excluding the `context.Context`
+	Params []typeRef
+
+	// Result is the name of the type returned from this method (excluding the error)
+	Result string
+}
+
+// sdkPackage is the subset of a go-azure-sdk package (e.g. `resource-manager/loadtestservice/2022-12-01/loadtests`)
+// used to generate a Resource
+type sdkPackage struct {
+	Name       string
+	ImportPath string
+
+	// Client is the name of the Client type used to call the API, e.g. `LoadTestsClient`
+	Client string
+
+	// Constants are the possible values for each of the string constants, keyed by the type name
+	Constants map[string][]string
+
+	// Functions are the names of the package-level functions, e.g. `DefaultGetOperationOptions`
+	Functions map[string]struct{}
+
+	// Methods are the methods available on the Client, keyed by the method name
+	Methods map[string]sdkMethod
+
+	// Models are the fields within each of the structs, keyed by the type name
+	Models map[string][]sdkField
+
+	// ResourceIds are the names of the segments within each of the Resource IDs, keyed by the type name
+	ResourceIds map[string][]string
+}
+
+func parseSdkPackage(directory string, importPath string) (*sdkPackage, error) {
+	fileSet := token.NewFileSet()
+	packages, err := parser.ParseDir(fileSet, directory, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", directory, err)
+	}
+	if len(packages) != 1 {
+		return nil, fmt.Errorf("expected %q to contain a single package but got %d", directory, len(packages))
+	}
+
+	output := sdkPackage{
+		ImportPath:  importPath,
+		Constants:   make(map[string][]string),
+		Functions:   make(map[string]struct{}),
+		Methods:     make(map[string]sdkMethod),
+		Models:      make(map[string][]sdkField),
+		ResourceIds: make(map[string][]string),
+	}
+	stringTypes := make(map[string]struct{})
+	constants := make(map[string][]string)
+	methods := make(map[string]map[string]sdkMethod)
+
+	fileNames := make([]string, 0)
+	for _, pkg := range packages {
+		output.Name = pkg.Name
+		for fileName := range pkg.Files {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+	sort.Strings(fileNames)
+
+	for _, fileName := range fileNames {
+		file := packages[output.Name].Files[fileName]
+		for _, decl := range file.Decls {
+			switch v := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range v.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						switch t := s.Type.(type) {
+						case *ast.StructType:
+							output.Models[s.Name.Name] = parseFields(t)
+						case *ast.Ident:
+							if t.Name == "string" {
+								stringTypes[s.Name.Name] = struct{}{}
+							}
+						}
+
+					case *ast.ValueSpec:
+						if v.Tok != token.CONST || s.Type == nil {
+							continue
+						}
+						typeName, ok := s.Type.(*ast.Ident)
+						if !ok {
+							continue
+						}
+						for _, value := range s.Values {
+							if lit, ok := value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+								if unquoted, err := strconv.Unquote(lit.Value); err == nil {
+									constants[typeName.Name] = append(constants[typeName.Name], unquoted)
+								}
+							}
+						}
+					}
+				}
+
+			case *ast.FuncDecl:
+				if v.Recv == nil {
+					output.Functions[v.Name.Name] = struct{}{}
+					continue
+				}
+				if !v.Name.IsExported() || len(v.Recv.List) != 1 {
+					continue
+				}
+				receiver, ok := v.Recv.List[0].Type.(*ast.Ident)
+				if !ok {
+					continue
+				}
+
+				method := sdkMethod{
+					Name: v.Name.Name,
+				}
+				for i, param := range v.Type.Params.List {
+					if i == 0 {
+						// the `context.Context`
+						continue
+					}
+					for range namesOrOne(param.Names) {
+						method.Params = append(method.Params, parseTypeRef(param.Type))
+					}
+				}
+				if results := v.Type.Results; results != nil && len(results.List) > 0 {
+					method.Result = parseTypeRef(results.List[0].Type).Name
+				}
+
+				if methods[receiver.Name] == nil {
+					methods[receiver.Name] = make(map[string]sdkMethod)
+				}
+				methods[receiver.Name][method.Name] = method
+			}
+		}
+	}
+
+	for typeName, values := range constants {
+		if _, ok := stringTypes[typeName]; ok {
+			output.Constants[typeName] = values
+		}
+	}
+
+	for typeName := range output.Models {
+		// Resource IDs are constructed using `NewXID` and contain the segments as fields
+		if !strings.HasSuffix(typeName, "Id") {
+			continue
+		}
+		if _, ok := output.Functions[fmt.Sprintf("New%sID", strings.TrimSuffix(typeName, "Id"))]; !ok {
+			continue
+		}
+		segments := make([]string, 0)
+		for _, field := range output.Models[typeName] {
+			segments = append(segments, field.Name)
+		}
+		output.ResourceIds[typeName] = segments
+	}
+
+	for receiver, receiverMethods := range methods {
+		if !strings.HasSuffix(receiver, "Client") {
+			continue
+		}
+		if output.Client != "" {
+			return nil, fmt.Errorf("expected %q to contain a single Client but got %q and %q", directory, output.Client, receiver)
+		}
+		output.Client = receiver
+		output.Methods = receiverMethods
+	}
+	if output.Client == "" {
+		return nil, fmt.Errorf("%q doesn't contain a Client", directory)
+	}
+
+	return &output, nil
+}
+
+func namesOrOne(names []*ast.Ident) []*ast.Ident {
+	if len(names) == 0 {
+		return []*ast.Ident{nil}
+	}
+	return names
+}
+
+func parseFields(input *ast.StructType) []sdkField {
+	output := make([]sdkField, 0)
+	for _, field := range input.Fields.List {
+		jsonName := ""
+		omitEmpty := false
+		if field.Tag != nil {
+			if tag, err := strconv.Unquote(field.Tag.Value); err == nil {
+				values := strings.Split(reflectTagValue(tag, "json"), ",")
+				jsonName = values[0]
+				for _, v := range values[1:] {
+					if v == "omitempty" {
+						omitEmpty = true
+					}
+				}
+			}
+		}
+
+		for _, name := range field.Names {
+			output = append(output, sdkField{
+				Name:      name.Name,
+				Type:      parseTypeRef(field.Type),
+				JsonName:  jsonName,
+				OmitEmpty: omitEmpty,
+			})
+		}
+	}
+	return output
+}
+
+func reflectTagValue(tag string, key string) string {
+	for _, segment := range strings.Split(tag, " ") {
+		if strings.HasPrefix(segment, key+":") {
+			if v, err := strconv.Unquote(strings.TrimPrefix(segment, key+":")); err == nil {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+func parseTypeRef(expr ast.Expr) typeRef {
+	switch v := expr.(type) {
+	case *ast.StarExpr:
+		output := parseTypeRef(v.X)
+		if output.Pointer || (!output.Slice && !output.Map && output.Name == "") {
+			return typeRef{}
+		}
+		output.Pointer = true
+		return output
+
+	case *ast.ArrayType:
+		elem := parseTypeRef(v.Elt)
+		if elem.Pointer || elem.Slice || elem.Map {
+			// e.g. `[]*string` or `[][]string` which aren't supported
+			return typeRef{}
+		}
+		elem.Slice = true
+		return elem
+
+	case *ast.MapType:
+		key, ok := v.Key.(*ast.Ident)
+		if !ok || key.Name != "string" {
+			return typeRef{}
+		}
+		elem := parseTypeRef(v.Value)
+		if elem.Pointer || elem.Slice || elem.Map {
+			return typeRef{}
+		}
+		elem.Map = true
+		return elem
+
+	case *ast.Ident:
+		return typeRef{
+			Name: v.Name,
+		}
+
+	case *ast.SelectorExpr:
+		pkg, ok := v.X.(*ast.Ident)
+		if !ok {
+			return typeRef{}
+		}
+		return typeRef{
+			Package: pkg.Name,
+			Name:    v.Sel.Name,
+		}
+	}
+
+	return typeRef{}
+}
+
+// operation is a method on the Client used to perform a CRUD operation
+type operation struct {
+	Method string
+
+	// Input is the name of the model type sent to the API, if any
+	Input string
+
+	// LongRunning is whether the method has a `ThenPoll` variant which should be used
+	LongRunning bool
+
+	// Options is the expression used for the OperationOptions parameter, if any
+	Options string
+}
+
+// call returns the expression used to call this operation
+func (o operation) call(args ...string) string {
+	method := o.Method
+	if o.LongRunning {
+		method += "ThenPoll"
+	}
+	if o.Options != "" {
+		args = append(args, o.Options)
+	}
+	return fmt.Sprintf("client.%s(ctx, %s)", method, strings.Join(args, ", "))
+}
+
+type operations struct {
+	ResourceId string
+
+	// Model is the name of the model type returned from the Get operation
+	Model string
+
+	Create operation
+	Read   operation
+	Update *operation
+	Delete operation
+}
+
+// findOperations determines the CRUD operations for the Resource, optionally using the specified Resource ID type
+func (p sdkPackage) findOperations(resourceId string) (*operations, error) {
+	methodNames := make([]string, 0)
+	for name := range p.Methods {
+		methodNames = append(methodNames, name)
+	}
+	sort.Strings(methodNames)
+
+	// the Read operation determines the Resource ID and the Model
+	reads := make(map[string]operations)
+	for _, name := range methodNames {
+		method := p.Methods[name]
+		if !strings.HasSuffix(name, "Get") || len(method.Params) == 0 {
+			continue
+		}
+		if _, ok := p.ResourceIds[method.Params[0].Name]; !ok || method.Params[0].Pointer {
+			continue
+		}
+		if resourceId != "" && method.Params[0].Name != resourceId {
+			continue
+		}
+
+		model := p.responseModel(method.Result)
+		if model == "" {
+			continue
+		}
+
+		if existing, ok := reads[method.Params[0].Name]; ok && existing.Read.Method == "Get" {
+			continue
+		}
+		reads[method.Params[0].Name] = operations{
+			ResourceId: method.Params[0].Name,
+			Model:      model,
+			Read: operation{
+				Method:  name,
+				Options: p.optionsFor(method),
+			},
+		}
+	}
+
+	if len(reads) == 0 {
+		if resourceId != "" {
+			return nil, fmt.Errorf("no Get operation was found for the Resource ID %q", resourceId)
+		}
+		return nil, fmt.Errorf("no Get operation was found which uses a Resource ID")
+	}
+	if len(reads) > 1 {
+		ids := make([]string, 0)
+		for id := range reads {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return nil, fmt.Errorf("multiple Resource IDs have a Get operation (%s) - the Resource ID to use must be specified", strings.Join(ids, ", "))
+	}
+
+	var output operations
+	for _, v := range reads {
+		output = v
+	}
+
+	var create, update, del *operation
+	for _, name := range methodNames {
+		method := p.Methods[name]
+		if strings.HasSuffix(name, "ThenPoll") || len(method.Params) == 0 || method.Params[0] != (typeRef{Name: output.ResourceId}) {
+			continue
+		}
+		_, longRunning := p.Methods[name+"ThenPoll"]
+
+		op := operation{
+			Method:      name,
+			LongRunning: longRunning,
+			Options:     p.optionsFor(method),
+		}
+		if len(method.Params) > 1 && !strings.HasSuffix(method.Params[1].Name, "OperationOptions") {
+			if _, ok := p.Models[method.Params[1].Name]; ok && method.Params[1].Package == "" && !method.Params[1].Slice && !method.Params[1].Map {
+				op.Input = method.Params[1].Name
+			}
+		}
+
+		switch {
+		case strings.Contains(name, "Create") && op.Input != "":
+			if create == nil || strings.HasSuffix(name, "CreateOrUpdate") {
+				create = &op
+			}
+		case strings.HasSuffix(name, "Update") && op.Input != "":
+			if update == nil || name == "Update" {
+				update = &op
+			}
+		case strings.HasSuffix(name, "Delete"):
+			if del == nil || name == "Delete" {
+				del = &op
+			}
+		}
+	}
+
+	if create == nil {
+		return nil, fmt.Errorf("no Create operation was found for the Resource ID %q", output.ResourceId)
+	}
+	if del == nil {
+		return nil, fmt.Errorf("no Delete operation was found for the Resource ID %q", output.ResourceId)
+	}
+	if create.Input != output.Model {
+		return nil, fmt.Errorf("the Create operation %q uses the model %q but the Get operation returns %q, which isn't supported", create.Method, create.Input, output.Model)
+	}
+
+	output.Create = *create
+	output.Update = update
+	output.Delete = *del
+	return &output, nil
+}
+
+// responseModel returns the type of the `Model` field within the specified response, when this is a single model
+func (p sdkPackage) responseModel(response string) string {
+	for _, field := range p.Models[response] {
+		if field.Name == "Model" && field.Type.Pointer && !field.Type.Slice && !field.Type.Map && field.Type.Package == "" {
+			if _, ok := p.Models[field.Type.Name]; ok {
+				return field.Type.Name
+			}
+		}
+	}
+	return ""
+}
+
+func (p sdkPackage) optionsFor(method sdkMethod) string {
+	for _, param := range method.Params {
+		if param.Name != method.Name+"OperationOptions" {
+			continue
+		}
+		if _, ok := p.Functions["Default"+param.Name]; ok {
+			return fmt.Sprintf("%s.Default%s()", p.Name, param.Name)
+		}
+		return fmt.Sprintf("%s.%s{}", p.Name, param.Name)
+	}
+	return ""
+}
+
+// findSdkPackage returns the directory containing the SDK package, which is either vendored or within the Go module cache
+func findSdkPackage(rootDirectory string, sdkPackage string) (string, error) {
+	vendored := filepath.Join(rootDirectory, "vendor", "github.com", "hashicorp", "go-azure-sdk", sdkPackage)
+	if info, err := os.Stat(vendored); err == nil && info.IsDir() {
+		return vendored, nil
+	}
+
+	goMod, err := os.ReadFile(filepath.Join(rootDirectory, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("reading go.mod: %+v", err)
+	}
+	version := ""
+	for _, line := range strings.Split(string(goMod), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "github.com/hashicorp/go-azure-sdk" {
+			version = fields[1]
+		}
+	}
+	if version == "" {
+		return "", fmt.Errorf("go.mod doesn't contain `github.com/hashicorp/go-azure-sdk`")
+	}
+
+	moduleCache := os.Getenv("GOMODCACHE")
+	if moduleCache == "" {
+		goPath := os.Getenv("GOPATH")
+		if goPath == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("determining the home directory: %+v", err)
+			}
+			goPath = filepath.Join(home, "go")
+		}
+		moduleCache = filepath.Join(goPath, "pkg", "mod")
+	}
+
+	cached := filepath.Join(moduleCache, "github.com", "hashicorp", fmt.Sprintf("go-azure-sdk@%s", version), sdkPackage)
+	if info, err := os.Stat(cached); err == nil && info.IsDir() {
+		return cached, nil
+	}
+
+	return "", fmt.Errorf("%q wasn't found within the vendor directory or %q - run `go mod download github.com/hashicorp/go-azure-sdk` or specify `-sdk-path`", sdkPackage, cached)
+}
@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// leadingProperties are output first within the `resource` block (in this order), matching the convention used
+// within the documentation/tests for the Provider
+var leadingProperties = []string{"name", "resource_group_name", "location"}
+
+// trailingProperties are output last within the `resource` block, after any nested blocks
+var trailingProperties = []string{"tags"}
+
+type configRenderer struct {
+	notes []string
+}
+
+// renderBody returns the body of the `resource` block for the Resource (as HCL, which needs to be formatted)
+// alongside notes about any properties which couldn't be populated
+func renderBody(schemas map[string]*schema.Schema, d *schema.ResourceData) (string, []string) {
+	values := make(map[string]interface{})
+	for key := range schemas {
+		values[key] = d.Get(key)
+	}
+
+	r := configRenderer{
+		notes: make([]string, 0),
+	}
+	lines := r.body(schemas, values, "")
+	if len(lines) == 0 {
+		return "", r.notes
+	}
+	return strings.Join(lines, "\n") + "\n", r.notes
+}
+
+// body returns the lines for the properties within a block, where attributes are output prior to nested blocks
+func (r *configRenderer) body(schemas map[string]*schema.Schema, values map[string]interface{}, path string) []string {
+	included := make(map[string]struct{})
+	for key, s := range schemas {
+		if r.include(s, values[key]) {
+			included[key] = struct{}{}
+		}
+	}
+
+	// properties which are Optional and Computed are populated by the API even when they're not specified, so
+	// are omitted when they conflict with another property which is specified
+	for key := range included {
+		s := schemas[key]
+		if !s.Optional || !s.Computed {
+			continue
+		}
+		for _, conflict := range s.ConflictsWith {
+			segments := strings.Split(conflict, ".")
+			other, ok := schemas[segments[len(segments)-1]]
+			if _, specified := included[segments[len(segments)-1]]; ok && specified && !other.Computed {
+				delete(included, key)
+				break
+			}
+		}
+	}
+
+	attributes := make([]string, 0)
+	blocks := make([]string, 0)
+	trailing := make([]string, 0)
+	for _, key := range orderedKeys(schemas, path == "") {
+		if _, ok := included[key]; !ok {
+			continue
+		}
+
+		s := schemas[key]
+		if s.Sensitive {
+			attributes = append(attributes, fmt.Sprintf("# TODO: `%s` is sensitive, so needs to be set manually", key))
+			r.notes = append(r.notes, fmt.Sprintf("`%s%s` is sensitive, so needs to be set manually", path, key))
+			continue
+		}
+
+		if nested, ok := s.Elem.(*schema.Resource); ok && s.ConfigMode != schema.SchemaConfigModeAttr {
+			for i, item := range listValue(values[key]) {
+				nestedValues, _ := item.(map[string]interface{})
+				nestedLines := r.body(nested.Schema, nestedValues, fmt.Sprintf("%s%s.%d.", path, key, i))
+				if len(nestedLines) == 0 {
+					blocks = append(blocks, fmt.Sprintf("\n%s {}", key))
+					continue
+				}
+				blocks = append(blocks, fmt.Sprintf("\n%s {\n%s\n}", key, strings.Join(nestedLines, "\n")))
+			}
+			continue
+		}
+
+		line := fmt.Sprintf("%s = %s", key, expression(s, values[key]))
+		if path == "" && contains(trailingProperties, key) {
+			trailing = append(trailing, "\n"+line)
+			continue
+		}
+		attributes = append(attributes, line)
+	}
+
+	lines := append(attributes, blocks...)
+	lines = append(lines, trailing...)
+	if len(attributes) == 0 && len(lines) > 0 {
+		// nested blocks are separated from the attributes by a blank line, which isn't needed without any attributes
+		lines[0] = strings.TrimPrefix(lines[0], "\n")
+	}
+	return lines
+}
+
+// include returns whether the property should be output within the Terraform Configuration
+func (r *configRenderer) include(s *schema.Schema, value interface{}) bool {
+	if s.Computed && !s.Optional && !s.Required {
+		return false
+	}
+	if s.Deprecated != "" && !s.Required {
+		return false
+	}
+	if s.Required || s.MinItems > 0 {
+		return true
+	}
+
+	if isEmpty(value) {
+		return false
+	}
+	if s.Default != nil && fmt.Sprint(s.Default) == fmt.Sprint(value) {
+		return false
+	}
+	return true
+}
+
+// orderedKeys returns the keys within the schema, where (within the top-level of a Resource) the leading and
+// trailing properties are output first and last respectively
+func orderedKeys(schemas map[string]*schema.Schema, topLevel bool) []string {
+	keys := make([]string, 0)
+	for key := range schemas {
+		if topLevel && (contains(leadingProperties, key) || contains(trailingProperties, key)) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if !topLevel {
+		return keys
+	}
+
+	output := make([]string, 0)
+	for _, key := range leadingProperties {
+		if _, ok := schemas[key]; ok {
+			output = append(output, key)
+		}
+	}
+	output = append(output, keys...)
+	for _, key := range trailingProperties {
+		if _, ok := schemas[key]; ok {
+			output = append(output, key)
+		}
+	}
+	return output
+}
+
+// expression returns the HCL expression for the value of an attribute
+func expression(s *schema.Schema, value interface{}) string {
+	switch v := value.(type) {
+	case *schema.Set:
+		return expression(s, v.List())
+
+	case []interface{}:
+		items := make([]string, 0)
+		for _, item := range v {
+			if nested, ok := s.Elem.(*schema.Resource); ok {
+				items = append(items, objectExpression(nested.Schema, item))
+				continue
+			}
+			items = append(items, primitiveExpression(item))
+		}
+		if s.Type == schema.TypeSet {
+			// the order of the items within a Set is based on their hash, so these are sorted for readability
+			sort.Strings(items)
+		}
+		if _, ok := s.Elem.(*schema.Resource); ok && len(items) > 0 {
+			return fmt.Sprintf("[\n%s,\n]", strings.Join(items, ",\n"))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+
+	case map[string]interface{}:
+		keys := make([]string, 0)
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		lines := make([]string, 0)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("%s = %s", mapKey(key), primitiveExpression(v[key])))
+		}
+		return fmt.Sprintf("{\n%s\n}", strings.Join(lines, "\n"))
+	}
+
+	return primitiveExpression(value)
+}
+
+// objectExpression returns the HCL expression for an item within a block which is configured as an attribute
+func objectExpression(schemas map[string]*schema.Schema, value interface{}) string {
+	values, _ := value.(map[string]interface{})
+
+	lines := make([]string, 0)
+	for _, key := range orderedKeys(schemas, false) {
+		s := schemas[key]
+		if s.Computed && !s.Optional && !s.Required {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s = %s", key, expression(s, values[key])))
+	}
+	return fmt.Sprintf("{\n%s\n}", strings.Join(lines, "\n"))
+}
+
+func primitiveExpression(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return hclString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return hclString(fmt.Sprint(value))
+}
+
+var identifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+func mapKey(key string) string {
+	if identifier.MatchString(key) {
+		return key
+	}
+	return hclString(key)
+}
+
+// hclString returns the quoted HCL string for the value, escaping any template sequences
+func hclString(value string) string {
+	quoted := strconv.Quote(value)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	quoted = strings.ReplaceAll(quoted, "%{", "%%{")
+	return quoted
+}
+
+func listValue(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	}
+	return nil
+}
+
+// isEmpty returns whether the value is the zero value for the property, in which case it's omitted when Optional
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case *schema.Set:
+		return v.Len() == 0
+	case []interface{}:
+		for _, item := range v {
+			if !isEmpty(item) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		for _, item := range v {
+			if !isEmpty(item) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.ValueOf(value).IsZero()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package generator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// probeResourceId is a Resource ID which shouldn't be accepted by the Importer of any Resource, used to detect
+// Importers which don't validate the Resource ID (and so would otherwise match every Resource)
+const probeResourceId = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/import-generator/providers/Import.Generator/probes/probe"
+
+// AzureResource is an existing Resource within Azure which should be imported
+type AzureResource struct {
+	// ID is the Resource ID of this Resource within Azure
+	ID string
+
+	// Name is the name of this Resource within Azure
+	Name string
+
+	// Type is the Azure Resource Manager type of this Resource, e.g. `Microsoft.Storage/storageAccounts`
+	Type string
+}
+
+// Note is a message about a Resource which needs to be reviewed, for example as the Resource couldn't be
+// imported or the generated Terraform Configuration is incomplete
+type Note struct {
+	// ID is the Resource ID of the Resource within Azure
+	ID string
+
+	// Address is the address of the Resource within the generated Terraform Configuration, if any
+	Address string
+
+	// Message describes what needs to be reviewed
+	Message string
+}
+
+func (n Note) String() string {
+	if n.Address != "" {
+		return fmt.Sprintf("%s (%s): %s", n.Address, n.ID, n.Message)
+	}
+	return fmt.Sprintf("%s: %s", n.ID, n.Message)
+}
+
+// Result is the Terraform Configuration generated for a set of Resources
+type Result struct {
+	// Config is the `import` blocks (and, unless only the `import` blocks were generated, the `resource` blocks)
+	Config []byte
+
+	// Imported is the number of Resources which an `import` block was generated for
+	Imported int
+
+	// Notes is the list of Resources which need to be reviewed
+	Notes []Note
+}
+
+// Generator generates `import` blocks (and a best-effort Terraform Configuration) for existing Resources within
+// Azure, using the Importer and Read functions of the Resources registered within the Provider.
+type Generator struct {
+	provider *schema.Provider
+
+	// idValidators is the IDValidationFunc for each Resource Type which exposes one, used to avoid calling the
+	// Importer for Resource Types which the Resource ID can't be imported into
+	idValidators map[string]pluginsdk.IDValidationFunc
+
+	// resourceTypes is the sorted list of Resource Types whose Importer validates the Resource ID
+	resourceTypes []string
+}
+
+// NewGenerator returns a Generator for the Resources registered within the (configured) Provider, where
+// idValidators is the IDValidationFunc for each (Typed) Resource which exposes one
+func NewGenerator(ctx context.Context, provider *schema.Provider, idValidators map[string]pluginsdk.IDValidationFunc) *Generator {
+	g := &Generator{
+		provider:      provider,
+		idValidators:  idValidators,
+		resourceTypes: make([]string, 0),
+	}
+
+	for resourceType, resource := range provider.ResourcesMap {
+		if resource.Importer == nil {
+			continue
+		}
+		if _, ok := idValidators[resourceType]; !ok && g.importable(ctx, resourceType, probeResourceId) {
+			// the Importer accepts any value, so can't be used to determine whether a Resource ID matches
+			continue
+		}
+		g.resourceTypes = append(g.resourceTypes, resourceType)
+	}
+	sort.Strings(g.resourceTypes)
+
+	return g
+}
+
+// Match returns the Resource Types which the Resource ID can be imported into. Deprecated Resource Types
+// are only returned when the Resource ID can't be imported into any other Resource Type.
+func (g *Generator) Match(ctx context.Context, id string) []string {
+	current := make([]string, 0)
+	deprecated := make([]string, 0)
+	for _, resourceType := range g.resourceTypes {
+		if validator, ok := g.idValidators[resourceType]; ok && validator(id) != nil {
+			continue
+		}
+		if !g.importable(ctx, resourceType, id) {
+			continue
+		}
+
+		if g.provider.ResourcesMap[resourceType].DeprecationMessage != "" {
+			deprecated = append(deprecated, resourceType)
+			continue
+		}
+		current = append(current, resourceType)
+	}
+
+	if len(current) > 0 {
+		return current
+	}
+	return deprecated
+}
+
+// importable returns whether the Importer for the Resource Type accepts the Resource ID
+func (g *Generator) importable(ctx context.Context, resourceType, id string) (ok bool) {
+	// the Importer may make assumptions about the Resource ID (or the Provider) which don't hold for the
+	// Resource IDs of other Resource Types
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+
+	_, err := g.provider.ImportState(ctx, &terraform.InstanceInfo{Type: resourceType}, id)
+	return err == nil
+}
+
+// Generate generates an `import` block for each Resource, alongside a `resource` block populated by reading the
+// Resource using the Provider - unless importOnly is specified, in which case only the `import` blocks are generated
+// (for example to use with `terraform plan -generate-config-out`).
+func (g *Generator) Generate(ctx context.Context, resources []AzureResource, importOnly bool) (*Result, error) {
+	result := Result{
+		Notes: make([]Note, 0),
+	}
+	blocks := make([]string, 0)
+	labels := make(map[string]int)
+
+	sorted := make([]AzureResource, len(resources))
+	copy(sorted, resources)
+	sort.Slice(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].ID) < strings.ToLower(sorted[j].ID)
+	})
+
+	for _, azureResource := range sorted {
+		resourceTypes := g.Match(ctx, azureResource.ID)
+		if len(resourceTypes) == 0 {
+			result.Notes = append(result.Notes, Note{
+				ID:      azureResource.ID,
+				Message: fmt.Sprintf("no Resource supports importing the Azure Resource Type %q", azureResource.Type),
+			})
+			continue
+		}
+
+		resourceType := resourceTypes[0]
+		address := fmt.Sprintf("%s.%s", resourceType, uniqueLabel(labels, resourceType, azureResource.Name))
+		if len(resourceTypes) > 1 {
+			result.Notes = append(result.Notes, Note{
+				ID:      azureResource.ID,
+				Address: address,
+				Message: fmt.Sprintf("this Resource can also be imported into %s", strings.Join(resourceTypes[1:], ", ")),
+			})
+		}
+
+		block := importBlock(address, azureResource.ID)
+		if !importOnly {
+			config, notes, err := g.resourceConfig(ctx, resourceType, address, azureResource.ID)
+			if err != nil {
+				result.Notes = append(result.Notes, Note{
+					ID:      azureResource.ID,
+					Address: address,
+					Message: fmt.Sprintf("reading the Resource: %+v", err),
+				})
+				continue
+			}
+			for _, message := range notes {
+				result.Notes = append(result.Notes, Note{
+					ID:      azureResource.ID,
+					Address: address,
+					Message: message,
+				})
+			}
+			block += "\n" + config
+		}
+
+		blocks = append(blocks, block)
+		result.Imported++
+	}
+
+	result.Config = hclwrite.Format([]byte(strings.Join(blocks, "\n")))
+	return &result, nil
+}
+
+// resourceConfig imports and then reads the Resource using the Provider, returning the `resource` block for the
+// Resource alongside any notes about properties which couldn't be populated
+func (g *Generator) resourceConfig(ctx context.Context, resourceType, address, id string) (string, []string, error) {
+	resource := g.provider.ResourcesMap[resourceType]
+
+	states, err := g.provider.ImportState(ctx, &terraform.InstanceInfo{Type: resourceType}, id)
+	if err != nil {
+		return "", nil, fmt.Errorf("importing: %+v", err)
+	}
+	if len(states) == 0 {
+		return "", nil, fmt.Errorf("importing: no state was returned")
+	}
+
+	// Importers can return multiple states (for example for related Resources), where the first is the Resource itself
+	state, diags := resource.RefreshWithoutUpgrade(ctx, states[0], g.provider.Meta())
+	if diags.HasError() {
+		messages := make([]string, 0)
+		for _, v := range diags {
+			messages = append(messages, v.Summary)
+		}
+		return "", nil, fmt.Errorf("%s", strings.Join(messages, "\n"))
+	}
+	if state == nil || state.ID == "" {
+		return "", nil, fmt.Errorf("the Resource no longer exists")
+	}
+
+	name := strings.TrimPrefix(address, resourceType+".")
+	body, notes := renderBody(resource.Schema, resource.Data(state))
+	return fmt.Sprintf("resource %q %q {\n%s}\n", resourceType, name, body), notes, nil
+}
+
+func importBlock(address, id string) string {
+	return fmt.Sprintf(`import {
+to = %s
+id = %s
+}
+`, address, hclString(id))
+}
+
+var invalidLabelCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// uniqueLabel returns the label for the `resource` block of the Resource within Azure, which is unique for
+// the Resource Type
+func uniqueLabel(labels map[string]int, resourceType, name string) string {
+	label := strings.Trim(invalidLabelCharacters.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" {
+		label = "imported"
+	}
+	if label[0] >= '0' && label[0] <= '9' {
+		label = "_" + label
+	}
+
+	key := fmt.Sprintf("%s.%s", resourceType, label)
+	labels[key]++
+	if count := labels[key]; count > 1 {
+		label = fmt.Sprintf("%s_%d", label, count)
+	}
+	return label
+}
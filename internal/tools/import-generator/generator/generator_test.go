@@ -0,0 +1,348 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package generator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+const exampleIdPrefix = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Example/examples/"
+
+func validateExampleId(id string) error {
+	if !strings.HasPrefix(id, exampleIdPrefix) || strings.Contains(strings.TrimPrefix(id, exampleIdPrefix), "/") {
+		return fmt.Errorf("parsing %q: expected an Example ID", id)
+	}
+	return nil
+}
+
+func exampleResource(deprecationMessage string) *schema.Resource {
+	return &schema.Resource{
+		DeprecationMessage: deprecationMessage,
+		Importer:           pluginsdk.ImporterValidatingResourceId(validateExampleId),
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			name := strings.TrimPrefix(d.Id(), exampleIdPrefix)
+			if name == "gone" {
+				d.SetId("")
+				return nil
+			}
+
+			d.Set("name", name)
+			d.Set("resource_group_name", "example")
+			d.Set("location", "westeurope")
+			d.Set("capacity", 1)
+			d.Set("enabled", false)
+			d.Set("fqdn", name+".example.com")
+			d.Set("description", "uses ${template} syntax")
+			d.Set("password", "secret")
+			d.Set("sku", []interface{}{
+				map[string]interface{}{
+					"name": "Standard",
+					"tier": "",
+				},
+			})
+			d.Set("zones", []interface{}{"1", "2"})
+			d.Set("tags", map[string]interface{}{
+				"environment": "production",
+				"cost-centre": "1234",
+			})
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"resource_group_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"capacity": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"legacy_setting": {
+				Type:       schema.TypeString,
+				Optional:   true,
+				Deprecated: "this property has been removed from the API",
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"sku": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"tier": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"zones": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func testProvider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"azurerm_example":        exampleResource(""),
+			"azurerm_legacy_example": exampleResource("The `azurerm_legacy_example` resource has been superseded by the `azurerm_example` resource"),
+			"azurerm_passthrough": {
+				Importer: &schema.ResourceImporter{
+					StateContext: schema.ImportStatePassthroughContext,
+				},
+				Schema: map[string]*schema.Schema{},
+			},
+			"azurerm_panics": {
+				Importer: &schema.ResourceImporter{
+					StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+						if !strings.HasPrefix(d.Id(), "/panics/") {
+							panic("unexpected Resource ID")
+						}
+						return []*schema.ResourceData{d}, nil
+					},
+				},
+				Schema: map[string]*schema.Schema{},
+			},
+			"azurerm_typed": {
+				Importer: &schema.ResourceImporter{
+					StateContext: schema.ImportStatePassthroughContext,
+				},
+				Schema: map[string]*schema.Schema{},
+			},
+		},
+	}
+}
+
+func TestMatch(t *testing.T) {
+	generator := NewGenerator(context.TODO(), testProvider(), map[string]pluginsdk.IDValidationFunc{
+		"azurerm_typed": func(id string) error {
+			if !strings.HasPrefix(id, "/typed/") {
+				return fmt.Errorf("parsing %q: expected a Typed ID", id)
+			}
+			return nil
+		},
+	})
+
+	testData := []struct {
+		Name     string
+		ID       string
+		Expected []string
+	}{
+		{
+			Name:     "deprecated Resources are excluded",
+			ID:       exampleIdPrefix + "first",
+			Expected: []string{"azurerm_example"},
+		},
+		{
+			Name:     "child Resource",
+			ID:       exampleIdPrefix + "first/children/child",
+			Expected: []string{},
+		},
+		{
+			Name:     "validated using the IDValidationFunc",
+			ID:       "/typed/example",
+			Expected: []string{"azurerm_typed"},
+		},
+		{
+			Name:     "Importer which panics",
+			ID:       "/panics/example",
+			Expected: []string{"azurerm_panics"},
+		},
+		{
+			Name:     "unsupported",
+			ID:       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Unsupported/unsupported/example",
+			Expected: []string{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := generator.Match(context.TODO(), v.ID)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	resources := []AzureResource{
+		{
+			ID:   exampleIdPrefix + "second",
+			Name: "Second",
+			Type: "Microsoft.Example/examples",
+		},
+		{
+			ID:   exampleIdPrefix + "first",
+			Name: "first",
+			Type: "Microsoft.Example/examples",
+		},
+		{
+			ID:   exampleIdPrefix + "gone",
+			Name: "second",
+			Type: "Microsoft.Example/examples",
+		},
+		{
+			ID:   "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Unsupported/unsupported/example",
+			Name: "example",
+			Type: "Microsoft.Unsupported/unsupported",
+		},
+	}
+
+	testData := []struct {
+		Name           string
+		ImportOnly     bool
+		ExpectedConfig string
+		ExpectedNotes  []string
+	}{
+		{
+			Name:       "import only",
+			ImportOnly: true,
+			ExpectedConfig: fmt.Sprintf(`import {
+  to = azurerm_example.first
+  id = "%[1]sfirst"
+}
+
+import {
+  to = azurerm_example.second
+  id = "%[1]sgone"
+}
+
+import {
+  to = azurerm_example.second_2
+  id = "%[1]ssecond"
+}
+`, exampleIdPrefix),
+			ExpectedNotes: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Unsupported/unsupported/example: no Resource supports importing the Azure Resource Type \"Microsoft.Unsupported/unsupported\"",
+			},
+		},
+		{
+			Name: "config",
+			ExpectedConfig: fmt.Sprintf(`import {
+  to = azurerm_example.first
+  id = "%[1]sfirst"
+}
+
+resource "azurerm_example" "first" {
+  name                = "first"
+  resource_group_name = "example"
+  location            = "westeurope"
+  description         = "uses $${template} syntax"
+  # TODO: `+"`password`"+` is sensitive, so needs to be set manually
+  zones = ["1", "2"]
+
+  sku {
+    name = "Standard"
+  }
+
+  tags = {
+    cost-centre = "1234"
+    environment = "production"
+  }
+}
+
+import {
+  to = azurerm_example.second_2
+  id = "%[1]ssecond"
+}
+
+resource "azurerm_example" "second_2" {
+  name                = "second"
+  resource_group_name = "example"
+  location            = "westeurope"
+  description         = "uses $${template} syntax"
+  # TODO: `+"`password`"+` is sensitive, so needs to be set manually
+  zones = ["1", "2"]
+
+  sku {
+    name = "Standard"
+  }
+
+  tags = {
+    cost-centre = "1234"
+    environment = "production"
+  }
+}
+`, exampleIdPrefix),
+			ExpectedNotes: []string{
+				"azurerm_example.first (" + exampleIdPrefix + "first): `password` is sensitive, so needs to be set manually",
+				"azurerm_example.second (" + exampleIdPrefix + "gone): reading the Resource: the Resource no longer exists",
+				"azurerm_example.second_2 (" + exampleIdPrefix + "second): `password` is sensitive, so needs to be set manually",
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Unsupported/unsupported/example: no Resource supports importing the Azure Resource Type \"Microsoft.Unsupported/unsupported\"",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		generator := NewGenerator(context.TODO(), testProvider(), nil)
+		result, err := generator.Generate(context.TODO(), resources, v.ImportOnly)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if actual := string(result.Config); actual != v.ExpectedConfig {
+			t.Fatalf("expected:\n%s\n\nbut got:\n%s", v.ExpectedConfig, actual)
+		}
+
+		notes := make([]string, 0)
+		for _, note := range result.Notes {
+			notes = append(notes, note.String())
+		}
+		if !reflect.DeepEqual(notes, v.ExpectedNotes) {
+			t.Fatalf("expected the notes:\n%s\n\nbut got:\n%s", strings.Join(v.ExpectedNotes, "\n"), strings.Join(notes, "\n"))
+		}
+	}
+}
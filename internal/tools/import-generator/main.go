@@ -0,0 +1,247 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/import-generator/generator"
+)
+
+func main() {
+	f := flag.NewFlagSet("import-generator", flag.ExitOnError)
+
+	subscriptionId := f.String("subscription-id", os.Getenv("ARM_SUBSCRIPTION_ID"), "the ID of the Subscription containing the Resources to import, defaults to the `ARM_SUBSCRIPTION_ID` environment variable")
+	resourceGroupName := f.String("resource-group", "", "the name of the Resource Group containing the Resources to import, when unspecified all Resources within the Subscription are imported")
+	outputPath := f.String("output", "", "the path to the directory where the Terraform Configuration should be written")
+	fileName := f.String("file-name", "imports.tf", "the name of the file within the output directory where the Terraform Configuration should be written")
+	importOnly := f.Bool("import-only", false, "only generate the `import` blocks, for example to use with `terraform plan -generate-config-out`")
+	recordingPath := f.String("recording", "", "the path to a file used to record (or replay) the requests made to Azure")
+	recordingMode := f.String("recording-mode", string(common.RecordingModeRecord), "whether the requests made to Azure should be recorded into (`record`) or replayed from (`replay`) the file specified in `-recording`")
+
+	if err := f.Parse(os.Args[1:]); err != nil {
+		log.Fatalf("error parsing args: %+v", err)
+	}
+
+	if *outputPath == "" {
+		log.Fatalf("the `-output` argument must be specified")
+	}
+
+	input := generatorInput{
+		SubscriptionId:    *subscriptionId,
+		ResourceGroupName: *resourceGroupName,
+		OutputPath:        *outputPath,
+		FileName:          *fileName,
+		ImportOnly:        *importOnly,
+		RecordingPath:     *recordingPath,
+		RecordingMode:     common.RecordingMode(*recordingMode),
+	}
+	if err := run(context.Background(), input); err != nil {
+		log.Fatalf("error generating the Terraform Configuration: %+v", err)
+	}
+}
+
+type generatorInput struct {
+	SubscriptionId    string
+	ResourceGroupName string
+	OutputPath        string
+	FileName          string
+	ImportOnly        bool
+	RecordingPath     string
+	RecordingMode     common.RecordingMode
+}
+
+func run(ctx context.Context, input generatorInput) error {
+	outputFilePath := filepath.Join(input.OutputPath, input.FileName)
+	if _, err := os.Stat(outputFilePath); err == nil {
+		return fmt.Errorf("%q already exists", outputFilePath)
+	}
+
+	var recorder *common.Recorder
+	if input.RecordingPath != "" {
+		if input.RecordingMode != common.RecordingModeRecord && input.RecordingMode != common.RecordingModeReplay {
+			return fmt.Errorf("`-recording-mode` must be either %q or %q but got %q", common.RecordingModeRecord, common.RecordingModeReplay, input.RecordingMode)
+		}
+
+		var err error
+		recorder, err = common.NewRecorder(input.RecordingMode, input.RecordingPath)
+		if err != nil {
+			return fmt.Errorf("building the Recorder: %+v", err)
+		}
+		defer recorder.Close()
+	}
+
+	p, err := configureProvider(ctx, input.SubscriptionId, recorder)
+	if err != nil {
+		return err
+	}
+	client := p.Meta().(*clients.Client)
+
+	resources, err := listResources(ctx, client, input.ResourceGroupName)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Found %d Resources", len(resources))
+
+	result, err := generator.NewGenerator(ctx, p, idValidators()).Generate(ctx, resources, input.ImportOnly)
+	if err != nil {
+		return err
+	}
+
+	if recorder != nil {
+		if err := recorder.Save(); err != nil {
+			return fmt.Errorf("saving the recording: %+v", err)
+		}
+	}
+
+	if err := os.MkdirAll(input.OutputPath, 0o755); err != nil {
+		return fmt.Errorf("creating %q: %+v", input.OutputPath, err)
+	}
+	if err := os.WriteFile(outputFilePath, result.Config, 0o644); err != nil {
+		return fmt.Errorf("writing %q: %+v", outputFilePath, err)
+	}
+
+	fmt.Printf("Generated %d `import` blocks into %q\n", result.Imported, outputFilePath)
+	for _, note := range result.Notes {
+		fmt.Printf("NOTE: %s\n", note.String())
+	}
+
+	return nil
+}
+
+// configureProvider configures the Provider in read-only mode, so that reading the Resources can't modify them.
+// Authentication uses the same environment variables (and Azure CLI) as the Provider.
+func configureProvider(ctx context.Context, subscriptionId string, recorder *common.Recorder) (*schema.Provider, error) {
+	p := provider.AzureProvider()
+	if recorder != nil {
+		p = provider.AzureProviderWithRecorder(recorder)
+	}
+
+	config := map[string]interface{}{
+		"features": []interface{}{
+			map[string]interface{}{},
+		},
+		"read_only":                  true,
+		"skip_provider_registration": true,
+	}
+	if subscriptionId != "" {
+		config["subscription_id"] = subscriptionId
+	}
+	if recorder != nil && recorder.Mode() == common.RecordingModeReplay {
+		// no credentials are available when replaying, so the placeholder values from the recording are used
+		config["client_id"] = common.RecordedClientId
+		config["subscription_id"] = common.RecordedSubscriptionId
+		config["tenant_id"] = common.RecordedTenantId
+	}
+
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		for _, v := range diags {
+			log.Printf("[ERROR] %s: %s", v.Summary, v.Detail)
+		}
+		return nil, fmt.Errorf("configuring the Provider")
+	}
+
+	return p, nil
+}
+
+// listResources returns the Resources within the Resource Group (including the Resource Group itself), or all of the
+// Resource Groups and Resources within the Subscription when no Resource Group is specified
+func listResources(ctx context.Context, client *clients.Client, resourceGroupName string) ([]generator.AzureResource, error) {
+	output := make([]generator.AzureResource, 0)
+
+	if resourceGroupName != "" {
+		group, err := client.Resource.GroupsClient.Get(ctx, resourceGroupName)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving the Resource Group %q: %+v", resourceGroupName, err)
+		}
+		output = append(output, generator.AzureResource{
+			ID:   pointer.From(group.ID),
+			Name: pointer.From(group.Name),
+			Type: pointer.From(group.Type),
+		})
+
+		iterator, err := client.Resource.ResourcesClient.ListByResourceGroupComplete(ctx, resourceGroupName, "", "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("listing the Resources within the Resource Group %q: %+v", resourceGroupName, err)
+		}
+		for iterator.NotDone() {
+			v := iterator.Value()
+			output = append(output, generator.AzureResource{
+				ID:   pointer.From(v.ID),
+				Name: pointer.From(v.Name),
+				Type: pointer.From(v.Type),
+			})
+			if err := iterator.NextWithContext(ctx); err != nil {
+				return nil, fmt.Errorf("listing the Resources within the Resource Group %q: %+v", resourceGroupName, err)
+			}
+		}
+
+		return output, nil
+	}
+
+	groups, err := client.Resource.GroupsClient.ListComplete(ctx, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing the Resource Groups: %+v", err)
+	}
+	for groups.NotDone() {
+		v := groups.Value()
+		output = append(output, generator.AzureResource{
+			ID:   pointer.From(v.ID),
+			Name: pointer.From(v.Name),
+			Type: pointer.From(v.Type),
+		})
+		if err := groups.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing the Resource Groups: %+v", err)
+		}
+	}
+
+	iterator, err := client.Resource.ResourcesClient.ListComplete(ctx, "", "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing the Resources: %+v", err)
+	}
+	for iterator.NotDone() {
+		v := iterator.Value()
+		output = append(output, generator.AzureResource{
+			ID:   pointer.From(v.ID),
+			Name: pointer.From(v.Name),
+			Type: pointer.From(v.Type),
+		})
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing the Resources: %+v", err)
+		}
+	}
+
+	return output, nil
+}
+
+// idValidators returns the IDValidationFunc for each Typed Resource, which is used to avoid calling the Importer
+// for Resources which the Resource ID can't be imported into
+func idValidators() map[string]pluginsdk.IDValidationFunc {
+	output := make(map[string]pluginsdk.IDValidationFunc)
+	for _, service := range provider.SupportedTypedServices() {
+		for _, resource := range service.Resources() {
+			validateFunc := resource.IDValidationFunc()
+			output[resource.ResourceType()] = func(id string) error {
+				_, errs := validateFunc(id, "id")
+				if len(errs) > 0 {
+					return errs[0]
+				}
+				return nil
+			}
+		}
+	}
+	return output
+}
@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+// ChangelogEntry is a single line within a section of the Changelog
+type ChangelogEntry struct {
+	Kind         Kind
+	ResourceType string
+	Message      string
+}
+
+// Changelog is a summary of the changes between two schema dumps, categorised in the same way as `CHANGELOG.md`
+type Changelog struct {
+	BreakingChanges []ChangelogEntry
+	Features        []ChangelogEntry
+	Enhancements    []ChangelogEntry
+	Deprecations    []ChangelogEntry
+}
+
+// Changelog compares the schema within the base dump to the schema within the current dump, returning a summary
+// of the changes introduced in the current dump
+func (d *Differ) Changelog(baseFileName string, currentFileName string) (*Changelog, error) {
+	base, err := loadFile(baseFileName)
+	if err != nil {
+		return nil, fmt.Errorf("loading %q: %+v", baseFileName, err)
+	}
+	current, err := loadFile(currentFileName)
+	if err != nil {
+		return nil, fmt.Errorf("loading %q: %+v", currentFileName, err)
+	}
+
+	if base.ProviderName != current.ProviderName {
+		return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", base.ProviderName, current.ProviderName)
+	}
+	if base.ProviderSchema == nil || current.ProviderSchema == nil {
+		return nil, fmt.Errorf("both %q and %q must contain a provider schema", baseFileName, currentFileName)
+	}
+
+	d.base = base
+	d.current = current
+
+	return buildChangelog(base.ProviderSchema, current.ProviderSchema), nil
+}
+
+func buildChangelog(base *providerjson.ProviderSchemaJSON, current *providerjson.ProviderSchemaJSON) *Changelog {
+	changelog := &Changelog{}
+
+	for _, v := range compareProviders(base, current) {
+		message := v.Message
+		if v.Rule == RuleRemovedResource {
+			message = fmt.Sprintf("this %s has been removed", v.Kind)
+		}
+		changelog.BreakingChanges = append(changelog.BreakingChanges, ChangelogEntry{
+			Kind:         v.Kind,
+			ResourceType: v.ResourceType,
+			Message:      message,
+		})
+	}
+
+	changelog.compareResources(KindDataSource, base.DataSourcesMap, current.DataSourcesMap)
+	changelog.compareResources(KindResource, base.ResourcesMap, current.ResourcesMap)
+
+	for _, entries := range [][]ChangelogEntry{changelog.BreakingChanges, changelog.Features, changelog.Enhancements, changelog.Deprecations} {
+		sortChangelogEntries(entries)
+	}
+
+	return changelog
+}
+
+func (c *Changelog) compareResources(kind Kind, base map[string]providerjson.ResourceJSON, current map[string]providerjson.ResourceJSON) {
+	for resourceType, currentResource := range current {
+		baseResource, ok := base[resourceType]
+		if !ok {
+			c.Features = append(c.Features, ChangelogEntry{
+				Kind:         kind,
+				ResourceType: resourceType,
+			})
+			continue
+		}
+
+		if baseResource.DeprecationMessage == "" && currentResource.DeprecationMessage != "" {
+			c.Deprecations = append(c.Deprecations, ChangelogEntry{
+				Kind:         kind,
+				ResourceType: resourceType,
+				Message:      fmt.Sprintf("this %s has been deprecated: %s", kind, currentResource.DeprecationMessage),
+			})
+		}
+
+		newProperties := make([]string, 0)
+		for _, change := range comparePropertiesForChangelog("", baseResource.Schema, currentResource.Schema) {
+			if change.deprecated != "" {
+				c.Deprecations = append(c.Deprecations, ChangelogEntry{
+					Kind:         kind,
+					ResourceType: resourceType,
+					Message:      fmt.Sprintf("the `%s` property has been deprecated: %s", change.path, change.deprecated),
+				})
+				continue
+			}
+			newProperties = append(newProperties, change.path)
+		}
+
+		if len(newProperties) > 0 {
+			sort.Strings(newProperties)
+			c.Enhancements = append(c.Enhancements, ChangelogEntry{
+				Kind:         kind,
+				ResourceType: resourceType,
+				Message:      supportForProperties(newProperties),
+			})
+		}
+	}
+}
+
+type changelogPropertyChange struct {
+	path string
+
+	// deprecated is the deprecation message when an existing property has been deprecated, otherwise this is a
+	// new property
+	deprecated string
+}
+
+// comparePropertiesForChangelog returns the properties which have been added or deprecated, including those within
+// existing blocks - the properties within a new block aren't returned since the block itself is new
+func comparePropertiesForChangelog(parentPath string, base map[string]providerjson.SchemaJSON, current map[string]providerjson.SchemaJSON) []changelogPropertyChange {
+	changes := make([]changelogPropertyChange, 0)
+
+	for propertyName, currentProperty := range current {
+		path := propertyName
+		if parentPath != "" {
+			path = parentPath + "." + propertyName
+		}
+
+		baseProperty, ok := base[propertyName]
+		if !ok {
+			changes = append(changes, changelogPropertyChange{
+				path: path,
+			})
+			continue
+		}
+
+		if baseProperty.Deprecated == "" && currentProperty.Deprecated != "" {
+			changes = append(changes, changelogPropertyChange{
+				path:       path,
+				deprecated: currentProperty.Deprecated,
+			})
+		}
+
+		if baseNested, ok := nestedSchema(baseProperty); ok {
+			if currentNested, ok := nestedSchema(currentProperty); ok {
+				changes = append(changes, comparePropertiesForChangelog(path, baseNested, currentNested)...)
+			}
+		}
+	}
+
+	return changes
+}
+
+func supportForProperties(properties []string) string {
+	quoted := make([]string, 0)
+	for _, p := range properties {
+		quoted = append(quoted, fmt.Sprintf("`%s`", p))
+	}
+
+	if len(quoted) == 1 {
+		return fmt.Sprintf("support for the %s property", quoted[0])
+	}
+
+	return fmt.Sprintf("support for the %s and %s properties", strings.Join(quoted[:len(quoted)-1], ", "), quoted[len(quoted)-1])
+}
+
+func sortChangelogEntries(input []ChangelogEntry) {
+	sort.Slice(input, func(i, j int) bool {
+		if input[i].Kind != input[j].Kind {
+			return input[i].Kind < input[j].Kind
+		}
+		if input[i].ResourceType != input[j].ResourceType {
+			return input[i].ResourceType < input[j].ResourceType
+		}
+		return input[i].Message < input[j].Message
+	})
+}
+
+// WriteMarkdown writes the Changelog as Markdown in the same format used within `CHANGELOG.md`
+func (c Changelog) WriteMarkdown(w io.Writer) error {
+	sections := []struct {
+		title   string
+		entries []ChangelogEntry
+	}{
+		{
+			title:   "BREAKING CHANGES",
+			entries: c.BreakingChanges,
+		},
+		{
+			title:   "FEATURES",
+			entries: c.Features,
+		},
+		{
+			title:   "ENHANCEMENTS",
+			entries: c.Enhancements,
+		},
+		{
+			title:   "DEPRECATION",
+			entries: c.Deprecations,
+		},
+	}
+
+	lines := make([]string, 0)
+	for _, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, section.title+":", "")
+		for _, entry := range section.entries {
+			lines = append(lines, entry.markdown(section.title == "FEATURES"))
+		}
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, "NOTES:", "", "* no changes to the provider schema were found")
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+func (e ChangelogEntry) markdown(feature bool) string {
+	if feature {
+		kind := "Resource"
+		if e.Kind == KindDataSource {
+			kind = "Data Source"
+		}
+		return fmt.Sprintf("* **New %s**: `%s`", kind, e.ResourceType)
+	}
+
+	prefix := ""
+	if e.Kind == KindDataSource {
+		prefix = "Data Source: "
+	}
+	return fmt.Sprintf("* %s`%s` - %s", prefix, e.ResourceType, e.Message)
+}
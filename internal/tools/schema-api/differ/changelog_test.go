@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestChangelogMarkdown(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Base     *providerjson.ProviderSchemaJSON
+		Current  *providerjson.ProviderSchemaJSON
+		Expected string
+	}{
+		{
+			Name: "No Changes",
+			Base: &providerjson.ProviderSchemaJSON{
+				ResourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"name": {
+								Type:     providerjson.SchemaTypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			Current: &providerjson.ProviderSchemaJSON{
+				ResourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"name": {
+								Type:     providerjson.SchemaTypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			Expected: `NOTES:
+
+* no changes to the provider schema were found
+`,
+		},
+		{
+			Name: "All Categories",
+			Base: &providerjson.ProviderSchemaJSON{
+				ResourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"name": {
+								Type:     providerjson.SchemaTypeString,
+								Required: true,
+							},
+							"sku": {
+								Type:     providerjson.SchemaTypeString,
+								Optional: true,
+							},
+							"network_rules": {
+								Type:     providerjson.SchemaTypeList,
+								Optional: true,
+								Elem: providerjson.ResourceJSON{
+									Schema: map[string]providerjson.SchemaJSON{
+										"default_action": {
+											Type:     providerjson.SchemaTypeString,
+											Optional: true,
+										},
+									},
+								},
+							},
+						},
+					},
+					"azurerm_legacy": {
+						Schema: map[string]providerjson.SchemaJSON{},
+					},
+				},
+				DataSourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"name": {
+								Type:     providerjson.SchemaTypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			Current: &providerjson.ProviderSchemaJSON{
+				ResourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"name": {
+								Type:     providerjson.SchemaTypeString,
+								Required: true,
+							},
+							"sku": {
+								Type:       providerjson.SchemaTypeString,
+								Optional:   true,
+								Deprecated: "`sku` will be removed in favour of `sku_name` in version 4.0 of the AzureRM Provider",
+							},
+							"sku_name": {
+								Type:     providerjson.SchemaTypeString,
+								Optional: true,
+							},
+							"network_rules": {
+								Type:     providerjson.SchemaTypeList,
+								Optional: true,
+								Elem: providerjson.ResourceJSON{
+									Schema: map[string]providerjson.SchemaJSON{
+										"default_action": {
+											Type:     providerjson.SchemaTypeString,
+											Optional: true,
+										},
+										"bypass": {
+											Type:     providerjson.SchemaTypeString,
+											Optional: true,
+										},
+									},
+								},
+							},
+						},
+					},
+					"azurerm_new_example": {
+						Schema: map[string]providerjson.SchemaJSON{},
+					},
+				},
+				DataSourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"name": {
+								Type:     providerjson.SchemaTypeString,
+								Required: true,
+							},
+							"sku_name": {
+								Type:     providerjson.SchemaTypeString,
+								Computed: true,
+							},
+						},
+					},
+					"azurerm_new_example": {
+						Schema: map[string]providerjson.SchemaJSON{},
+					},
+				},
+			},
+			Expected: "BREAKING CHANGES:\n" +
+				"\n" +
+				"* `azurerm_legacy` - this resource has been removed\n" +
+				"\n" +
+				"FEATURES:\n" +
+				"\n" +
+				"* **New Data Source**: `azurerm_new_example`\n" +
+				"* **New Resource**: `azurerm_new_example`\n" +
+				"\n" +
+				"ENHANCEMENTS:\n" +
+				"\n" +
+				"* Data Source: `azurerm_example` - support for the `sku_name` property\n" +
+				"* `azurerm_example` - support for the `network_rules.bypass` and `sku_name` properties\n" +
+				"\n" +
+				"DEPRECATION:\n" +
+				"\n" +
+				"* `azurerm_example` - the `sku` property has been deprecated: `sku` will be removed in favour of `sku_name` in version 4.0 of the AzureRM Provider\n",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		buf := &bytes.Buffer{}
+		if err := buildChangelog(v.Base, v.Current).WriteMarkdown(buf); err != nil {
+			t.Fatalf("writing markdown: %+v", err)
+		}
+
+		if actual := buf.String(); actual != v.Expected {
+			t.Fatalf("expected:\n%s\n\nbut got:\n%s", v.Expected, actual)
+		}
+	}
+}
@@ -5,6 +5,8 @@ package differ
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 	schema_rules "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/schema-rules"
@@ -15,104 +17,175 @@ type Differ struct {
 	current *providerjson.ProviderWrapper
 }
 
+// Diff compares the schema of the current provider to the schema within the named dump, returning a message for each breaking change
 func (d *Differ) Diff(fileName string, providerName string) []string {
-	if err := d.loadFromProvider(providerjson.LoadData(), providerName); err != nil {
+	violations, err := d.Violations(fileName, providerName)
+	if err != nil {
 		return []string{err.Error()}
 	}
 
+	output := make([]string, 0)
+	for _, v := range violations {
+		output = append(output, v.String())
+	}
+	return output
+}
+
+// Violations compares the schema of the current provider to the schema within the named dump, returning each breaking change
+func (d *Differ) Violations(fileName string, providerName string) ([]Violation, error) {
+	if err := d.loadFromProvider(providerjson.LoadData(), providerName); err != nil {
+		return nil, err
+	}
+
 	if err := d.loadFromFile(fileName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if d.base.ProviderName != d.current.ProviderName {
-		return []string{fmt.Sprintf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)}
+		return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)
 	}
 
-	violations := make([]string, 0)
+	return compareProviders(d.base.ProviderSchema, d.current.ProviderSchema), nil
+}
 
-	for resource, rs := range d.current.ProviderSchema.ResourcesMap {
-		_, ok := d.base.ProviderSchema.ResourcesMap[resource]
-		if !ok {
-			// New resource, no breaking changes to worry about
-			continue
+func compareProviders(base *providerjson.ProviderSchemaJSON, current *providerjson.ProviderSchemaJSON) []Violation {
+	violations := make([]Violation, 0)
+	violations = append(violations, compareResources(KindResource, base.ResourcesMap, current.ResourcesMap, schema_rules.BreakingChangeRules)...)
+	violations = append(violations, compareResources(KindDataSource, base.DataSourcesMap, current.DataSourcesMap, schema_rules.BreakingChangeRulesDataSource)...)
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Kind != violations[j].Kind {
+			return violations[i].Kind < violations[j].Kind
 		}
-		for propertyName, propertySchema := range rs.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.ResourcesMap[resource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeResource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+		if violations[i].ResourceType != violations[j].ResourceType {
+			return violations[i].ResourceType < violations[j].ResourceType
+		}
+		if violations[i].Path != violations[j].Path {
+			return violations[i].Path < violations[j].Path
 		}
+		return violations[i].Rule < violations[j].Rule
+	})
+	return violations
+}
+
+func compareResources(kind Kind, base map[string]providerjson.ResourceJSON, current map[string]providerjson.ResourceJSON, rules []schema_rules.BreakingChangeRule) []Violation {
+	violations := make([]Violation, 0)
+
+	for resourceType, baseResource := range base {
+		if _, ok := current[resourceType]; !ok {
+			violations = append(violations, Violation{
+				Kind:         kind,
+				ResourceType: resourceType,
+				Rule:         RuleRemovedResource,
+				Message:      fmt.Sprintf("%s %q has been removed", kind, resourceType),
+			})
+			continue
+		}
+
+		violations = append(violations, compareSchemas(kind, resourceType, "", baseResource.Schema, current[resourceType].Schema, rules)...)
 	}
 
-	for dataSource, ds := range d.current.ProviderSchema.DataSourcesMap {
-		_, ok := d.base.ProviderSchema.DataSourcesMap[dataSource]
+	for resourceType, currentResource := range current {
+		baseResource, ok := base[resourceType]
 		if !ok {
-			// New data source, no breaking changes to worry about
+			// New resource, no breaking changes to worry about
 			continue
 		}
-		for propertyName, propertySchema := range ds.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.DataSourcesMap[dataSource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeDataSource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
+
+		for propertyName, propertySchema := range currentResource.Schema {
+			if _, ok := baseResource.Schema[propertyName]; ok {
+				continue
 			}
+
+			// New property, could be breaking - Required etc
+			violations = append(violations, checkRules(kind, resourceType, propertyName, providerjson.SchemaJSON{}, propertySchema, rules)...)
 		}
 	}
 
 	return violations
 }
 
-func compareNodeResource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeResource(newBase, newCurrent[k], k)...)
+// compareSchemas compares each property in the base schema with the same property in the current schema, including
+// any nested properties - the parentPath is the path to the block containing these properties (e.g. `network_rules`)
+func compareSchemas(kind Kind, resourceType string, parentPath string, base map[string]providerjson.SchemaJSON, current map[string]providerjson.SchemaJSON, rules []schema_rules.BreakingChangeRule) []Violation {
+	violations := make([]Violation, 0)
+
+	for propertyName, baseProperty := range base {
+		path := propertyName
+		if parentPath != "" {
+			path = parentPath + "." + propertyName
 		}
-	}
 
-	for _, v := range schema_rules.BreakingChangeRules {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+		currentProperty, ok := current[propertyName]
+		if !ok {
+			violations = append(violations, Violation{
+				Kind:         kind,
+				ResourceType: resourceType,
+				Path:         path,
+				Rule:         RuleRemovedProperty,
+				Message:      fmt.Sprintf("property %q has been removed", path),
+			})
+			continue
 		}
-	}
 
-	return
-}
+		if baseNested, ok := nestedSchema(baseProperty); ok {
+			if currentNested, ok := nestedSchema(currentProperty); ok {
+				violations = append(violations, compareSchemas(kind, resourceType, path, baseNested, currentNested, rules)...)
+			}
+		}
+
+		violations = append(violations, checkRules(kind, resourceType, path, baseProperty, currentProperty, rules)...)
+	}
 
-func compareNodeDataSource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeDataSource(newBase, newCurrent[k], k)...)
+	// new properties within existing blocks can also be breaking (e.g. Required)
+	if parentPath != "" {
+		for propertyName, currentProperty := range current {
+			if _, ok := base[propertyName]; !ok {
+				violations = append(violations, checkRules(kind, resourceType, parentPath+"."+propertyName, providerjson.SchemaJSON{}, currentProperty, rules)...)
+			}
 		}
 	}
 
-	for _, v := range schema_rules.BreakingChangeRulesDataSource {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+	return violations
+}
+
+func checkRules(kind Kind, resourceType string, path string, base providerjson.SchemaJSON, current providerjson.SchemaJSON, rules []schema_rules.BreakingChangeRule) []Violation {
+	violations := make([]Violation, 0)
+	for _, rule := range rules {
+		if err := rule.Check(base, current, path); err != nil {
+			violations = append(violations, Violation{
+				Kind:         kind,
+				ResourceType: resourceType,
+				Path:         path,
+				Rule:         ruleName(rule),
+				Message:      *err,
+			})
 		}
 	}
+	return violations
+}
 
-	return
+// ruleName returns the name of the type implementing the rule, e.g. `forceNewAdded`
+func ruleName(rule schema_rules.BreakingChangeRule) string {
+	name := fmt.Sprintf("%T", rule)
+	return name[strings.LastIndex(name, ".")+1:]
 }
 
-func nodeIsBlock(input providerjson.SchemaJSON) bool {
-	if input.Type == providerjson.SchemaTypeList || input.Type == providerjson.SchemaTypeSet {
-		if _, ok := input.Elem.(providerjson.ResourceJSON); ok {
-			return true
+// nestedSchema returns the schema for the properties within a block, which is a pointer when loaded from the
+// provider and a value when loaded from a file
+func nestedSchema(input providerjson.SchemaJSON) (map[string]providerjson.SchemaJSON, bool) {
+	if input.Type != providerjson.SchemaTypeList && input.Type != providerjson.SchemaTypeSet {
+		return nil, false
+	}
+
+	switch elem := input.Elem.(type) {
+	case providerjson.ResourceJSON:
+		return elem.Schema, true
+	case *providerjson.ResourceJSON:
+		if elem != nil {
+			return elem.Schema, true
 		}
 	}
 
-	return false
+	return nil, false
 }
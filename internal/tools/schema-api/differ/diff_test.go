@@ -0,0 +1,220 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestCompareProviders(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Base     map[string]providerjson.ResourceJSON
+		Current  map[string]providerjson.ResourceJSON
+		Expected []Violation
+	}{
+		{
+			Name: "No Changes",
+			Base: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {
+							Type:     providerjson.SchemaTypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			Current: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {
+							Type:     providerjson.SchemaTypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			Expected: []Violation{},
+		},
+		{
+			Name: "Resource Removed",
+			Base: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{},
+				},
+			},
+			Current: map[string]providerjson.ResourceJSON{},
+			Expected: []Violation{
+				{
+					Kind:         KindResource,
+					ResourceType: "azurerm_example",
+					Rule:         RuleRemovedResource,
+				},
+			},
+		},
+		{
+			Name: "Property Removed",
+			Base: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {
+							Type:     providerjson.SchemaTypeString,
+							Required: true,
+						},
+						"enabled": {
+							Type:     providerjson.SchemaTypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			Current: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {
+							Type:     providerjson.SchemaTypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			Expected: []Violation{
+				{
+					Kind:         KindResource,
+					ResourceType: "azurerm_example",
+					Path:         "enabled",
+					Rule:         RuleRemovedProperty,
+				},
+			},
+		},
+		{
+			Name: "Nested Property Removed and ForceNew Added",
+			Base: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"network_rules": {
+							Type:     providerjson.SchemaTypeList,
+							Optional: true,
+							Elem: providerjson.ResourceJSON{
+								Schema: map[string]providerjson.SchemaJSON{
+									"default_action": {
+										Type:     providerjson.SchemaTypeString,
+										Optional: true,
+									},
+									"bypass": {
+										Type:     providerjson.SchemaTypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Current: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"network_rules": {
+							Type:     providerjson.SchemaTypeList,
+							Optional: true,
+							Elem: &providerjson.ResourceJSON{
+								Schema: map[string]providerjson.SchemaJSON{
+									"default_action": {
+										Type:     providerjson.SchemaTypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Expected: []Violation{
+				{
+					Kind:         KindResource,
+					ResourceType: "azurerm_example",
+					Path:         "network_rules.bypass",
+					Rule:         RuleRemovedProperty,
+				},
+				{
+					Kind:         KindResource,
+					ResourceType: "azurerm_example",
+					Path:         "network_rules.default_action",
+					Rule:         "forceNewAdded",
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		base := &providerjson.ProviderSchemaJSON{
+			ResourcesMap:   v.Base,
+			DataSourcesMap: map[string]providerjson.ResourceJSON{},
+		}
+		current := &providerjson.ProviderSchemaJSON{
+			ResourcesMap:   v.Current,
+			DataSourcesMap: map[string]providerjson.ResourceJSON{},
+		}
+
+		actual := compareProviders(base, current)
+		if len(actual) != len(v.Expected) {
+			t.Fatalf("expected %d violations but got %d: %+v", len(v.Expected), len(actual), actual)
+		}
+		for i, expected := range v.Expected {
+			if actual[i].Kind != expected.Kind || actual[i].ResourceType != expected.ResourceType || actual[i].Path != expected.Path || actual[i].Rule != expected.Rule {
+				t.Fatalf("expected violation %d to be %+v but got %+v", i, expected, actual[i])
+			}
+			if actual[i].Message == "" {
+				t.Fatalf("expected violation %d to have a message", i)
+			}
+		}
+	}
+}
+
+func TestWriteReportSARIF(t *testing.T) {
+	violations := []Violation{
+		{
+			Kind:         KindDataSource,
+			ResourceType: "azurerm_example",
+			Path:         "name",
+			Rule:         "propertyType",
+			Message:      "example message",
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteReport(buf, ReportFormatSARIF, violations); err != nil {
+		t.Fatalf("writing report: %+v", err)
+	}
+
+	var report sarifReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("parsing report: %+v", err)
+	}
+
+	if len(report.Runs) != 1 || len(report.Runs[0].Results) != 1 {
+		t.Fatalf("expected a single run with a single result but got %+v", report)
+	}
+	result := report.Runs[0].Results[0]
+	if result.RuleID != "propertyType" {
+		t.Fatalf("expected the rule ID to be `propertyType` but got %q", result.RuleID)
+	}
+	if actual := result.Locations[0].LogicalLocations[0].FullyQualifiedName; actual != "data.azurerm_example.name" {
+		t.Fatalf("expected the fully qualified name to be `data.azurerm_example.name` but got %q", actual)
+	}
+
+	if err := WriteReport(buf, ReportFormat("yaml"), violations); err == nil {
+		t.Fatalf("expected an error for an unsupported report format")
+	}
+}
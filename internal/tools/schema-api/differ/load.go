@@ -11,19 +11,28 @@ import (
 )
 
 func (d *Differ) loadFromFile(fileName string) error {
-	f, err := os.Open(fileName)
+	wrapper, err := loadFile(fileName)
 	if err != nil {
 		return err
 	}
+	d.base = wrapper
+
+	return nil
+}
+
+func loadFile(fileName string) (*providerjson.ProviderWrapper, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
 	defer f.Close()
 	buf := &providerjson.ProviderWrapper{}
 	// TODO - Custom marshalling to fix the type assertions later? meh, works for now...
 	if err := json.NewDecoder(f).Decode(buf); err != nil {
-		return err
+		return nil, err
 	}
-	d.base = buf
 
-	return nil
+	return buf, nil
 }
 
 func (d *Differ) loadFromProvider(data *providerjson.ProviderJSON, providerName string) error {
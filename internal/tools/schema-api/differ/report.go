@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+type ReportFormat string
+
+const (
+	ReportFormatJSON  ReportFormat = "json"
+	ReportFormatSARIF ReportFormat = "sarif"
+	ReportFormatText  ReportFormat = "text"
+)
+
+// WriteReport writes the violations to the writer in the specified format
+func WriteReport(w io.Writer, format ReportFormat, violations []Violation) error {
+	switch format {
+	case ReportFormatJSON:
+		return writeJSON(w, violations)
+	case ReportFormatSARIF:
+		return writeSARIF(w, violations)
+	case ReportFormatText:
+		for _, v := range violations {
+			if _, err := fmt.Fprintln(w, v.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported report format %q", format)
+}
+
+type jsonReport struct {
+	Violations []Violation `json:"violations"`
+}
+
+func writeJSON(w io.Writer, violations []Violation) error {
+	if violations == nil {
+		violations = make([]Violation, 0)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonReport{
+		Violations: violations,
+	})
+}
+
+// the subset of the Static Analysis Results Interchange Format (SARIF) v2.1.0 used for the report, see
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func writeSARIF(w io.Writer, violations []Violation) error {
+	ruleIds := make(map[string]struct{})
+	results := make([]sarifResult, 0)
+	for _, v := range violations {
+		ruleIds[v.Rule] = struct{}{}
+
+		kind := "member"
+		if v.Path == "" {
+			kind = "type"
+		}
+		results = append(results, sarifResult{
+			RuleID: v.Rule,
+			Level:  "error",
+			Message: sarifMessage{
+				Text: v.String(),
+			},
+			Locations: []sarifLocation{
+				{
+					LogicalLocations: []sarifLogicalLocation{
+						{
+							FullyQualifiedName: v.Address(),
+							Kind:               kind,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	rules := make([]sarifRule, 0)
+	for id := range ruleIds {
+		rules = append(rules, sarifRule{
+			ID: id,
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifReport{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:  "schema-api",
+						Rules: rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import "fmt"
+
+type Kind string
+
+const (
+	KindDataSource Kind = "data source"
+	KindResource   Kind = "resource"
+)
+
+const (
+	// RuleRemovedResource is the rule for a Resource or Data Source which has been removed
+	RuleRemovedResource = "removedResource"
+
+	// RuleRemovedProperty is the rule for a property which has been removed from a Resource or Data Source
+	RuleRemovedProperty = "removedProperty"
+)
+
+// Violation is a breaking change between the base and current schemas
+type Violation struct {
+	// Kind is whether this violation is for a Resource or a Data Source
+	Kind Kind `json:"kind"`
+
+	// ResourceType is the type of the Resource or Data Source, e.g. `azurerm_resource_group`
+	ResourceType string `json:"resourceType"`
+
+	// Path is the path to the property within the Resource or Data Source, e.g. `network_rules.ip_rules`,
+	// which is empty when the violation applies to the Resource or Data Source itself
+	Path string `json:"path,omitempty"`
+
+	// Rule is the name of the rule which has been violated, e.g. `forceNewAdded`
+	Rule string `json:"rule"`
+
+	// Message is a description of the violation
+	Message string `json:"message"`
+}
+
+// Address returns the address of the Resource/Data Source and property this violation applies to, for example
+// `azurerm_resource_group.tags` or `data.azurerm_resource_group.location`
+func (v Violation) Address() string {
+	address := v.ResourceType
+	if v.Kind == KindDataSource {
+		address = "data." + address
+	}
+	if v.Path != "" {
+		address += "." + v.Path
+	}
+	return address
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return fmt.Sprintf("%s %q: %s", v.Kind, v.ResourceType, v.Message)
+}
@@ -26,6 +26,10 @@ func main() {
 	exportSchema := f.String("export", "", "export the schema to the given path/filename. Intended for use in the release process")
 	detectBreakingChanges := f.String("detect", "", "compare current schema to named dump.")
 	errorOnBreakingChange := f.Bool("error-on-violation", false, "should the detect mode exit with a non-zero error code. Defaults to `false`")
+	reportFormat := f.String("report-format", string(differ.ReportFormatText), "the format of the violations output by the detect mode, one of `text`, `json` or `sarif`. Defaults to `text`")
+	reportFile := f.String("report-file", "", "write the output of the detect or changelog modes to the given path/filename rather than stdout")
+	changelogBase := f.String("changelog-base", "", "generate a changelog of the changes from the named dump to the dump specified in `-changelog-current`")
+	changelogCurrent := f.String("changelog-current", "", "the named dump to compare against the dump specified in `-changelog-base`")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		fmt.Printf("error parsing args: %+v", err)
@@ -53,12 +57,63 @@ func main() {
 	case pointer.From(detectBreakingChanges) != "":
 		{
 			d := differ.Differ{}
-			if violations := d.Diff(*detectBreakingChanges, *providerName); violations != nil {
-				for _, v := range violations {
-					log.Println(v)
+			violations, err := d.Violations(*detectBreakingChanges, *providerName)
+			if err != nil {
+				log.Fatalf("error detecting breaking changes: %+v", err)
+			}
+
+			output := os.Stdout
+			if reportPath := pointer.From(reportFile); reportPath != "" {
+				file, err := os.Create(reportPath)
+				if err != nil {
+					log.Fatalf("error creating report file %q: %+v", reportPath, err)
+				}
+				output = file
+			}
+
+			if err := differ.WriteReport(output, differ.ReportFormat(pointer.From(reportFormat)), violations); err != nil {
+				log.Fatalf("error writing report: %+v", err)
+			}
+			if output != os.Stdout {
+				if err := output.Close(); err != nil {
+					log.Fatalf("error closing report file: %+v", err)
 				}
-				if pointer.From(errorOnBreakingChange) {
-					os.Exit(1)
+			}
+
+			if len(violations) > 0 && pointer.From(errorOnBreakingChange) {
+				os.Exit(1)
+			}
+
+			os.Exit(0)
+		}
+
+	case pointer.From(changelogBase) != "":
+		{
+			if pointer.From(changelogCurrent) == "" {
+				log.Fatalf("`-changelog-current` must be specified when using `-changelog-base`")
+			}
+
+			d := differ.Differ{}
+			changelog, err := d.Changelog(*changelogBase, *changelogCurrent)
+			if err != nil {
+				log.Fatalf("error generating changelog: %+v", err)
+			}
+
+			output := os.Stdout
+			if reportPath := pointer.From(reportFile); reportPath != "" {
+				file, err := os.Create(reportPath)
+				if err != nil {
+					log.Fatalf("error creating changelog file %q: %+v", reportPath, err)
+				}
+				output = file
+			}
+
+			if err := changelog.WriteMarkdown(output); err != nil {
+				log.Fatalf("error writing changelog: %+v", err)
+			}
+			if output != os.Stdout {
+				if err := output.Close(); err != nil {
+					log.Fatalf("error closing changelog file: %+v", err)
 				}
 			}
 
@@ -33,6 +33,9 @@ type SchemaJSON struct {
 	Elem        interface{} `json:"elem,omitempty"`
 	MaxItems    int         `json:"maxItems,omitempty"`
 	MinItems    int         `json:"minItems,omitempty"`
+	Deprecated  string      `json:"deprecated,omitempty"`
+
+	Validation *ValidationJSON `json:"validation,omitempty"`
 }
 
 func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
@@ -48,11 +51,15 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 	b.Description, _ = m["description"].(string)
 	b.Computed, _ = m["computed"].(bool)
 	b.ForceNew, _ = m["forceNew"].(bool)
+	b.Deprecated, _ = m["deprecated"].(string)
 	if max, ok := m["maxItems"].(float64); ok {
 		b.MaxItems = int(max)
 	}
 	if min, ok := m["minItems"].(float64); ok {
-		b.MaxItems = int(min)
+		b.MinItems = int(min)
+	}
+	if v, ok := m["validation"].(map[string]interface{}); ok {
+		b.Validation = validationFromMap(v)
 	}
 
 	if def, ok := m["default"]; ok && def != nil {
@@ -84,8 +91,9 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 }
 
 type ResourceJSON struct {
-	Schema   map[string]SchemaJSON `json:"schema"`
-	Timeouts *ResourceTimeoutJSON  `json:"timeouts,omitempty"`
+	Schema             map[string]SchemaJSON `json:"schema"`
+	Timeouts           *ResourceTimeoutJSON  `json:"timeouts,omitempty"`
+	DeprecationMessage string                `json:"deprecationMessage,omitempty"`
 }
 
 type ResourceTimeoutJSON struct {
@@ -21,6 +21,7 @@ func resourceFromRaw(input *schema.Resource) (*ResourceJSON, error) {
 		translatedSchema[k] = schemaFromRaw(s)
 	}
 	result.Schema = translatedSchema
+	result.DeprecationMessage = input.DeprecationMessage
 
 	if input.Timeouts != nil {
 		timeouts := &ResourceTimeoutJSON{}
@@ -57,6 +58,8 @@ func schemaFromRaw(input *schema.Schema) SchemaJSON {
 		Elem:        decodeElem(input.Elem),
 		MaxItems:    input.MaxItems,
 		MinItems:    input.MinItems,
+		Deprecated:  input.Deprecated,
+		Validation:  decodeValidation(input),
 	}
 }
 
@@ -94,8 +97,8 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.ForceNew = t.(bool)
 	}
 
-	if t, ok := input["forceNew"]; ok {
-		result.ForceNew = t.(bool)
+	if t, ok := input["deprecated"]; ok {
+		result.Deprecated = t.(string)
 	}
 
 	if t, ok := input["elem"]; ok {
@@ -110,6 +113,10 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.MaxItems = int(t.(float64))
 	}
 
+	if t, ok := input["validation"]; ok {
+		result.Validation = validationFromMap(t.(map[string]interface{}))
+	}
+
 	return result
 }
 
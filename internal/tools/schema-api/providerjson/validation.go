@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ValidationJSON describes the values accepted by the ValidateFunc of a property, where these can be determined
+type ValidationJSON struct {
+	// AllowedValues are the values accepted by a `validation.StringInSlice` ValidateFunc
+	AllowedValues []string `json:"allowedValues,omitempty"`
+
+	// MinValue is the minimum value accepted by a `validation.IntBetween` or `validation.IntAtLeast` ValidateFunc
+	MinValue *int `json:"minValue,omitempty"`
+
+	// MaxValue is the maximum value accepted by a `validation.IntBetween` or `validation.IntAtMost` ValidateFunc
+	MaxValue *int `json:"maxValue,omitempty"`
+}
+
+// validationProbeKey is the property name passed to the ValidateFunc, which is included in the error messages
+const validationProbeKey = "probe"
+
+// validationProbeString is a value which no ValidateFunc should accept, so that the allowed values are returned
+const validationProbeString = "\x00"
+
+var (
+	allowedValuesRegex = regexp.MustCompile(`(?s)^expected ` + validationProbeKey + ` to be one of \[(.*)\], got ` + validationProbeString + `$`)
+	intRangeRegex      = regexp.MustCompile(`^expected ` + validationProbeKey + ` to be in the range \((-?\d+) - (-?\d+)\), got -?\d+$`)
+	intAtLeastRegex    = regexp.MustCompile(`^expected ` + validationProbeKey + ` to be at least \((-?\d+)\), got -?\d+$`)
+	intAtMostRegex     = regexp.MustCompile(`^expected ` + validationProbeKey + ` to be at most \((-?\d+)\), got -?\d+$`)
+)
+
+// decodeValidation determines the values accepted by a ValidateFunc from the errors it returns for values which are
+// out of range - since the ValidateFunc is a closure the values it was built with can't otherwise be retrieved. Only
+// the `StringInSlice`, `IntBetween`, `IntAtLeast` and `IntAtMost` validation functions (including when combined using
+// `validation.All`) are supported.
+func decodeValidation(input *schema.Schema) *ValidationJSON {
+	if input.ValidateFunc == nil {
+		return nil
+	}
+
+	result := ValidationJSON{}
+	switch input.Type {
+	case schema.TypeString:
+		for _, err := range probeValidateFunc(input.ValidateFunc, validationProbeString) {
+			if match := allowedValuesRegex.FindStringSubmatch(err.Error()); match != nil {
+				result.AllowedValues = splitAllowedValues(input.ValidateFunc, match[1])
+			}
+		}
+
+	case schema.TypeInt:
+		for _, probe := range []int{math.MinInt, math.MaxInt} {
+			for _, err := range probeValidateFunc(input.ValidateFunc, probe) {
+				message := err.Error()
+				if match := intRangeRegex.FindStringSubmatch(message); match != nil {
+					result.MinValue = parseInt(match[1])
+					result.MaxValue = parseInt(match[2])
+				}
+				if match := intAtLeastRegex.FindStringSubmatch(message); match != nil {
+					result.MinValue = parseInt(match[1])
+				}
+				if match := intAtMostRegex.FindStringSubmatch(message); match != nil {
+					result.MaxValue = parseInt(match[1])
+				}
+			}
+		}
+	}
+
+	if len(result.AllowedValues) == 0 && result.MinValue == nil && result.MaxValue == nil {
+		return nil
+	}
+
+	return &result
+}
+
+// probeValidateFunc returns the errors returned by the ValidateFunc for the specified value, recovering from any
+// ValidateFunc which doesn't handle values of an unexpected type
+func probeValidateFunc(f schema.SchemaValidateFunc, value interface{}) (errs []error) {
+	defer func() {
+		if r := recover(); r != nil {
+			errs = nil
+		}
+	}()
+
+	_, errs = f(value, validationProbeKey)
+	return errs
+}
+
+// splitAllowedValues splits the space separated list of allowed values from the error message, joining values which
+// contain spaces by checking each candidate against the ValidateFunc
+func splitAllowedValues(f schema.SchemaValidateFunc, input string) []string {
+	if input == "" {
+		return nil
+	}
+
+	output := make([]string, 0)
+	candidate := ""
+	for _, segment := range strings.Split(input, " ") {
+		if candidate != "" {
+			candidate += " "
+		}
+		candidate += segment
+
+		if len(probeValidateFunc(f, candidate)) == 0 {
+			output = append(output, candidate)
+			candidate = ""
+		}
+	}
+
+	sort.Strings(output)
+	return output
+}
+
+func parseInt(input string) *int {
+	v, err := strconv.Atoi(input)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+func validationFromMap(input map[string]interface{}) *ValidationJSON {
+	result := ValidationJSON{}
+	if v, ok := input["allowedValues"].([]interface{}); ok {
+		for _, value := range v {
+			if s, ok := value.(string); ok {
+				result.AllowedValues = append(result.AllowedValues, s)
+			}
+		}
+	}
+	if v, ok := input["minValue"].(float64); ok {
+		min := int(v)
+		result.MinValue = &min
+	}
+	if v, ok := input["maxValue"].(float64); ok {
+		max := int(v)
+		result.MaxValue = &max
+	}
+
+	return &result
+}
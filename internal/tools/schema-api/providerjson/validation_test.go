@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func TestDecodeValidation(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    *schema.Schema
+		Expected *ValidationJSON
+	}{
+		{
+			Name: "No Validation",
+			Input: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		{
+			Name: "Unsupported Validation",
+			Input: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+		{
+			Name: "String In Slice",
+			Input: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Basic", "Zone Redundant"}, false),
+			},
+			Expected: &ValidationJSON{
+				AllowedValues: []string{"Basic", "Standard", "Zone Redundant"},
+			},
+		},
+		{
+			Name: "String In Slice Combined",
+			Input: &schema.Schema{
+				Type: schema.TypeString,
+				ValidateFunc: validation.All(
+					validation.StringIsNotEmpty,
+					validation.StringInSlice([]string{"Basic", "Standard"}, true),
+				),
+			},
+			Expected: &ValidationJSON{
+				AllowedValues: []string{"Basic", "Standard"},
+			},
+		},
+		{
+			Name: "Int Between",
+			Input: &schema.Schema{
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntBetween(-5, 10),
+			},
+			Expected: &ValidationJSON{
+				MinValue: pointer.To(-5),
+				MaxValue: pointer.To(10),
+			},
+		},
+		{
+			Name: "Int At Least",
+			Input: &schema.Schema{
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			Expected: &ValidationJSON{
+				MinValue: pointer.To(1),
+			},
+		},
+		{
+			Name: "Int At Most",
+			Input: &schema.Schema{
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntAtMost(100),
+			},
+			Expected: &ValidationJSON{
+				MaxValue: pointer.To(100),
+			},
+		},
+		{
+			Name: "Panicking Validation",
+			Input: &schema.Schema{
+				Type: schema.TypeString,
+				ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+					_ = i.(int)
+					return nil, nil
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := decodeValidation(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
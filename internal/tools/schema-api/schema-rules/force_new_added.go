@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type forceNewAdded struct{}
+
+var _ BreakingChangeRule = forceNewAdded{}
+
+// Check - Checks that an existing property is not updated to become ForceNew, since changing it would then recreate the resource
+func (forceNewAdded) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type != "" && current.Type != "" && !base.ForceNew && current.ForceNew {
+		return pointer.To(fmt.Sprintf("property %q has become ForceNew", propertyName))
+	}
+
+	return nil
+}
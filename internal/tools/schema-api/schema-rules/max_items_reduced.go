@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type maxItemsReduced struct{}
+
+var _ BreakingChangeRule = maxItemsReduced{}
+
+// Check - Checks that the MaxItems of a configurable property is not reduced, since existing configurations may specify more items
+func (maxItemsReduced) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type == "" || current.Type == "" || !(current.Optional || current.Required) {
+		return nil
+	}
+
+	// a MaxItems of 0 means that the number of items is unlimited
+	if current.MaxItems > 0 && (base.MaxItems == 0 || current.MaxItems < base.MaxItems) {
+		from := "unlimited"
+		if base.MaxItems > 0 {
+			from = fmt.Sprintf("%d", base.MaxItems)
+		}
+		return pointer.To(fmt.Sprintf("MaxItems for property %q has been reduced from %s to %d", propertyName, from, current.MaxItems))
+	}
+
+	return nil
+}
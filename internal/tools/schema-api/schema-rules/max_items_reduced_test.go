@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestMaxItemsReduced_Check(t *testing.T) {
+	node := func(maxItems int) providerjson.SchemaJSON {
+		return providerjson.SchemaJSON{
+			Type:     providerjson.SchemaTypeList,
+			Optional: true,
+			MaxItems: maxItems,
+		}
+	}
+
+	testData := []struct {
+		Name      string
+		Base      providerjson.SchemaJSON
+		Current   providerjson.SchemaJSON
+		Violation bool
+	}{
+		{
+			Name:    "Unchanged",
+			Base:    node(2),
+			Current: node(2),
+		},
+		{
+			Name:    "Increased",
+			Base:    node(2),
+			Current: node(5),
+		},
+		{
+			Name:    "Limit Removed",
+			Base:    node(2),
+			Current: node(0),
+		},
+		{
+			Name:      "Reduced",
+			Base:      node(5),
+			Current:   node(2),
+			Violation: true,
+		},
+		{
+			Name:      "Limit Added",
+			Base:      node(0),
+			Current:   node(1),
+			Violation: true,
+		},
+		{
+			Name: "Computed Only",
+			Base: node(5),
+			Current: providerjson.SchemaJSON{
+				Type:     providerjson.SchemaTypeList,
+				Computed: true,
+				MaxItems: 1,
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		res := maxItemsReduced{}.Check(v.Base, v.Current, "example")
+		if v.Violation && res == nil {
+			t.Fatalf("expected violation, but didn't get one")
+		}
+		if !v.Violation && res != nil {
+			t.Fatalf("expected no violation, got %+v", *res)
+		}
+	}
+}
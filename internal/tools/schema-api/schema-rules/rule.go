@@ -11,12 +11,16 @@ type BreakingChangeRule interface {
 
 var BreakingChangeRules = []BreakingChangeRule{
 	becomeComputedOnly{},
+	forceNewAdded{},
+	maxItemsReduced{},
 	newRequiredPropertyExistingResource{},
 	optionalRemoveComputed{},
 	optionalToRequired{},
 	propertyType{},
+	validationStricter{},
 }
 
 var BreakingChangeRulesDataSource = []BreakingChangeRule{
+	maxItemsReduced{},
 	propertyType{},
 }
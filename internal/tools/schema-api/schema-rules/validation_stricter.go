@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type validationStricter struct{}
+
+var _ BreakingChangeRule = validationStricter{}
+
+// Check - Checks that the values accepted by a configurable property are not narrowed, either by removing allowed values
+// or by reducing the allowed range, since existing configurations may specify a value which is no longer valid.
+//
+// This is only checked when the validation of the property could be determined in both schemas - since schemas
+// exported prior to the validation being captured would otherwise report every validated property.
+func (validationStricter) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type == "" || current.Type == "" || !(current.Optional || current.Required) || base.Validation == nil || current.Validation == nil {
+		return nil
+	}
+
+	baseValidation := *base.Validation
+
+	violations := make([]string, 0)
+	if len(current.Validation.AllowedValues) > 0 {
+		if len(baseValidation.AllowedValues) == 0 {
+			violations = append(violations, fmt.Sprintf("is now limited to the values %q", current.Validation.AllowedValues))
+		} else if removed := removedValues(baseValidation.AllowedValues, current.Validation.AllowedValues); len(removed) > 0 {
+			violations = append(violations, fmt.Sprintf("no longer allows the values %q", removed))
+		}
+	}
+
+	if v := current.Validation.MinValue; v != nil && (baseValidation.MinValue == nil || *v > *baseValidation.MinValue) {
+		from := "unlimited"
+		if baseValidation.MinValue != nil {
+			from = fmt.Sprintf("%d", *baseValidation.MinValue)
+		}
+		violations = append(violations, fmt.Sprintf("has had its minimum value increased from %s to %d", from, *v))
+	}
+
+	if v := current.Validation.MaxValue; v != nil && (baseValidation.MaxValue == nil || *v < *baseValidation.MaxValue) {
+		from := "unlimited"
+		if baseValidation.MaxValue != nil {
+			from = fmt.Sprintf("%d", *baseValidation.MaxValue)
+		}
+		violations = append(violations, fmt.Sprintf("has had its maximum value reduced from %s to %d", from, *v))
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return pointer.To(fmt.Sprintf("the validation for property %q has become stricter - it %s", propertyName, strings.Join(violations, " and ")))
+}
+
+func removedValues(base []string, current []string) []string {
+	existing := make(map[string]struct{}, len(current))
+	for _, v := range current {
+		existing[v] = struct{}{}
+	}
+
+	output := make([]string, 0)
+	for _, v := range base {
+		if _, ok := existing[v]; !ok {
+			output = append(output, v)
+		}
+	}
+
+	return output
+}
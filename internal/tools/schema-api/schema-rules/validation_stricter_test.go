@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestValidationStricter_Check(t *testing.T) {
+	values := func(input ...string) providerjson.SchemaJSON {
+		return providerjson.SchemaJSON{
+			Type:     providerjson.SchemaTypeString,
+			Optional: true,
+			Validation: &providerjson.ValidationJSON{
+				AllowedValues: input,
+			},
+		}
+	}
+	between := func(min, max *int) providerjson.SchemaJSON {
+		return providerjson.SchemaJSON{
+			Type:     providerjson.SchemaTypeInt,
+			Optional: true,
+			Validation: &providerjson.ValidationJSON{
+				MinValue: min,
+				MaxValue: max,
+			},
+		}
+	}
+
+	testData := []struct {
+		Name      string
+		Base      providerjson.SchemaJSON
+		Current   providerjson.SchemaJSON
+		Violation bool
+	}{
+		{
+			Name:    "Allowed Values Unchanged",
+			Base:    values("Basic", "Standard"),
+			Current: values("Basic", "Standard"),
+		},
+		{
+			Name:    "Allowed Value Added",
+			Base:    values("Basic", "Standard"),
+			Current: values("Basic", "Premium", "Standard"),
+		},
+		{
+			Name:      "Allowed Value Removed",
+			Base:      values("Basic", "Standard"),
+			Current:   values("Standard"),
+			Violation: true,
+		},
+		{
+			Name: "Validation Not Captured",
+			Base: providerjson.SchemaJSON{
+				Type:     providerjson.SchemaTypeString,
+				Optional: true,
+			},
+			Current: values("Standard"),
+		},
+		{
+			Name:    "Allowed Values Removed",
+			Base:    values("Standard"),
+			Current: providerjson.SchemaJSON{Type: providerjson.SchemaTypeString, Optional: true},
+		},
+		{
+			Name:    "Range Unchanged",
+			Base:    between(pointer.To(1), pointer.To(10)),
+			Current: between(pointer.To(1), pointer.To(10)),
+		},
+		{
+			Name:    "Range Widened",
+			Base:    between(pointer.To(1), pointer.To(10)),
+			Current: between(pointer.To(0), pointer.To(100)),
+		},
+		{
+			Name:      "Minimum Increased",
+			Base:      between(pointer.To(1), pointer.To(10)),
+			Current:   between(pointer.To(2), pointer.To(10)),
+			Violation: true,
+		},
+		{
+			Name:      "Maximum Reduced",
+			Base:      between(pointer.To(1), pointer.To(10)),
+			Current:   between(pointer.To(1), pointer.To(5)),
+			Violation: true,
+		},
+		{
+			Name:      "Maximum Added",
+			Base:      between(pointer.To(1), nil),
+			Current:   between(pointer.To(1), pointer.To(5)),
+			Violation: true,
+		},
+		{
+			Name: "Computed Only",
+			Base: values("Basic", "Standard"),
+			Current: providerjson.SchemaJSON{
+				Type:     providerjson.SchemaTypeString,
+				Computed: true,
+				Validation: &providerjson.ValidationJSON{
+					AllowedValues: []string{"Standard"},
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		res := validationStricter{}.Check(v.Base, v.Current, "example")
+		if v.Violation && res == nil {
+			t.Fatalf("expected violation, but didn't get one")
+		}
+		if !v.Violation && res != nil {
+			t.Fatalf("expected no violation, got %+v", *res)
+		}
+	}
+}